@@ -0,0 +1,41 @@
+package connectors
+
+import (
+	"context"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// DefaultBulkPollInterval is how often WaitForBulkWrite checks the job unless configured otherwise.
+const DefaultBulkPollInterval = 5 * time.Second
+
+// WaitForBulkWrite polls the job until it is done or the context is cancelled.
+// A zero interval means DefaultBulkPollInterval. The last known status is returned along with the context error.
+func WaitForBulkWrite(
+	ctx context.Context, conn BulkWriteConnector, jobId string, interval time.Duration,
+) (*common.BulkJobStatus, error) {
+	if interval <= 0 {
+		interval = DefaultBulkPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := conn.GetBulkWriteStatus(ctx, jobId)
+		if err != nil {
+			return nil, err
+		}
+
+		if status.IsDone() {
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+)
+
+var (
+	// ErrMissingBulkData is returned when no records were given for the bulk job.
+	ErrMissingBulkData = errors.New("no bulk data provided")
+	// ErrUnsupportedBulkMode is returned when the bulk write mode is unknown or not offered by the provider.
+	ErrUnsupportedBulkMode = errors.New("bulk write mode is not supported")
+	// ErrUnsupportedBulkFormat is returned when records are given in the format the provider cannot accept.
+	ErrUnsupportedBulkFormat = errors.New("bulk data format is not supported")
+	// ErrMissingExternalIdField is returned when upsert has no field to match existing records by.
+	ErrMissingExternalIdField = errors.New("external id field is required for upsert")
+)
+
+// maxJSONLineSize limits the size of a single JSON record.
+const maxJSONLineSize = 10 * 1024 * 1024
+
+// BulkWriteMode is the operation applied to every record of a bulk job.
+type BulkWriteMode string
+
+const (
+	BulkWriteModeInsert BulkWriteMode = "insert"
+	BulkWriteModeUpdate BulkWriteMode = "update"
+	BulkWriteModeUpsert BulkWriteMode = "upsert"
+	BulkWriteModeDelete BulkWriteMode = "delete"
+)
+
+// BulkDataFormat is the encoding of the records stream.
+type BulkDataFormat string
+
+const (
+	// BulkDataFormatCSV is a CSV file with a header row holding field names.
+	BulkDataFormatCSV BulkDataFormat = "csv"
+	// BulkDataFormatJSONLines has one JSON object per line.
+	BulkDataFormatJSONLines BulkDataFormat = "jsonl"
+)
+
+// BulkWriteParams defines a bulk job writing many records of a single object.
+type BulkWriteParams struct {
+	// The name of the object we are writing, e.g. "Account"
+	ObjectName string // required
+	// Mode is the operation applied to records.
+	Mode BulkWriteMode // required
+	// ExternalIdField is the field used to match existing records on upsert, e.g. "external_id__c".
+	ExternalIdField string // required for upsert only
+	// Format of Data, CSV is assumed when empty.
+	Format BulkDataFormat // optional
+	// Data is a stream of records. Update and delete records must carry record identifiers.
+	Data io.Reader // required
+}
+
+func (p BulkWriteParams) ValidateParams() error {
+	if len(p.ObjectName) == 0 {
+		return ErrMissingObjects
+	}
+
+	switch p.Mode {
+	case BulkWriteModeInsert, BulkWriteModeUpdate, BulkWriteModeDelete:
+	case BulkWriteModeUpsert:
+		if len(p.ExternalIdField) == 0 {
+			return ErrMissingExternalIdField
+		}
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedBulkMode, p.Mode)
+	}
+
+	switch p.Format {
+	case "", BulkDataFormatCSV, BulkDataFormatJSONLines:
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedBulkFormat, p.Format)
+	}
+
+	if p.Data == nil {
+		return ErrMissingBulkData
+	}
+
+	return nil
+}
+
+// BulkJobState is the provider-neutral stage of a bulk job.
+type BulkJobState string
+
+const (
+	// BulkJobStateInProgress means records are still being uploaded or processed.
+	BulkJobStateInProgress BulkJobState = "inProgress"
+	// BulkJobStateComplete means every record was processed, some of them may have failed.
+	BulkJobStateComplete BulkJobState = "complete"
+	// BulkJobStateFailed means the job failed as a whole.
+	BulkJobStateFailed BulkJobState = "failed"
+	// BulkJobStateAborted means the job was cancelled.
+	BulkJobStateAborted BulkJobState = "aborted"
+)
+
+// BulkJob is a handle of a started bulk job.
+type BulkJob struct {
+	// JobId identifies the job when asking for its status and results.
+	JobId string `json:"jobId"`
+	// State right after the job was started.
+	// Providers with synchronous batch APIs report a complete job straight away.
+	State BulkJobState `json:"state"`
+}
+
+// BulkJobStatus describes the progress of a bulk job.
+type BulkJobStatus struct {
+	JobId            string       `json:"jobId"`
+	State            BulkJobState `json:"state"`
+	RecordsProcessed int64        `json:"recordsProcessed"`
+	RecordsFailed    int64        `json:"recordsFailed"`
+	// Message explains why the job failed or was aborted.
+	Message string `json:"message,omitempty"`
+	// ProviderState is the job state as reported by the provider.
+	ProviderState string `json:"providerState,omitempty"`
+}
+
+// IsDone is true once the job reached a terminal state.
+func (s BulkJobStatus) IsDone() bool {
+	return s.State != BulkJobStateInProgress
+}
+
+// BulkWriteResults holds the outcome of every processed record of a bulk job.
+type BulkWriteResults struct {
+	JobId string `json:"jobId"`
+	// Records has a result per record, Data holds the record fields as they were sent.
+	// The order of records may differ from the order of the input.
+	Records []WriteResult `json:"records"`
+}
+
+// JSONLinesToCSV converts a stream of JSON objects, one per line, to CSV.
+// Columns are the sorted union of fields across all records. Nested objects are flattened
+// using dotted names, e.g. {"Account":{"Name":"Acme"}} becomes column "Account.Name",
+// arrays are written as JSON, and missing values are left empty.
+// Null values are written as nullValue, which lets providers tell clearing a field apart from leaving it unchanged.
+func JSONLinesToCSV(data io.Reader, nullValue string) ([]byte, error) {
+	records := make([]map[string]string, 0)
+	columns := make(map[string]bool)
+
+	scanner := bufio.NewScanner(data)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxJSONLineSize)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		// Numbers are kept as written, large identifiers would lose precision as float64.
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.UseNumber()
+
+		var object map[string]any
+		if err := decoder.Decode(&object); err != nil {
+			return nil, fmt.Errorf("%w: line %v: %w", ErrRecordDataNotJSON, line, err)
+		}
+
+		record := make(map[string]string)
+		if err := flattenJSONObject("", object, nullValue, record); err != nil {
+			return nil, fmt.Errorf("%w: line %v: %w", ErrRecordDataNotJSON, line, err)
+		}
+
+		for column := range record {
+			columns[column] = true
+		}
+
+		records = append(records, record)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	header := make([]string, 0, len(columns))
+	for column := range columns {
+		header = append(header, column)
+	}
+
+	slices.Sort(header)
+
+	var output bytes.Buffer
+
+	writer := csv.NewWriter(&output)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		row := make([]string, len(header))
+		for index, column := range header {
+			row[index] = record[column]
+		}
+
+		if err := writer.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+
+	return output.Bytes(), writer.Error()
+}
+
+func flattenJSONObject(prefix string, object map[string]any, nullValue string, output map[string]string) error {
+	for key, value := range object {
+		name := key
+		if len(prefix) != 0 {
+			name = prefix + "." + key
+		}
+
+		switch val := value.(type) {
+		case nil:
+			output[name] = nullValue
+		case string:
+			output[name] = val
+		case bool:
+			output[name] = strconv.FormatBool(val)
+		case json.Number:
+			output[name] = val.String()
+		case map[string]any:
+			if err := flattenJSONObject(name, val, nullValue, output); err != nil {
+				return err
+			}
+		default:
+			encoded, err := json.Marshal(val)
+			if err != nil {
+				return err
+			}
+
+			output[name] = string(encoded)
+		}
+	}
+
+	return nil
+}
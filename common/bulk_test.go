@@ -0,0 +1,56 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestJSONLinesToCSV(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        string
+		nullValue    string
+		expected     string
+		expectedErrs []error
+	}{
+		{
+			name: "Columns are the union of fields, nested objects are flattened",
+			input: `{"Name":"Acme","Id":90071992547409934,"Account":{"Number":"A-1"}}
+
+{"Name":"Globex, Inc.","Active":true,"Tags":["a","b"],"Note":null}`,
+			expected: "Account.Number,Active,Id,Name,Note,Tags\n" +
+				"A-1,,90071992547409934,Acme,,\n" +
+				`,true,,"Globex, Inc.",,"[""a"",""b""]"` + "\n",
+		},
+		{
+			name:      "Null values are written as the null token",
+			input:     `{"Name":"Acme","Phone":null,"Account":{"Number":null}}`,
+			nullValue: "#N/A",
+			expected:  "Account.Number,Name,Phone\n#N/A,Acme,#N/A\n",
+		},
+		{
+			name:         "Every line must be an object",
+			input:        "{\"Name\":\"Acme\"}\n[1,2]",
+			expectedErrs: []error{ErrRecordDataNotJSON},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := JSONLinesToCSV(strings.NewReader(tt.input), tt.nullValue)
+			testutils.CheckErrors(t, tt.name, tt.expectedErrs, err)
+
+			if err == nil && string(output) != tt.expected {
+				t.Fatalf("%s: expected (%q), got (%q)", tt.name, tt.expected, string(output))
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package common
+
+// ValueType is a provider-neutral type of field value.
+type ValueType string
+
+const (
+	ValueTypeString       ValueType = "string"
+	ValueTypeBoolean      ValueType = "boolean"
+	ValueTypeInt          ValueType = "int"
+	ValueTypeFloat        ValueType = "float"
+	ValueTypeDate         ValueType = "date"
+	ValueTypeDateTime     ValueType = "datetime"
+	ValueTypeSingleSelect ValueType = "singleSelect"
+	ValueTypeMultiSelect  ValueType = "multiSelect"
+	ValueTypeReference    ValueType = "reference"
+	// ValueTypeOther is used when provider type has no neutral equivalent. Check ProviderType instead.
+	ValueTypeOther ValueType = "other"
+)
+
+// FieldMetadata describes a single field of an object.
+// Flags are pointers, nil means the provider doesn't report this property.
+type FieldMetadata struct {
+	// DisplayName is the provider's label for the field.
+	DisplayName string `json:"displayName"`
+
+	// ValueType is the type of the field value.
+	ValueType ValueType `json:"valueType,omitempty"`
+
+	// ProviderType is the raw type as reported by the provider, ex: "picklist", "enumeration".
+	ProviderType string `json:"providerType,omitempty"`
+
+	// Required means the value must be provided when creating a record.
+	Required *bool `json:"required,omitempty"`
+
+	// Nullable means the field can be empty.
+	Nullable *bool `json:"nullable,omitempty"`
+
+	// Createable means the field can be set when creating a record.
+	Createable *bool `json:"createable,omitempty"`
+
+	// Updateable means the field can be changed when updating a record.
+	Updateable *bool `json:"updateable,omitempty"`
+
+	// Values lists allowed options for select fields.
+	Values []FieldValue `json:"values,omitempty"`
+
+	// ReferenceTo lists object names this field can point to.
+	ReferenceTo []string `json:"referenceTo,omitempty"`
+}
+
+// FieldValue is one of the options of a select field.
+type FieldValue struct {
+	// Value is what is stored in the record.
+	Value string `json:"value"`
+
+	// DisplayValue is the label shown to users.
+	DisplayValue string `json:"displayValue"`
+}
+
+// IsReadOnly returns true if the field is known to be neither createable nor updateable.
+func (f FieldMetadata) IsReadOnly() bool {
+	return f.Createable != nil && !*f.Createable &&
+		f.Updateable != nil && !*f.Updateable
+}
+
+// AddField registers a field in both FieldsMap and Fields.
+func (m *ObjectMetadata) AddField(name string, field FieldMetadata) {
+	if m.FieldsMap == nil {
+		m.FieldsMap = make(map[string]string)
+	}
+
+	if m.Fields == nil {
+		m.Fields = make(map[string]FieldMetadata)
+	}
+
+	m.FieldsMap[name] = field.DisplayName
+	m.Fields[name] = field
+}
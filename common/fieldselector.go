@@ -0,0 +1,256 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidFieldSelector is returned when a field of ReadParams cannot be understood as a selector.
+var ErrInvalidFieldSelector = errors.New("invalid field selector")
+
+// FieldSelector locates a value inside ReadResultRow.Raw. It is parsed from a field of ReadParams,
+// which can be written in one of the forms:
+//
+//	"email"                          top level key
+//	"properties.firstname"           dotted path of nested objects
+//	"$.attributes.emails[0]"         JSONPath with dot notation and array indices
+//	"$['@odata.etag']"               JSONPath with bracket notation, allowing keys having dots
+//
+// Keys are matched case-insensitively, an exact match is preferred.
+// A key which exists in the record as written, dots included, takes precedence over the path.
+// Only fields starting with "$." or "$[" are JSONPath, other fields starting with "$" are keys.
+// A dotted field having empty segments, such as "a..b", is a single key.
+type FieldSelector struct {
+	// Field is the selector as requested.
+	Field string
+	steps []selectorStep
+}
+
+// selectorStep is either an object key or an array index.
+type selectorStep struct {
+	key   string
+	index int
+	isKey bool
+}
+
+// ParseFieldSelector converts a field into a selector.
+func ParseFieldSelector(field string) (*FieldSelector, error) {
+	if len(field) == 0 {
+		return nil, fmt.Errorf("%w: empty field", ErrInvalidFieldSelector)
+	}
+
+	var (
+		steps []selectorStep
+		err   error
+	)
+
+	if isJSONPath(field) {
+		steps, err = parseJSONPath(field)
+	} else {
+		steps, err = parseDottedPath(field)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q %w", ErrInvalidFieldSelector, field, err)
+	}
+
+	return &FieldSelector{
+		Field: field,
+		steps: steps,
+	}, nil
+}
+
+// IsNested tells if the selector reaches past the top level keys.
+func (s FieldSelector) IsNested() bool {
+	return len(s.steps) > 1 || (len(s.steps) == 1 && !s.steps[0].isKey)
+}
+
+// Root returns the top level key the selector starts with.
+func (s FieldSelector) Root() string {
+	return s.Key(0)
+}
+
+// Key returns the object key at the given depth of the selector.
+// Empty string is returned for array indices and depths beyond the selector.
+func (s FieldSelector) Key(depth int) string {
+	if depth < 0 || depth >= len(s.steps) || !s.steps[depth].isKey {
+		return ""
+	}
+
+	return s.steps[depth].key
+}
+
+// Lookup returns the value of the record located by the selector.
+func (s FieldSelector) Lookup(record map[string]any) (any, bool) {
+	if !isJSONPath(s.Field) {
+		// Keys may have dots, the whole field is tried as one key first.
+		if value, ok := lookupKey(record, s.Field); ok {
+			return value, true
+		}
+	}
+
+	var current any = record
+
+	for _, step := range s.steps {
+		var ok bool
+
+		if step.isKey {
+			object, isObject := current.(map[string]any)
+			if !isObject {
+				return nil, false
+			}
+
+			current, ok = lookupKey(object, step.key)
+		} else {
+			current, ok = lookupIndex(current, step.index)
+		}
+
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// ExtractFieldsFromRaw returns the values of a record located by the fields, see FieldSelector.
+// Result keys are fields as requested, lowercased unless preserveCase is set.
+// Fields which are not present in the record are omitted.
+func ExtractFieldsFromRaw(fields []string, record map[string]any, preserveCase bool) map[string]any {
+	out := make(map[string]any, len(fields))
+
+	for _, field := range fields {
+		selector, err := ParseFieldSelector(field)
+		if err != nil {
+			continue
+		}
+
+		value, ok := selector.Lookup(record)
+		if !ok {
+			continue
+		}
+
+		if preserveCase {
+			out[field] = value
+		} else {
+			out[strings.ToLower(field)] = value
+		}
+	}
+
+	return out
+}
+
+func lookupKey(object map[string]any, key string) (any, bool) {
+	if value, ok := object[key]; ok {
+		return value, true
+	}
+
+	for name, value := range object {
+		if strings.EqualFold(name, key) {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+func lookupIndex(current any, index int) (any, bool) {
+	array, ok := current.([]any)
+	if !ok {
+		return nil, false
+	}
+
+	if index < 0 {
+		index += len(array)
+	}
+
+	if index < 0 || index >= len(array) {
+		return nil, false
+	}
+
+	return array[index], true
+}
+
+func isJSONPath(field string) bool {
+	return strings.HasPrefix(field, "$.") || strings.HasPrefix(field, "$[")
+}
+
+func parseDottedPath(field string) ([]selectorStep, error) {
+	parts := strings.Split(field, ".")
+	steps := make([]selectorStep, len(parts))
+
+	for i, part := range parts {
+		if len(part) == 0 {
+			// Not a path, the field is a key which happens to have dots.
+			return []selectorStep{{key: field, isKey: true}}, nil
+		}
+
+		steps[i] = selectorStep{key: part, isKey: true}
+	}
+
+	return steps, nil
+}
+
+// parseJSONPath supports the subset of JSONPath which refers to a single value,
+// wildcards, slices, filters and recursive descent are not supported.
+func parseJSONPath(field string) ([]selectorStep, error) { // nolint:cyclop
+	var steps []selectorStep
+
+	rest := field[1:]
+
+	for len(rest) != 0 {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+
+			if end == -1 {
+				end = len(rest)
+			}
+
+			key := rest[:end]
+			if len(key) == 0 || key == "*" || key == "." {
+				return nil, errors.New("unsupported key")
+			}
+
+			steps = append(steps, selectorStep{key: key, isKey: true})
+			rest = rest[end:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, errors.New("unclosed bracket")
+			}
+
+			step, err := parseBracket(rest[1:end])
+			if err != nil {
+				return nil, err
+			}
+
+			steps = append(steps, step)
+			rest = rest[end+1:]
+		default:
+			return nil, errors.New("unexpected character")
+		}
+	}
+
+	if len(steps) == 0 {
+		return nil, errors.New("root is not a field")
+	}
+
+	return steps, nil
+}
+
+func parseBracket(content string) (selectorStep, error) {
+	if len(content) >= 2 && (content[0] == '\'' || content[0] == '"') && content[len(content)-1] == content[0] {
+		return selectorStep{key: content[1 : len(content)-1], isKey: true}, nil
+	}
+
+	index, err := strconv.Atoi(content)
+	if err != nil {
+		return selectorStep{}, errors.New("unsupported index")
+	}
+
+	return selectorStep{index: index}, nil
+}
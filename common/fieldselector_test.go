@@ -0,0 +1,124 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestExtractFieldsFromRaw(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	record := map[string]any{
+		"id":          "7",
+		"Email":       "alice@example.com",
+		"@odata.etag": "W/\"4372108\"",
+		"$type":       "contact",
+		"notes..":     "draft",
+		"properties": map[string]any{
+			"firstname": "Alice",
+			"LastName":  "Smith",
+		},
+		"attributes": map[string]any{
+			"emails": []any{"alice@example.com", "a.smith@example.com"},
+			"address": map[string]any{
+				"city.name": "Paris",
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		fields       []string
+		preserveCase bool
+		expected     map[string]any
+	}{
+		{
+			name:     "Top level fields are lowercased",
+			fields:   []string{"ID", "email"},
+			expected: map[string]any{"id": "7", "email": "alice@example.com"},
+		},
+		{
+			name:     "Dotted path",
+			fields:   []string{"properties.firstname", "Properties.lastname"},
+			expected: map[string]any{"properties.firstname": "Alice", "properties.lastname": "Smith"},
+		},
+		{
+			name:     "Key having dots wins over path",
+			fields:   []string{"@odata.etag"},
+			expected: map[string]any{"@odata.etag": "W/\"4372108\""},
+		},
+		{
+			name:   "JSONPath with indices and brackets",
+			fields: []string{"$.attributes.emails[1]", "$.attributes.emails[-1]", "$['attributes']['address']['city.name']"},
+			expected: map[string]any{
+				"$.attributes.emails[1]":                  "a.smith@example.com",
+				"$.attributes.emails[-1]":                 "a.smith@example.com",
+				"$['attributes']['address']['city.name']": "Paris",
+			},
+		},
+		{
+			name:     "Fields which are neither JSONPath nor dotted path are keys",
+			fields:   []string{"$type", "notes.."},
+			expected: map[string]any{"$type": "contact", "notes..": "draft"},
+		},
+		{
+			name:         "Casing is preserved",
+			fields:       []string{"Email", "properties.LastName"},
+			preserveCase: true,
+			expected:     map[string]any{"Email": "alice@example.com", "properties.LastName": "Smith"},
+		},
+		{
+			name:     "Missing and invalid fields are omitted",
+			fields:   []string{"phone", "properties.firstname.first", "$.attributes.emails[5]", "$..id"},
+			expected: map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output := ExtractFieldsFromRaw(tt.fields, record, tt.preserveCase)
+			if !reflect.DeepEqual(output, tt.expected) {
+				t.Fatalf("%s: expected: (%v), got: (%v)", tt.name, tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestParseFieldSelector(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        string
+		expectedErrs []error
+	}{
+		{name: "Plain field", input: "email"},
+		{name: "Dotted path", input: "properties.firstname"},
+		{name: "JSONPath", input: "$.emails[0].value"},
+		{name: "Empty field", input: "", expectedErrs: []error{ErrInvalidFieldSelector}},
+		{name: "Key with empty segments", input: "properties..firstname"},
+		{name: "Key with dollar sign", input: "$type"},
+		{name: "Dollar sign alone is a key", input: "$"},
+		{name: "Root only", input: "$.", expectedErrs: []error{ErrInvalidFieldSelector}},
+		{name: "Wildcard", input: "$.emails[*]", expectedErrs: []error{ErrInvalidFieldSelector}},
+		{name: "Recursive descent", input: "$..email", expectedErrs: []error{ErrInvalidFieldSelector}},
+		{name: "Unclosed bracket", input: "$['email'", expectedErrs: []error{ErrInvalidFieldSelector}},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseFieldSelector(tt.input)
+			testutils.CheckErrors(t, tt.name, tt.expectedErrs, err)
+		})
+	}
+}
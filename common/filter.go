@@ -0,0 +1,166 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidFilter is returned when filter expression is malformed.
+var ErrInvalidFilter = errors.New("invalid filter expression")
+
+// FilterOperator is an operation of a filter expression node.
+type FilterOperator string
+
+const (
+	// FilterOperatorEq matches records where the field equals the value.
+	FilterOperatorEq FilterOperator = "eq"
+	// FilterOperatorNe matches records where the field doesn't equal the value.
+	FilterOperatorNe FilterOperator = "ne"
+	// FilterOperatorGt matches records where the field is greater than the value.
+	FilterOperatorGt FilterOperator = "gt"
+	// FilterOperatorLt matches records where the field is less than the value.
+	FilterOperatorLt FilterOperator = "lt"
+	// FilterOperatorIn matches records where the field equals any of the values.
+	FilterOperatorIn FilterOperator = "in"
+	// FilterOperatorContains matches records where the text field contains the value.
+	FilterOperatorContains FilterOperator = "contains"
+	// FilterOperatorAnd matches records satisfying every operand.
+	FilterOperatorAnd FilterOperator = "and"
+	// FilterOperatorOr matches records satisfying at least one operand.
+	FilterOperatorOr FilterOperator = "or"
+)
+
+// FilterExpr is a provider-neutral filter applied when reading records.
+// Leaf nodes compare Field against Value (or Values for "in"),
+// while "and"/"or" nodes combine Operands. Use constructor functions such as FilterEq and FilterAnd
+// to build an expression. Values are strings, numbers, booleans or time.Time,
+// each connector formats them according to its query language.
+// Connectors which cannot express the filter return ErrOperationNotSupportedForObject.
+type FilterExpr struct {
+	Operator FilterOperator
+	Field    string
+	Value    any
+	Values   []any
+	Operands []*FilterExpr
+}
+
+// FilterEq matches records where field equals value.
+func FilterEq(field string, value any) *FilterExpr {
+	return &FilterExpr{Operator: FilterOperatorEq, Field: field, Value: value}
+}
+
+// FilterNe matches records where field doesn't equal value.
+func FilterNe(field string, value any) *FilterExpr {
+	return &FilterExpr{Operator: FilterOperatorNe, Field: field, Value: value}
+}
+
+// FilterGt matches records where field is greater than value.
+func FilterGt(field string, value any) *FilterExpr {
+	return &FilterExpr{Operator: FilterOperatorGt, Field: field, Value: value}
+}
+
+// FilterLt matches records where field is less than value.
+func FilterLt(field string, value any) *FilterExpr {
+	return &FilterExpr{Operator: FilterOperatorLt, Field: field, Value: value}
+}
+
+// FilterIn matches records where field equals any of the values.
+func FilterIn(field string, values ...any) *FilterExpr {
+	return &FilterExpr{Operator: FilterOperatorIn, Field: field, Values: values}
+}
+
+// FilterContains matches records where text field contains the value.
+func FilterContains(field string, value string) *FilterExpr {
+	return &FilterExpr{Operator: FilterOperatorContains, Field: field, Value: value}
+}
+
+// FilterAnd matches records satisfying all operands.
+func FilterAnd(operands ...*FilterExpr) *FilterExpr {
+	return &FilterExpr{Operator: FilterOperatorAnd, Operands: operands}
+}
+
+// FilterOr matches records satisfying any of the operands.
+func FilterOr(operands ...*FilterExpr) *FilterExpr {
+	return &FilterExpr{Operator: FilterOperatorOr, Operands: operands}
+}
+
+// IsLogical returns true for nodes combining other expressions.
+func (f *FilterExpr) IsLogical() bool {
+	return f.Operator == FilterOperatorAnd || f.Operator == FilterOperatorOr
+}
+
+// Validate checks that every node of the expression is well-formed.
+func (f *FilterExpr) Validate() error {
+	if f == nil {
+		return fmt.Errorf("%w: empty expression", ErrInvalidFilter)
+	}
+
+	switch f.Operator {
+	case FilterOperatorAnd, FilterOperatorOr:
+		if len(f.Operands) == 0 {
+			return fmt.Errorf("%w: %v requires operands", ErrInvalidFilter, f.Operator)
+		}
+
+		for _, operand := range f.Operands {
+			if err := operand.Validate(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case FilterOperatorIn:
+		if len(f.Values) == 0 {
+			return fmt.Errorf("%w: %v requires values for field %v", ErrInvalidFilter, f.Operator, f.Field)
+		}
+	case FilterOperatorContains:
+		if _, ok := f.Value.(string); !ok {
+			return fmt.Errorf("%w: %v requires text value for field %v", ErrInvalidFilter, f.Operator, f.Field)
+		}
+	case FilterOperatorEq, FilterOperatorNe, FilterOperatorGt, FilterOperatorLt:
+	default:
+		return fmt.Errorf("%w: unknown operator %q", ErrInvalidFilter, f.Operator)
+	}
+
+	if len(f.Field) == 0 {
+		return fmt.Errorf("%w: %v requires field", ErrInvalidFilter, f.Operator)
+	}
+
+	return nil
+}
+
+// String returns human-readable form of the expression, used in error messages.
+func (f *FilterExpr) String() string {
+	if f == nil {
+		return ""
+	}
+
+	switch f.Operator { // nolint:exhaustive
+	case FilterOperatorAnd, FilterOperatorOr:
+		operands := make([]string, len(f.Operands))
+		for index, operand := range f.Operands {
+			operands[index] = operand.String()
+		}
+
+		return "(" + strings.Join(operands, " "+string(f.Operator)+" ") + ")"
+	case FilterOperatorIn:
+		return fmt.Sprintf("%v in %v", f.Field, f.Values)
+	default:
+		return fmt.Sprintf("%v %v %v", f.Field, f.Operator, f.Value)
+	}
+}
+
+// NewUnsupportedFilterError describes a filter expression which the connector cannot express.
+func NewUnsupportedFilterError(expr *FilterExpr, reason string) error {
+	return fmt.Errorf("%w: filter %v: %v", ErrOperationNotSupportedForObject, expr, reason)
+}
+
+// ValidateNoFilter is used by connectors which cannot filter records.
+// It returns ErrOperationNotSupportedForObject when FilterBy is set, rather than reading unfiltered records.
+func (p ReadParams) ValidateNoFilter() error {
+	if p.FilterBy != nil {
+		return NewUnsupportedFilterError(p.FilterBy, "connector cannot filter records")
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestFilterExprValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        *FilterExpr
+		expectedErrs []error
+	}{
+		{
+			name:  "Nested expression is valid",
+			input: FilterAnd(FilterEq("name", "Acme"), FilterOr(FilterGt("size", 5), FilterIn("tier", "A", "B"))),
+		},
+		{
+			name:         "Logical operator requires operands",
+			input:        FilterOr(),
+			expectedErrs: []error{ErrInvalidFilter},
+		},
+		{
+			name:         "Comparison requires field",
+			input:        FilterAnd(FilterEq("", "Acme")),
+			expectedErrs: []error{ErrInvalidFilter},
+		},
+		{
+			name:         "In requires values",
+			input:        FilterIn("tier"),
+			expectedErrs: []error{ErrInvalidFilter},
+		},
+		{
+			name:         "Unknown operator",
+			input:        &FilterExpr{Operator: "like", Field: "name", Value: "A%"},
+			expectedErrs: []error{ErrInvalidFilter},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			testutils.CheckErrors(t, tt.name, tt.expectedErrs, tt.input.Validate())
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// HealthReport describes the outcome of a connection check.
+type HealthReport struct {
+	// Healthy is true when the provider accepted the credentials.
+	Healthy bool `json:"healthy"`
+	// CheckedAt is the time when the check started.
+	CheckedAt time.Time `json:"checkedAt"`
+	// Latency is the round trip time of the check, including token refresh.
+	Latency time.Duration `json:"latency"`
+	// Account identifies the connected account or instance, when the provider reports it.
+	Account string `json:"account,omitempty"`
+	// Scopes granted to the credentials. Empty when the provider doesn't expose them.
+	Scopes []string `json:"scopes,omitempty"`
+	// Quota is the API usage allowance. Nil when the provider doesn't expose it.
+	Quota *APIQuota `json:"quota,omitempty"`
+}
+
+// APIQuota is the number of API calls allowed within a time window.
+type APIQuota struct {
+	// Limit is the total number of calls allowed within the window.
+	Limit int64 `json:"limit"`
+	// Remaining is the number of calls left within the window.
+	Remaining int64 `json:"remaining"`
+	// Window is the period the limit applies to, e.g. 24 hours for a daily limit.
+	Window time.Duration `json:"window,omitempty"`
+}
+
+// Headroom is the fraction of the quota left, ranging from 0 to 1.
+func (q APIQuota) Headroom() float64 {
+	if q.Limit <= 0 {
+		return 0
+	}
+
+	return float64(q.Remaining) / float64(q.Limit)
+}
+
+// ParseAPIQuota reads the limit and remaining calls from response headers.
+// Nil is returned when either header is missing or malformed.
+func ParseAPIQuota(headers http.Header, limitKey, remainingKey string, window time.Duration) *APIQuota {
+	limit, err := strconv.ParseInt(headers.Get(limitKey), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	remaining, err := strconv.ParseInt(headers.Get(remainingKey), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &APIQuota{
+		Limit:     limit,
+		Remaining: remaining,
+		Window:    window,
+	}
+}
+
+// ClassifyConnectionError makes sure that a failed connection check can be matched,
+// using errors.Is, against one of ErrAccessToken, ErrInvalidGrant, ErrForbidden or ErrApiDisabled.
+// Errors already carrying one of them are returned as is, otherwise 401 and 403 statuses are mapped.
+// ErrApiDisabled is recognised by provider specific error interpreters only.
+// Any other failure, for example a network error, is returned unchanged.
+func ClassifyConnectionError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	err = transformOauth2LibraryError(err)
+
+	if errors.Is(err, ErrAccessToken) || errors.Is(err, ErrInvalidGrant) ||
+		errors.Is(err, ErrForbidden) || errors.Is(err, ErrApiDisabled) {
+		return err
+	}
+
+	if errors.Is(err, ErrInvalidSessionId) {
+		return fmt.Errorf("%w: %w", ErrAccessToken, err)
+	}
+
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) {
+		return err
+	}
+
+	switch statusErr.HTTPStatus {
+	case http.StatusUnauthorized:
+		return fmt.Errorf("%w: %w", ErrAccessToken, err)
+	case http.StatusForbidden:
+		return fmt.Errorf("%w: %w", ErrForbidden, err)
+	default:
+		return err
+	}
+}
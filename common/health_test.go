@@ -0,0 +1,92 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/amp-labs/connectors/test/utils/testutils"
+	"golang.org/x/oauth2"
+)
+
+func TestClassifyConnectionError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        error
+		expectedErrs []error
+	}{
+		{
+			name:         "Unauthorized status is an invalid access token",
+			input:        NewHTTPStatusError(http.StatusUnauthorized, ErrCaller),
+			expectedErrs: []error{ErrAccessToken, ErrCaller},
+		},
+		{
+			name:         "Forbidden status is forbidden",
+			input:        NewHTTPStatusError(http.StatusForbidden, ErrCaller),
+			expectedErrs: []error{ErrForbidden, ErrCaller},
+		},
+		{
+			name:         "Invalid session is an invalid access token",
+			input:        ErrInvalidSessionId,
+			expectedErrs: []error{ErrAccessToken, ErrInvalidSessionId},
+		},
+		{
+			name: "Rejected refresh token is an invalid grant",
+			input: &url.Error{Op: "Post", URL: "https://example.com/token", Err: &oauth2.RetrieveError{
+				ErrorCode: "invalid_grant",
+			}},
+			expectedErrs: []error{ErrInvalidGrant},
+		},
+		{
+			name:         "Known errors are kept",
+			input:        NewHTTPStatusError(http.StatusForbidden, ErrApiDisabled),
+			expectedErrs: []error{ErrApiDisabled},
+		},
+		{
+			name:         "Other errors are unchanged",
+			input:        NewHTTPStatusError(http.StatusInternalServerError, ErrServer),
+			expectedErrs: []error{ErrServer},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			testutils.CheckErrors(t, tt.name, tt.expectedErrs, ClassifyConnectionError(tt.input))
+		})
+	}
+}
+
+func TestOAuthTokenScopes(t *testing.T) {
+	t.Parallel()
+
+	token := (&oauth2.Token{AccessToken: "secret"}).WithExtra(map[string]any{
+		"scope": "api refresh_token,offline_access",
+	})
+
+	client, err := NewOAuthHTTPClient(context.Background(), WithTokenSource(oauth2.StaticTokenSource(token)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current, ok, err := OAuthToken(context.Background(), client)
+	if err != nil || !ok {
+		t.Fatalf("expected OAuth token, got %v, %v", ok, err)
+	}
+
+	expected := []string{"api", "refresh_token", "offline_access"}
+	if scopes := OAuthTokenScopes(current); !reflect.DeepEqual(scopes, expected) {
+		t.Fatalf("expected scopes %v, got %v", expected, scopes)
+	}
+
+	if _, ok, _ := OAuthToken(context.Background(), http.DefaultClient); ok {
+		t.Fatal("client without OAuth must have no token")
+	}
+}
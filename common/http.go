@@ -34,6 +34,9 @@ type HTTPClient struct {
 	Client          AuthenticatedHTTPClient // underlying HTTP client. Required.
 	ErrorHandler    ErrorHandler            // optional error handler. If not set, then the default error handler is used.
 	ResponseHandler ResponseHandler         // optional, Allows mutation of the http.Response from the Saas API response.
+	RetryPolicy     *RetryPolicy            // optional. If not set, then requests are not retried.
+	IsRetryable     RetryableErrorFunc      // optional, decides which errors are retried. Defaults to IsRetryableError.
+	RateLimiter     RateLimiter             // optional. If set, every request waits for its turn before being sent.
 }
 
 // getURL returns the base prefixed URL.
@@ -264,7 +267,32 @@ func makeDeleteRequest(ctx context.Context, url string, headers []Header) (*http
 }
 
 // sendRequest sends the given request and returns the response & response body.
-func (h *HTTPClient) sendRequest(req *http.Request) (*http.Response, []byte, error) { //nolint:cyclop
+// If the retry policy is set, failed requests are sent again.
+func (h *HTTPClient) sendRequest(req *http.Request) (*http.Response, []byte, error) {
+	if h.RetryPolicy != nil {
+		return h.sendWithRetries(req)
+	}
+
+	res, body, err := h.sendRequestOnce(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res, body, nil
+}
+
+// sendRequestOnce sends the given request and returns the response & response body.
+// On error the response is still returned when available, so that its headers can be inspected.
+func (h *HTTPClient) sendRequestOnce(req *http.Request) (*http.Response, []byte, error) { //nolint:cyclop
+	if h.RateLimiter != nil {
+		release, err := h.RateLimiter.Acquire(req.Context())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		defer release()
+	}
+
 	// Send the request
 	res, err := h.Client.Do(req)
 	if err != nil {
@@ -296,11 +324,17 @@ func (h *HTTPClient) sendRequest(req *http.Request) (*http.Response, []byte, err
 
 	// Check the response status code
 	if res.StatusCode < 200 || res.StatusCode > 299 {
+		errorHandler := InterpretError
 		if h.ErrorHandler != nil {
-			return nil, nil, h.ErrorHandler(res, body)
+			errorHandler = h.ErrorHandler
+		}
+
+		if err = errorHandler(res, body); err == nil {
+			// Error was ignored by the handler, the caller is responsible for it.
+			return nil, nil, nil
 		}
 
-		return nil, nil, InterpretError(res, body)
+		return res, nil, err
 	}
 
 	return res, body, nil
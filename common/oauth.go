@@ -3,6 +3,7 @@ package common
 import (
 	"context"
 	"net/http"
+	"strings"
 	"sync"
 
 	"golang.org/x/oauth2"
@@ -43,6 +44,54 @@ func NewOAuthHTTPClient(ctx context.Context, opts ...OAuthOption) (Authenticated
 	return newOAuthClient(ctx, params), nil
 }
 
+// OAuthToken returns the current token of a client created by NewOAuthHTTPClient.
+// The token is refreshed if it has expired. False is returned for clients using any other authentication.
+func OAuthToken(ctx context.Context, client AuthenticatedHTTPClient) (*oauth2.Token, bool, error) {
+	httpClient, ok := client.(*http.Client)
+	if !ok {
+		return nil, false, nil
+	}
+
+	transport, ok := httpClient.Transport.(*oauth2Transport)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var (
+		token *oauth2.Token
+		err   error
+	)
+
+	if srcCtx, ok := transport.Source.(TokenSourceWithContext); ok {
+		token, err = srcCtx.TokenWithContext(ctx)
+	} else {
+		token, err = transport.Source.Token()
+	}
+
+	if err != nil {
+		return nil, true, transformOauth2LibraryError(err)
+	}
+
+	return token, true, nil
+}
+
+// OAuthTokenScopes returns scopes granted to the token, as listed by the token endpoint response.
+// Scopes are space separated according to RFC 6749, some providers separate them by commas.
+func OAuthTokenScopes(token *oauth2.Token) []string {
+	if token == nil {
+		return nil
+	}
+
+	scope, ok := token.Extra("scope").(string)
+	if !ok {
+		return nil
+	}
+
+	return strings.FieldsFunc(scope, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+}
+
 // oauthClientParams is the internal configuration for the oauth http client.
 type oauthClientParams struct {
 	client       *http.Client
@@ -0,0 +1,83 @@
+package pagination
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/amp-labs/connectors/common/jsonquery"
+	"github.com/spyzhov/ajson"
+)
+
+// hasMore reads the boolean flag of more pages. Without the flag more pages are assumed.
+func hasMore(node *ajson.Node, path string) (bool, error) {
+	if len(path) == 0 {
+		return true, nil
+	}
+
+	zoom, key := SplitPath(path)
+
+	more, err := jsonquery.New(node, zoom...).Bool(key, true)
+	if err != nil {
+		return false, err
+	}
+
+	return more != nil && *more, nil
+}
+
+// hasMoreOrFull prefers the flag of more pages if it is known, otherwise a full page implies the next one.
+func hasMoreOrFull(node *ajson.Node, path string, size int64, pageSize int) (bool, error) {
+	if len(path) != 0 {
+		return hasMore(node, path)
+	}
+
+	return size != 0 && size >= int64(pageSize), nil
+}
+
+func countRecords(node *ajson.Node, path string) (int64, error) {
+	zoom, key := SplitPath(path)
+
+	return jsonquery.New(node, zoom...).ArraySize(key)
+}
+
+// textAt returns a string or a number located under the dotted path. Missing and null values are empty.
+// Keys having dots themselves, such as "@odata.nextLink", are matched before the path is split.
+func textAt(node *ajson.Node, path string) (string, error) {
+	zoom, key := SplitPath(path)
+	if node.IsObject() && node.HasKey(path) {
+		zoom, key = nil, path
+	}
+
+	query := jsonquery.New(node, zoom...)
+
+	text, err := query.Str(key, true)
+	if err == nil {
+		if text == nil {
+			return "", nil
+		}
+
+		return *text, nil
+	}
+
+	number, numErr := query.Integer(key, true)
+	if numErr != nil {
+		return "", err
+	}
+
+	if number == nil {
+		return "", nil
+	}
+
+	return strconv.FormatInt(*number, 10), nil
+}
+
+// SplitPath separates the dotted path into objects to zoom into and the final key, as used by jsonquery.New.
+// Empty path refers to the node itself.
+func SplitPath(path string) ([]string, string) {
+	if len(path) == 0 {
+		return nil, ""
+	}
+
+	parts := strings.Split(path, ".")
+
+	return parts[:len(parts)-1], parts[len(parts)-1]
+}
@@ -0,0 +1,125 @@
+// Package pagination holds reusable strategies of moving through pages of a list endpoint.
+//
+// Every strategy turns a list response into common.NextPageFunc and rebuilds the request URL
+// from the token it produced. Tokens are encoded, so that callers cannot tell or change
+// offsets or provider URLs. URLs of the next page are only followed when they point
+// to the same host as the first page.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/urlbuilder"
+)
+
+var (
+	// ErrInvalidToken is returned when the next page token was not produced by the strategy.
+	ErrInvalidToken = errors.New("next page token is invalid")
+	// ErrForeignURL is returned when the next page is located at another host than the first page.
+	ErrForeignURL = errors.New("next page URL points to another host")
+)
+
+// Strategy knows where a provider announces the next page and how that page is requested.
+type Strategy interface {
+	// PageURL returns the URL of the page the token refers to, starting from the URL of the first page.
+	// Empty token refers to the first page. The first page URL is not modified.
+	PageURL(firstPage *urlbuilder.URL, token common.NextPageToken) (*urlbuilder.URL, error)
+	// NextPage returns the function producing the token of the following page.
+	// It is given the URL and response headers of the current page.
+	// Empty token means there are no more pages.
+	NextPage(page *urlbuilder.URL, headers http.Header) common.NextPageFunc
+}
+
+// pageState is what a token remembers about the next page. Only one field is used by any strategy.
+type pageState struct {
+	Cursor string `json:"c,omitempty"`
+	Offset int64  `json:"o,omitempty"`
+	Page   int64  `json:"p,omitempty"`
+	URL    string `json:"u,omitempty"`
+}
+
+func encodeToken(state pageState) (common.NextPageToken, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+
+	return common.NextPageToken(base64.RawURLEncoding.EncodeToString(data)), nil
+}
+
+func decodeToken(token common.NextPageToken) (*pageState, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token.String())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	var state pageState
+	if err = json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	return &state, nil
+}
+
+// clonePage copies the URL, so that page parameters are not set on the caller's instance.
+func clonePage(page *urlbuilder.URL) (*urlbuilder.URL, error) {
+	return urlbuilder.New(page.String())
+}
+
+// withPageSize sets the page size when the provider accepts it.
+func withPageSize(page *urlbuilder.URL, param string, size int) {
+	if len(param) != 0 && size > 0 {
+		page.WithQueryParam(param, fmt.Sprint(size))
+	}
+}
+
+// encodeURL makes a token of the next page URL, relative URLs are resolved against the current page.
+func encodeURL(page *urlbuilder.URL, next string) (string, error) {
+	if len(next) == 0 {
+		return "", nil
+	}
+
+	current, err := page.ToURL()
+	if err != nil {
+		return "", err
+	}
+
+	target, err := current.Parse(next)
+	if err != nil {
+		return "", errors.Join(err, urlbuilder.ErrInvalidURL)
+	}
+
+	token, err := encodeToken(pageState{URL: target.String()})
+
+	return token.String(), err
+}
+
+// decodeURL returns the next page URL of the token, which must share the host with the first page.
+func decodeURL(firstPage *urlbuilder.URL, token common.NextPageToken) (*urlbuilder.URL, error) {
+	state, err := decodeToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	first, err := firstPage.ToURL()
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := url.Parse(state.URL)
+	if err != nil || len(state.URL) == 0 {
+		return nil, ErrInvalidToken
+	}
+
+	if target.Scheme != first.Scheme || target.Host != first.Host {
+		return nil, fmt.Errorf("%w: %v", ErrForeignURL, target.Host)
+	}
+
+	return urlbuilder.New(state.URL)
+}
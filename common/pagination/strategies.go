@@ -0,0 +1,302 @@
+package pagination
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/urlbuilder"
+	"github.com/spyzhov/ajson"
+)
+
+// Cursor reads an opaque cursor from the body, which is sent back as a query parameter.
+// Example: Gong "records.cursor" sent as "cursor", Zoho "info.next_page_token" sent as "page_token".
+type Cursor struct {
+	// Path is the dotted location of the cursor, e.g. "paging.next.after". Missing or null cursor ends reading.
+	Path string
+	// Param is the query parameter carrying the cursor.
+	Param string
+	// HasMorePath is the dotted location of a boolean telling if more pages exist. Optional.
+	HasMorePath string
+	// SizeParam is the query parameter with the number of records per page. Optional.
+	SizeParam string
+	// PageSize is sent via SizeParam.
+	PageSize int
+}
+
+func (s Cursor) PageURL(firstPage *urlbuilder.URL, token common.NextPageToken) (*urlbuilder.URL, error) {
+	page, err := clonePage(firstPage)
+	if err != nil {
+		return nil, err
+	}
+
+	withPageSize(page, s.SizeParam, s.PageSize)
+
+	if len(token) == 0 {
+		return page, nil
+	}
+
+	state, err := decodeToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(state.Cursor) == 0 {
+		return nil, ErrInvalidToken
+	}
+
+	page.WithQueryParam(s.Param, state.Cursor)
+
+	return page, nil
+}
+
+func (s Cursor) NextPage(*urlbuilder.URL, http.Header) common.NextPageFunc {
+	return func(node *ajson.Node) (string, error) {
+		if more, err := hasMore(node, s.HasMorePath); err != nil || !more {
+			return "", err
+		}
+
+		cursor, err := textAt(node, s.Path)
+		if err != nil || len(cursor) == 0 {
+			return "", err
+		}
+
+		token, err := encodeToken(pageState{Cursor: cursor})
+
+		return token.String(), err
+	}
+}
+
+// NextURL follows the URL of the next page found in the body.
+// Example: Dynamics "@odata.nextLink", Zendesk "links.next".
+// Query of the returned URL uses the default encoding, providers apply their urlbuilder encoding exceptions on it.
+type NextURL struct {
+	// Path is the dotted location of the next page URL. Missing or null URL ends reading.
+	Path string
+	// SizeParam is the query parameter with the number of records per page, sent on the first page. Optional.
+	SizeParam string
+	// PageSize is sent via SizeParam.
+	PageSize int
+}
+
+func (s NextURL) PageURL(firstPage *urlbuilder.URL, token common.NextPageToken) (*urlbuilder.URL, error) {
+	if len(token) != 0 {
+		return decodeURL(firstPage, token)
+	}
+
+	page, err := clonePage(firstPage)
+	if err != nil {
+		return nil, err
+	}
+
+	withPageSize(page, s.SizeParam, s.PageSize)
+
+	return page, nil
+}
+
+func (s NextURL) NextPage(page *urlbuilder.URL, _ http.Header) common.NextPageFunc {
+	return func(node *ajson.Node) (string, error) {
+		next, err := textAt(node, s.Path)
+		if err != nil {
+			return "", err
+		}
+
+		return encodeURL(page, next)
+	}
+}
+
+// LinkHeader follows the "next" relation of the Link header as defined by RFC 5988.
+// Example: GitHub, Okta.
+type LinkHeader struct {
+	// SizeParam is the query parameter with the number of records per page, sent on the first page. Optional.
+	SizeParam string
+	// PageSize is sent via SizeParam.
+	PageSize int
+}
+
+func (s LinkHeader) PageURL(firstPage *urlbuilder.URL, token common.NextPageToken) (*urlbuilder.URL, error) {
+	return NextURL{SizeParam: s.SizeParam, PageSize: s.PageSize}.PageURL(firstPage, token)
+}
+
+func (s LinkHeader) NextPage(page *urlbuilder.URL, headers http.Header) common.NextPageFunc {
+	return func(*ajson.Node) (string, error) {
+		return encodeURL(page, NextLink(headers))
+	}
+}
+
+// Offset counts records already read and sends the count to skip them.
+// Reading ends on a page having fewer records than the page size, or when the body says there is no more.
+// Example: Jira "startAt" with "maxResults", Instantly "skip" with "limit".
+type Offset struct {
+	// Param is the query parameter with the number of records to skip.
+	Param string
+	// SizeParam is the query parameter with the number of records per page.
+	SizeParam string
+	// PageSize is sent via SizeParam. Required.
+	PageSize int
+	// RecordsPath is the dotted location of records in the body. Empty means the body itself is an array.
+	RecordsPath string
+	// HasMorePath is the dotted location of a boolean telling if more pages exist. Optional.
+	HasMorePath string
+}
+
+func (s Offset) PageURL(firstPage *urlbuilder.URL, token common.NextPageToken) (*urlbuilder.URL, error) {
+	page, err := clonePage(firstPage)
+	if err != nil {
+		return nil, err
+	}
+
+	withPageSize(page, s.SizeParam, s.PageSize)
+
+	offset := int64(0)
+
+	if len(token) != 0 {
+		state, err := decodeToken(token)
+		if err != nil {
+			return nil, err
+		}
+
+		if state.Offset < 0 {
+			return nil, ErrInvalidToken
+		}
+
+		offset = state.Offset
+	}
+
+	page.WithQueryParam(s.Param, strconv.FormatInt(offset, 10))
+
+	return page, nil
+}
+
+func (s Offset) NextPage(page *urlbuilder.URL, _ http.Header) common.NextPageFunc {
+	return func(node *ajson.Node) (string, error) {
+		size, err := countRecords(node, s.RecordsPath)
+		if err != nil {
+			return "", err
+		}
+
+		if more, err := hasMoreOrFull(node, s.HasMorePath, size, s.PageSize); err != nil || !more {
+			return "", err
+		}
+
+		offset := queryInteger(page, s.Param, 0)
+		token, err := encodeToken(pageState{Offset: offset + size})
+
+		return token.String(), err
+	}
+}
+
+// PageNumber requests pages by their number.
+// Reading ends on a page having fewer records than the page size, or when the body says there is no more.
+// Example: Zoho "page" with "per_page" and "info.more_records".
+type PageNumber struct {
+	// Param is the query parameter with the page number.
+	Param string
+	// FirstPage is the number of the first page. Defaults to 1.
+	FirstPage int64
+	// SizeParam is the query parameter with the number of records per page.
+	SizeParam string
+	// PageSize is sent via SizeParam. Required.
+	PageSize int
+	// RecordsPath is the dotted location of records in the body. Empty means the body itself is an array.
+	RecordsPath string
+	// HasMorePath is the dotted location of a boolean telling if more pages exist. Optional.
+	HasMorePath string
+}
+
+func (s PageNumber) PageURL(firstPage *urlbuilder.URL, token common.NextPageToken) (*urlbuilder.URL, error) {
+	page, err := clonePage(firstPage)
+	if err != nil {
+		return nil, err
+	}
+
+	withPageSize(page, s.SizeParam, s.PageSize)
+
+	number := s.firstPage()
+
+	if len(token) != 0 {
+		state, err := decodeToken(token)
+		if err != nil {
+			return nil, err
+		}
+
+		if state.Page < number {
+			return nil, ErrInvalidToken
+		}
+
+		number = state.Page
+	}
+
+	page.WithQueryParam(s.Param, strconv.FormatInt(number, 10))
+
+	return page, nil
+}
+
+func (s PageNumber) NextPage(page *urlbuilder.URL, _ http.Header) common.NextPageFunc {
+	return func(node *ajson.Node) (string, error) {
+		size, err := countRecords(node, s.RecordsPath)
+		if err != nil {
+			return "", err
+		}
+
+		if more, err := hasMoreOrFull(node, s.HasMorePath, size, s.PageSize); err != nil || !more {
+			return "", err
+		}
+
+		number := queryInteger(page, s.Param, s.firstPage())
+		token, err := encodeToken(pageState{Page: number + 1})
+
+		return token.String(), err
+	}
+}
+
+func (s PageNumber) firstPage() int64 {
+	if s.FirstPage == 0 {
+		return 1
+	}
+
+	return s.FirstPage
+}
+
+// NextLink returns the URL of the "next" relation of the Link header, e.g.
+// <https://api.github.com/user/repos?page=2>; rel="next", <https://api.github.com/user/repos?page=5>; rel="last".
+func NextLink(headers http.Header) string {
+	for _, header := range headers.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			target, params, found := strings.Cut(link, ";")
+			if !found {
+				continue
+			}
+
+			for _, param := range strings.Split(params, ";") {
+				name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(name, "rel") {
+					continue
+				}
+
+				for _, rel := range strings.Fields(strings.Trim(value, `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return strings.Trim(strings.TrimSpace(target), "<>")
+					}
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+func queryInteger(page *urlbuilder.URL, param string, defaultValue int64) int64 {
+	value, ok := page.GetFirstQueryParam(param)
+	if !ok {
+		return defaultValue
+	}
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return defaultValue
+	}
+
+	return number
+}
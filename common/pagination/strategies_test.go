@@ -0,0 +1,202 @@
+package pagination
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/urlbuilder"
+	"github.com/spyzhov/ajson"
+)
+
+func TestStrategies(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		// strategy under test
+		strategy Strategy
+		// body and headers of the first page
+		body    string
+		headers http.Header
+		// expectedFirst is the URL of the first page
+		expectedFirst string
+		// expectedSecond is the URL of the page after the first one, empty when there is none
+		expectedSecond string
+	}{
+		{
+			name:           "Cursor in body",
+			strategy:       Cursor{Path: "paging.next.after", Param: "after", SizeParam: "limit", PageSize: 100},
+			body:           `{"results": [], "paging": {"next": {"after": "35"}}}`,
+			expectedFirst:  "https://api.example.com/v1/contacts?limit=100",
+			expectedSecond: "https://api.example.com/v1/contacts?after=35&limit=100",
+		},
+		{
+			name:          "Cursor ends when it is missing",
+			strategy:      Cursor{Path: "paging.next.after", Param: "after"},
+			body:          `{"results": [], "paging": null}`,
+			expectedFirst: "https://api.example.com/v1/contacts",
+		},
+		{
+			name:          "Cursor ends when there is no more",
+			strategy:      Cursor{Path: "info.next_page_token", Param: "page_token", HasMorePath: "info.more_records"},
+			body:          `{"data": [], "info": {"next_page_token": "c85", "more_records": false}}`,
+			expectedFirst: "https://api.example.com/v1/contacts",
+		},
+		{
+			name:           "Next URL in body",
+			strategy:       NextURL{Path: "@odata.nextLink"},
+			body:           `{"value": [], "@odata.nextLink": "https://api.example.com/v1/contacts?$skiptoken=x"}`,
+			expectedFirst:  "https://api.example.com/v1/contacts",
+			expectedSecond: "https://api.example.com/v1/contacts?%24skiptoken=x",
+		},
+		{
+			name:           "Relative next URL",
+			strategy:       NextURL{Path: "links.next"},
+			body:           `{"links": {"next": "/v1/contacts?page[after]=abc"}}`,
+			expectedFirst:  "https://api.example.com/v1/contacts",
+			expectedSecond: "https://api.example.com/v1/contacts?page%5Bafter%5D=abc",
+		},
+		{
+			name:     "Link header",
+			strategy: LinkHeader{SizeParam: "per_page", PageSize: 50},
+			body:     `[]`,
+			headers: http.Header{"Link": []string{
+				`<https://api.example.com/v1/contacts?page=1>; rel="prev", ` +
+					`<https://api.example.com/v1/contacts?page=3&per_page=50>; rel="next"`,
+			}},
+			expectedFirst:  "https://api.example.com/v1/contacts?per_page=50",
+			expectedSecond: "https://api.example.com/v1/contacts?page=3&per_page=50",
+		},
+		{
+			name:          "Link header without next page",
+			strategy:      LinkHeader{},
+			body:          `[]`,
+			headers:       http.Header{"Link": []string{`<https://api.example.com/v1/contacts?page=1>; rel="first"`}},
+			expectedFirst: "https://api.example.com/v1/contacts",
+		},
+		{
+			name:           "Offset after full page",
+			strategy:       Offset{Param: "startAt", SizeParam: "maxResults", PageSize: 2, RecordsPath: "issues"},
+			body:           `{"issues": [{}, {}]}`,
+			expectedFirst:  "https://api.example.com/v1/contacts?maxResults=2&startAt=0",
+			expectedSecond: "https://api.example.com/v1/contacts?maxResults=2&startAt=2",
+		},
+		{
+			name:          "Offset ends at partial page",
+			strategy:      Offset{Param: "skip", SizeParam: "limit", PageSize: 2},
+			body:          `[{}]`,
+			expectedFirst: "https://api.example.com/v1/contacts?limit=2&skip=0",
+		},
+		{
+			name: "Page number",
+			strategy: PageNumber{
+				Param: "page", SizeParam: "per_page", PageSize: 200,
+				RecordsPath: "data", HasMorePath: "info.more_records",
+			},
+			body:           `{"data": [{}], "info": {"more_records": true}}`,
+			expectedFirst:  "https://api.example.com/v1/contacts?page=1&per_page=200",
+			expectedSecond: "https://api.example.com/v1/contacts?page=2&per_page=200",
+		},
+		{
+			name:          "Page number starting from zero ends at empty page",
+			strategy:      PageNumber{Param: "page", FirstPage: -1, SizeParam: "size", PageSize: 10},
+			body:          `[]`,
+			expectedFirst: "https://api.example.com/v1/contacts?page=-1&size=10",
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			firstPage, err := urlbuilder.New("https://api.example.com/v1/contacts")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			page, err := tt.strategy.PageURL(firstPage, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if page.String() != tt.expectedFirst {
+				t.Fatalf("expected first page %v, got %v", tt.expectedFirst, page.String())
+			}
+
+			node, err := ajson.Unmarshal([]byte(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			token, err := tt.strategy.NextPage(page, tt.headers)(node)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(tt.expectedSecond) == 0 {
+				if len(token) != 0 {
+					t.Fatalf("expected no next page, got token %v", token)
+				}
+
+				return
+			}
+
+			next, err := tt.strategy.PageURL(firstPage, common.NextPageToken(token))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if next.String() != tt.expectedSecond {
+				t.Fatalf("expected second page %v, got %v", tt.expectedSecond, next.String())
+			}
+
+			if firstPage.String() != "https://api.example.com/v1/contacts" {
+				t.Fatalf("first page must not change, got %v", firstPage.String())
+			}
+		})
+	}
+}
+
+func TestTokenSafety(t *testing.T) {
+	t.Parallel()
+
+	firstPage, err := urlbuilder.New("https://api.example.com/v1/contacts")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Raw URLs are not accepted as tokens.
+	_, err = NextURL{Path: "next"}.PageURL(firstPage, "https://api.example.com/v1/contacts?page=2")
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected %v, got %v", ErrInvalidToken, err)
+	}
+
+	// URLs at another host are not followed.
+	node, _ := ajson.Unmarshal([]byte(`{"next": "https://attacker.example.org/steal"}`))
+
+	token, err := NextURL{Path: "next"}.NextPage(firstPage, nil)(node)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NextURL{Path: "next"}.PageURL(firstPage, common.NextPageToken(token))
+	if !errors.Is(err, ErrForeignURL) {
+		t.Fatalf("expected %v, got %v", ErrForeignURL, err)
+	}
+
+	// Offset token doesn't reveal the URL.
+	node, _ = ajson.Unmarshal([]byte(`[{}]`))
+
+	token, err = Offset{Param: "skip", SizeParam: "limit", PageSize: 1}.NextPage(firstPage, nil)(node)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token != "eyJvIjoxfQ" { // {"o":1}
+		t.Fatalf("unexpected offset token %v", token)
+	}
+}
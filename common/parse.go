@@ -1,8 +1,6 @@
 package common
 
 import (
-	"strings"
-
 	"github.com/amp-labs/connectors/common/handy"
 	"github.com/amp-labs/connectors/common/jsonquery"
 	"github.com/spyzhov/ajson"
@@ -61,39 +59,35 @@ func ParseResult(
 }
 
 // ExtractLowercaseFieldsFromRaw returns a map of fields from a record.
-// The fields are all returned in lowercase.
+// The fields are all returned in lowercase. Nested values are located by dotted or JSONPath fields,
+// see FieldSelector.
 func ExtractLowercaseFieldsFromRaw(fields []string, record map[string]interface{}) map[string]interface{} {
-	out := make(map[string]interface{}, len(fields))
-
-	// Modify all record keys to lowercase
-	lowercaseRecord := make(map[string]interface{}, len(record))
-	for key, value := range record {
-		lowercaseRecord[strings.ToLower(key)] = value
-	}
+	return ExtractFieldsFromRaw(fields, record, false)
+}
 
-	for _, field := range fields {
-		// Lowercase the field name to make lookup case-insensitive.
-		lowercaseField := strings.ToLower(field)
+func GetMarshaledData(records []map[string]any, fields []string) ([]ReadResultRow, error) {
+	return marshalRecords(records, fields, false), nil
+}
 
-		if value, ok := lowercaseRecord[lowercaseField]; ok {
-			out[lowercaseField] = value
-		}
+// MakeMarshaledDataFunc returns GetMarshaledData, which keeps the casing of fields
+// as requested when preserveCase is set. It is meant to receive ReadParams.PreserveFieldCase.
+func MakeMarshaledDataFunc(preserveCase bool) func([]map[string]any, []string) ([]ReadResultRow, error) {
+	return func(records []map[string]any, fields []string) ([]ReadResultRow, error) {
+		return marshalRecords(records, fields, preserveCase), nil
 	}
-
-	return out
 }
 
-func GetMarshaledData(records []map[string]any, fields []string) ([]ReadResultRow, error) {
+func marshalRecords(records []map[string]any, fields []string, preserveCase bool) []ReadResultRow {
 	data := make([]ReadResultRow, len(records))
 
 	for i, record := range records {
 		data[i] = ReadResultRow{
-			Fields: ExtractLowercaseFieldsFromRaw(fields, record),
+			Fields: ExtractFieldsFromRaw(fields, record, preserveCase),
 			Raw:    record,
 		}
 	}
 
-	return data, nil
+	return data
 }
 
 func GetRecordsUnderJSONPath(jsonPath string) RecordsFunc {
@@ -0,0 +1,11 @@
+package common
+
+import "context"
+
+// RateLimiter throttles outgoing requests of HTTPClient.
+// Acquire blocks until the request is allowed to be sent, or the context is done.
+// The returned release function must be called once the response was received,
+// limiters that cap concurrency use it to free the slot.
+type RateLimiter interface {
+	Acquire(ctx context.Context) (release func(), err error)
+}
@@ -0,0 +1,191 @@
+// nolint:ireturn
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// noRelease is returned by limiters that don't hold any resources for the duration of a request.
+func noRelease() {}
+
+// TokenBucket allows a sustained rate of requests with occasional bursts.
+// The bucket holds up to burst tokens and is refilled at a constant rate.
+// Each request takes one token, waiting for the refill if the bucket is empty.
+type TokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// minRequestsPerSecond is one request per hour.
+const minRequestsPerSecond = 1.0 / 3600
+
+// NewTokenBucket creates a limiter allowing requestsPerSecond on average and up to burst at once.
+// Rates that are not positive are raised to one request per hour,
+// so that a misconfigured bucket throttles requests rather than waiting for an infinite time.
+func NewTokenBucket(requestsPerSecond float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+
+	if !(requestsPerSecond >= minRequestsPerSecond) {
+		requestsPerSecond = minRequestsPerSecond
+	}
+
+	return &TokenBucket{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+func (b *TokenBucket) Acquire(ctx context.Context) (func(), error) {
+	wait := b.reserve()
+	if wait == 0 {
+		return noRelease, nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		b.cancelReservation()
+
+		return nil, ctx.Err()
+	case <-timer.C:
+		return noRelease, nil
+	}
+}
+
+// reserve takes a token, possibly going into debt, and returns how long to wait for the debt to be repaid.
+func (b *TokenBucket) reserve() time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+func (b *TokenBucket) cancelReservation() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.tokens++
+}
+
+// Concurrency caps the number of requests in flight.
+type Concurrency struct {
+	slots chan struct{}
+}
+
+// NewConcurrency creates a limiter allowing at most maxRequests at the same time.
+// Limits that are not positive are raised to one request, so that requests are serialized rather than blocked forever.
+func NewConcurrency(maxRequests int) *Concurrency {
+	if maxRequests < 1 {
+		maxRequests = 1
+	}
+
+	return &Concurrency{
+		slots: make(chan struct{}, maxRequests),
+	}
+}
+
+func (c *Concurrency) Acquire(ctx context.Context) (func(), error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case c.slots <- struct{}{}:
+		var once sync.Once
+
+		return func() {
+			once.Do(func() { <-c.slots })
+		}, nil
+	}
+}
+
+// DailyQuota counts requests made during a UTC calendar day.
+// Once the quota is used up requests fail immediately instead of waiting for the next day.
+type DailyQuota struct {
+	mutex sync.Mutex
+	quota int
+	used  int
+	day   time.Time
+	now   func() time.Time
+}
+
+// NewDailyQuota creates a limiter allowing at most quota requests per day.
+func NewDailyQuota(quota int) *DailyQuota {
+	return &DailyQuota{
+		quota: quota,
+		now:   time.Now,
+	}
+}
+
+func (q *DailyQuota) Acquire(ctx context.Context) (func(), error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	today := q.now().UTC().Truncate(24 * time.Hour) // nolint:mnd
+	if !today.Equal(q.day) {
+		q.day = today
+		q.used = 0
+	}
+
+	if q.used >= q.quota {
+		return nil, fmt.Errorf("%w: daily quota of %v requests is used up", common.ErrLimitExceeded, q.quota)
+	}
+
+	q.used++
+
+	return noRelease, nil
+}
+
+// Chain applies limiters in order. A request proceeds only once every limiter has allowed it.
+type Chain struct {
+	limiters []common.RateLimiter
+}
+
+func NewChain(limiters ...common.RateLimiter) *Chain {
+	return &Chain{
+		limiters: limiters,
+	}
+}
+
+func (c *Chain) Acquire(ctx context.Context) (func(), error) {
+	releases := make([]func(), 0, len(c.limiters))
+	releaseAll := func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
+		}
+	}
+
+	for _, limiter := range c.limiters {
+		release, err := limiter.Acquire(ctx)
+		if err != nil {
+			releaseAll()
+
+			return nil, err
+		}
+
+		releases = append(releases, release)
+	}
+
+	return releaseAll, nil
+}
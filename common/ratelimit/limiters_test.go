@@ -0,0 +1,188 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/providers"
+)
+
+func TestTokenBucket(t *testing.T) {
+	t.Parallel()
+
+	bucket := NewTokenBucket(20, 2)
+	started := time.Now()
+
+	for range 4 {
+		release, err := bucket.Acquire(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		release()
+	}
+
+	// Two requests are free due to the burst, the other two wait 50ms each.
+	if elapsed := time.Since(started); elapsed < 90*time.Millisecond {
+		t.Fatalf("expected requests to be throttled, took (%v)", elapsed)
+	}
+}
+
+func TestTokenBucketCancellation(t *testing.T) {
+	t.Parallel()
+
+	bucket := NewTokenBucket(0.001, 1)
+	if _, err := bucket.Acquire(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := bucket.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got (%v)", err)
+	}
+}
+
+func TestTokenBucketNonPositiveRate(t *testing.T) {
+	t.Parallel()
+
+	for _, rate := range []float64{0, -5, math.NaN()} {
+		bucket := NewTokenBucket(rate, 1)
+		if _, err := bucket.Acquire(context.Background()); err != nil {
+			t.Fatalf("rate %v: unexpected error: %v", rate, err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+
+		if _, err := bucket.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("rate %v: expected request to be throttled, got (%v)", rate, err)
+		}
+
+		cancel()
+	}
+}
+
+func TestConcurrencyNonPositiveLimit(t *testing.T) {
+	t.Parallel()
+
+	for _, limit := range []int{0, -3} {
+		limiter := NewConcurrency(limit)
+
+		release, err := limiter.Acquire(context.Background())
+		if err != nil {
+			t.Fatalf("limit %v: unexpected error: %v", limit, err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+
+		if _, err := limiter.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("limit %v: expected second request to wait, got (%v)", limit, err)
+		}
+
+		cancel()
+		release()
+	}
+}
+
+func TestConcurrency(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewConcurrency(2)
+
+	var (
+		inFlight atomic.Int32
+		peak     atomic.Int32
+		group    sync.WaitGroup
+	)
+
+	for range 10 {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			release, err := limiter.Acquire(context.Background())
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+
+				return
+			}
+			defer release()
+
+			current := inFlight.Add(1)
+			for {
+				old := peak.Load()
+				if current <= old || peak.CompareAndSwap(old, current) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+			inFlight.Add(-1)
+		}()
+	}
+
+	group.Wait()
+
+	if peak.Load() > 2 {
+		t.Fatalf("expected at most 2 requests in flight, got (%v)", peak.Load())
+	}
+}
+
+func TestDailyQuota(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 10, 1, 23, 0, 0, 0, time.UTC)
+	quota := NewDailyQuota(2)
+	quota.now = func() time.Time { return now }
+
+	for range 2 {
+		if _, err := quota.Acquire(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if _, err := quota.Acquire(context.Background()); !errors.Is(err, common.ErrLimitExceeded) {
+		t.Fatalf("expected limit exceeded error, got (%v)", err)
+	}
+
+	now = now.Add(2 * time.Hour)
+
+	if _, err := quota.Acquire(context.Background()); err != nil {
+		t.Fatalf("expected quota to be reset on the next day, got (%v)", err)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+
+	first, err := registry.Get(providers.Hubspot, "workspace-a")
+	if err != nil || first == nil {
+		t.Fatalf("expected limiter for hubspot, got (%v, %v)", first, err)
+	}
+
+	same, _ := registry.Get(providers.Hubspot, "workspace-a")
+	other, _ := registry.Get(providers.Hubspot, "workspace-b")
+
+	if same != first {
+		t.Fatal("expected the same connection to share a limiter")
+	}
+
+	if other == first {
+		t.Fatal("expected different connections to have separate limiters")
+	}
+
+	none, err := registry.Get(providers.Salesforce, "workspace-a")
+	if err != nil || none != nil {
+		t.Fatalf("expected no limiter for provider without limits, got (%v, %v)", none, err)
+	}
+}
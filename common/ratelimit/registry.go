@@ -0,0 +1,111 @@
+// nolint:ireturn
+package ratelimit
+
+import (
+	"sync"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/providers"
+)
+
+// defaultRegistry is shared by all connectors created in this process.
+var defaultRegistry = NewRegistry() // nolint:gochecknoglobals
+
+// New creates a limiter enforcing every limit set by providers.SetRateLimit.
+// Returns nil if the options don't describe any limits.
+func New(opts *providers.RateLimitOpts) common.RateLimiter {
+	if opts == nil {
+		return nil
+	}
+
+	limiters := make([]common.RateLimiter, 0)
+
+	// Concurrency goes first, therefore waiting for the token bucket happens while holding the slot.
+	// Quota goes last, so that requests cancelled while waiting are not counted.
+	if opts.ConcurrentRequests > 0 {
+		limiters = append(limiters, NewConcurrency(opts.ConcurrentRequests))
+	}
+
+	if opts.RequestsPerSecond > 0 {
+		limiters = append(limiters, NewTokenBucket(opts.RequestsPerSecond, opts.Burst))
+	}
+
+	if opts.DailyQuota > 0 {
+		limiters = append(limiters, NewDailyQuota(opts.DailyQuota))
+	}
+
+	if len(limiters) == 0 {
+		return nil
+	}
+
+	return NewChain(limiters...)
+}
+
+// Registry keeps limiter state per connection.
+// Every connection, usually identified by the workspace, has its own quota with the provider.
+// Connectors talking to the same connection must share the limiter,
+// while connectors of different connections must not starve each other.
+type Registry struct {
+	mutex    sync.Mutex
+	limiters map[registryKey]common.RateLimiter
+}
+
+type registryKey struct {
+	provider   providers.Provider
+	connection string
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		limiters: make(map[registryKey]common.RateLimiter),
+	}
+}
+
+// Get returns the limiter of a connection, creating it from the provider rate limits on first use.
+// Returns nil if the provider has no rate limits.
+func (r *Registry) Get(provider providers.Provider, connection string) (common.RateLimiter, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := registryKey{
+		provider:   provider,
+		connection: connection,
+	}
+
+	if limiter, ok := r.limiters[key]; ok {
+		return limiter, nil
+	}
+
+	// Unknown providers are rejected, even though limits are kept apart from the catalog.
+	if _, err := providers.ReadInfo(provider); err != nil {
+		return nil, err
+	}
+
+	limiter := New(providers.ReadRateLimit(provider))
+	r.limiters[key] = limiter
+
+	return limiter, nil
+}
+
+// Attach sets the limiter of a connection on the HTTP client.
+// The client is left unchanged if the provider has no rate limits.
+func (r *Registry) Attach(client *common.HTTPClient, provider providers.Provider, connection string) error {
+	limiter, err := r.Get(provider, connection)
+	if err != nil {
+		return err
+	}
+
+	if limiter != nil {
+		client.RateLimiter = limiter
+	}
+
+	return nil
+}
+
+// Attach sets the limiter of a connection on the HTTP client using the process wide registry.
+// Example:
+//
+//	err := ratelimit.Attach(conn.HTTPClient(), providers.Hubspot, workspaceRef)
+func Attach(client *common.HTTPClient, provider providers.Provider, connection string) error {
+	return defaultRegistry.Attach(client, provider, connection)
+}
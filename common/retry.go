@@ -0,0 +1,239 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts     = 4
+	defaultRetryInitialInterval = 500 * time.Millisecond
+	defaultRetryMaxInterval     = 30 * time.Second
+	defaultRetryMultiplier      = 2.0
+	defaultRetryJitter          = 0.2
+
+	// Timestamps above this value are treated as unix epoch seconds rather than relative delays.
+	// Some providers send X-RateLimit-Reset as epoch, others as a number of seconds to wait.
+	epochSecondsThreshold = 1_000_000_000
+)
+
+// ErrRetriesExhausted is joined with the last error once the retry policy gives up.
+var ErrRetriesExhausted = errors.New("retries exhausted")
+
+// RetryableErrorFunc decides whether a failed request can be sent again.
+type RetryableErrorFunc func(err error) bool
+
+// RetryPolicy describes how HTTPClient re-sends failed requests.
+// Retries are opt-in, HTTPClient sends each request once unless a policy is set.
+// Delay between attempts grows exponentially from InitialInterval by Multiplier, capped at MaxInterval,
+// and is randomized by Jitter. If the provider responded with Retry-After or X-RateLimit-Reset headers
+// their value takes precedence over the computed delay.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// InitialInterval is the delay before the second attempt.
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between attempts.
+	MaxInterval time.Duration
+	// Multiplier is applied to the delay after every attempt.
+	Multiplier float64
+	// Jitter is a fraction in the range [0, 1] by which the delay is randomly shortened or extended.
+	Jitter float64
+	// MaxElapsedTime is the deadline for all attempts combined. Zero means no deadline.
+	// The context deadline, if any, is always respected.
+	MaxElapsedTime time.Duration
+	// IsRetryable overrides the classification of errors for this policy. Optional.
+	// When not set, the classifier of HTTPClient is used.
+	IsRetryable RetryableErrorFunc
+}
+
+// DefaultRetryPolicy returns a policy with sensible defaults.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:     defaultRetryMaxAttempts,
+		InitialInterval: defaultRetryInitialInterval,
+		MaxInterval:     defaultRetryMaxInterval,
+		Multiplier:      defaultRetryMultiplier,
+		Jitter:          defaultRetryJitter,
+	}
+}
+
+// IsRetryableError is the default error classification.
+// Errors marked as ErrRetryable are retried, as well as gateway errors which are usually transient.
+// Not Found is not retried, even though InterpretError marks it as ErrRetryable,
+// a missing resource rarely appears within the time span of retries.
+func IsRetryableError(err error) bool {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.HTTPStatus {
+		case http.StatusNotFound:
+			return false
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+
+	return errors.Is(err, ErrRetryable)
+}
+
+// sendWithRetries sends the request according to the policy.
+// Every failure is classified, non-retryable errors are returned immediately.
+func (h *HTTPClient) sendWithRetries(req *http.Request) (*http.Response, []byte, error) {
+	policy := h.RetryPolicy
+	started := time.Now()
+	delay := policy.InitialInterval
+
+	isRetryable := h.isRetryable()
+	if policy.IsRetryable != nil {
+		isRetryable = policy.IsRetryable
+	}
+
+	for attempt := 1; ; attempt++ {
+		res, body, err := h.sendRequestOnce(req)
+		if err == nil {
+			return res, body, nil
+		}
+
+		if !isRetryable(err) {
+			return nil, nil, err
+		}
+
+		if attempt >= policy.MaxAttempts {
+			return nil, nil, errors.Join(ErrRetriesExhausted, err)
+		}
+
+		wait := policy.withJitter(delay)
+		if suggested, ok := retryDelayFromHeaders(res); ok {
+			wait = suggested
+		}
+
+		if policy.MaxElapsedTime > 0 && time.Since(started)+wait > policy.MaxElapsedTime {
+			return nil, nil, errors.Join(ErrRetriesExhausted, err)
+		}
+
+		// URL path and query may carry credentials, only the host is logged.
+		slog.Debug("Retrying request", "method", req.Method, "host", req.URL.Host,
+			"attempt", attempt, "wait", wait, "error", err)
+
+		if waitErr := sleepWithContext(req.Context(), wait); waitErr != nil {
+			return nil, nil, errors.Join(waitErr, err)
+		}
+
+		rewound, rewindErr := rewindRequest(req)
+		if rewindErr != nil {
+			return nil, nil, errors.Join(rewindErr, err)
+		}
+
+		req = rewound
+
+		delay = policy.nextInterval(delay)
+	}
+}
+
+func (h *HTTPClient) isRetryable() RetryableErrorFunc {
+	if h.IsRetryable != nil {
+		return h.IsRetryable
+	}
+
+	return IsRetryableError
+}
+
+func (p *RetryPolicy) nextInterval(current time.Duration) time.Duration {
+	next := time.Duration(float64(current) * p.Multiplier)
+	if p.MaxInterval > 0 && next > p.MaxInterval {
+		return p.MaxInterval
+	}
+
+	return next
+}
+
+func (p *RetryPolicy) withJitter(delay time.Duration) time.Duration {
+	if p.Jitter <= 0 {
+		return delay
+	}
+
+	// Random factor in the range [1-jitter, 1+jitter].
+	factor := 1 + p.Jitter*(2*rand.Float64()-1) // nolint:gosec,mnd
+
+	return time.Duration(float64(delay) * factor)
+}
+
+// retryDelayFromHeaders reads the delay requested by the provider.
+// Retry-After is either a number of seconds or an HTTP date.
+// X-RateLimit-Reset is either a number of seconds or a unix timestamp.
+func retryDelayFromHeaders(res *http.Response) (time.Duration, bool) {
+	if res == nil {
+		return 0, false
+	}
+
+	if value := res.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return nonNegative(time.Duration(seconds) * time.Second), true
+		}
+
+		if date, err := http.ParseTime(value); err == nil {
+			return nonNegative(time.Until(date)), true
+		}
+	}
+
+	if value := res.Header.Get("X-RateLimit-Reset"); value != "" {
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+			if seconds > epochSecondsThreshold {
+				return nonNegative(time.Until(time.Unix(seconds, 0))), true
+			}
+
+			return nonNegative(time.Duration(seconds) * time.Second), true
+		}
+	}
+
+	return 0, false
+}
+
+func nonNegative(duration time.Duration) time.Duration {
+	if duration < 0 {
+		return 0
+	}
+
+	return duration
+}
+
+func sleepWithContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rewindRequest returns a copy of the request which can be sent again.
+// Request body is consumed by the first attempt, therefore it is recreated using GetBody.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return clone, nil
+	}
+
+	if req.GetBody == nil {
+		return nil, fmt.Errorf("%w: request body cannot be replayed", ErrRequestFailed)
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRequestFailed, err)
+	}
+
+	clone.Body = body
+
+	return clone, nil
+}
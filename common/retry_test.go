@@ -0,0 +1,206 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestRetryPolicy(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		failures         int
+		failureStatus    int
+		failureHeaders   map[string]string
+		isRetryable      RetryableErrorFunc
+		maxAttempts      int
+		expectedAttempts int32
+		expectedErrs     []error
+	}{
+		{
+			name:             "Too many requests is retried until success",
+			failures:         2,
+			failureStatus:    http.StatusTooManyRequests,
+			maxAttempts:      4,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "Attempts are limited",
+			failures:         10,
+			failureStatus:    http.StatusServiceUnavailable,
+			maxAttempts:      3,
+			expectedAttempts: 3,
+			expectedErrs:     []error{ErrServer, ErrRetriesExhausted},
+		},
+		{
+			name:             "Caller errors are not retried",
+			failures:         1,
+			failureStatus:    http.StatusBadRequest,
+			maxAttempts:      3,
+			expectedAttempts: 1,
+			expectedErrs:     []error{ErrCaller},
+		},
+		{
+			name:             "Not found is not retried",
+			failures:         1,
+			failureStatus:    http.StatusNotFound,
+			maxAttempts:      3,
+			expectedAttempts: 1,
+			expectedErrs:     []error{ErrRetryable},
+		},
+		{
+			name:             "Custom classification is honoured",
+			failures:         1,
+			failureStatus:    http.StatusBadRequest,
+			isRetryable:      func(err error) bool { return errors.Is(err, ErrCaller) },
+			maxAttempts:      3,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "Retry-After header is honoured",
+			failures:         1,
+			failureStatus:    http.StatusTooManyRequests,
+			failureHeaders:   map[string]string{"Retry-After": "0"},
+			maxAttempts:      2,
+			expectedAttempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != `{"name":"value"}` {
+					t.Errorf("%s: request body was not replayed, got (%s)", tt.name, body)
+				}
+
+				if int(attempts.Add(1)) <= tt.failures {
+					for key, value := range tt.failureHeaders {
+						w.Header().Set(key, value)
+					}
+
+					w.WriteHeader(tt.failureStatus)
+
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client := &JSONHTTPClient{
+				HTTPClient: &HTTPClient{
+					Base:   server.URL,
+					Client: http.DefaultClient,
+					RetryPolicy: &RetryPolicy{
+						MaxAttempts:     tt.maxAttempts,
+						InitialInterval: time.Millisecond,
+						MaxInterval:     time.Millisecond,
+						Multiplier:      2,
+						Jitter:          0.5,
+					},
+					IsRetryable: tt.isRetryable,
+				},
+			}
+
+			_, err := client.Post(context.Background(), "/objects", map[string]string{"name": "value"})
+			testutils.CheckErrors(t, tt.name, tt.expectedErrs, err)
+
+			if attempts.Load() != tt.expectedAttempts {
+				t.Fatalf("%s: expected (%v) attempts, got (%v)", tt.name, tt.expectedAttempts, attempts.Load())
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDeadline(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &HTTPClient{
+		Base:   server.URL,
+		Client: http.DefaultClient,
+		RetryPolicy: &RetryPolicy{
+			MaxAttempts:    5,
+			MaxElapsedTime: time.Second,
+		},
+	}
+
+	_, _, err := client.Get(context.Background(), "/objects")
+	if !errors.Is(err, ErrRetriesExhausted) || !errors.Is(err, ErrRetryable) {
+		t.Fatalf("expected exhausted retryable error, got (%v)", err)
+	}
+}
+
+func TestRetryDelayFromHeaders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		expected time.Duration
+		found    bool
+	}{
+		{
+			name:    "No headers",
+			headers: map[string]string{},
+		},
+		{
+			name:     "Retry-After in seconds",
+			headers:  map[string]string{"Retry-After": "7"},
+			expected: 7 * time.Second,
+			found:    true,
+		},
+		{
+			name:     "Relative rate limit reset",
+			headers:  map[string]string{"X-RateLimit-Reset": "12"},
+			expected: 12 * time.Second,
+			found:    true,
+		},
+		{
+			name:     "Rate limit reset in the past",
+			headers:  map[string]string{"X-RateLimit-Reset": "1500000000"},
+			expected: 0,
+			found:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			res := &http.Response{Header: http.Header{}}
+			for key, value := range tt.headers {
+				res.Header.Set(key, value)
+			}
+
+			delay, found := retryDelayFromHeaders(res)
+			if delay != tt.expected || found != tt.found {
+				t.Fatalf("%s: expected (%v, %v), got (%v, %v)", tt.name, tt.expected, tt.found, delay, found)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+var (
+	// ErrMissingTargetURL is returned when a subscription has no URL to deliver events to.
+	ErrMissingTargetURL = errors.New("missing target url")
+	// ErrMissingSubscriptionID is returned when a subscription is deleted without its id.
+	ErrMissingSubscriptionID = errors.New("missing subscription id")
+	// ErrUnknownChangeType is returned when a subscription asks for a change type which is not known.
+	ErrUnknownChangeType = errors.New("unknown change type")
+)
+
+// ChangeType is the kind of change which happened to a record.
+type ChangeType string
+
+const (
+	ChangeTypeCreated ChangeType = "created"
+	ChangeTypeUpdated ChangeType = "updated"
+	ChangeTypeDeleted ChangeType = "deleted"
+)
+
+// AllChangeTypes are the change types a subscription receives when none is requested.
+var AllChangeTypes = []ChangeType{ // nolint:gochecknoglobals
+	ChangeTypeCreated, ChangeTypeUpdated, ChangeTypeDeleted,
+}
+
+// SubscribeParams describes a webhook to be registered at the provider.
+type SubscribeParams struct {
+	// ObjectName of records whose changes are delivered.
+	ObjectName string
+	// Changes to be delivered. Empty list means all of them.
+	Changes []ChangeType
+	// TargetURL receives the events, it must be reachable by the provider.
+	TargetURL string
+	// Name of the webhook, for providers which display it. Optional.
+	Name string
+	// Username and Password are sent by the provider as basic auth, for providers which support it.
+	// Optional, see webhook.BasicAuth.
+	Username string
+	Password string
+}
+
+// Subscription is a webhook registered at the provider.
+type Subscription struct {
+	// ID is used to delete the subscription.
+	ID         string       `json:"id"`
+	ObjectName string       `json:"objectName,omitempty"`
+	Changes    []ChangeType `json:"changes,omitempty"`
+	TargetURL  string       `json:"targetURL"`
+	// Active is false when the provider stopped delivering events.
+	Active bool `json:"active"`
+	// Secret signs the events, for providers which generate it per subscription.
+	Secret string `json:"secret,omitempty"`
+	// Raw is the subscription as returned by the provider.
+	Raw map[string]any `json:"raw,omitempty"`
+}
+
+// ResolveChanges returns the requested change types, all of them when none was requested.
+func (p SubscribeParams) ResolveChanges() []ChangeType {
+	if len(p.Changes) == 0 {
+		return slices.Clone(AllChangeTypes)
+	}
+
+	return p.Changes
+}
+
+func (p SubscribeParams) ValidateParams() error {
+	if len(p.ObjectName) == 0 {
+		return ErrMissingObjects
+	}
+
+	if len(p.TargetURL) == 0 {
+		return ErrMissingTargetURL
+	}
+
+	if _, err := url.ParseRequestURI(p.TargetURL); err != nil {
+		return fmt.Errorf("%w: %w", ErrMissingTargetURL, err)
+	}
+
+	for _, change := range p.Changes {
+		switch change {
+		case ChangeTypeCreated, ChangeTypeUpdated, ChangeTypeDeleted:
+		default:
+			return fmt.Errorf("%w: %v", ErrUnknownChangeType, change)
+		}
+	}
+
+	return nil
+}
@@ -97,7 +97,11 @@ type ReadParams struct {
 	// The name of the object we are reading, e.g. "Account"
 	ObjectName string // required
 	// The fields we are reading from the object, e.g. ["Id", "Name", "BillingCity"]
+	// Nested values are selected by dotted paths or JSONPath, e.g. "properties.firstname" or "$.emails[0].value",
+	// these are resolved against ReadResultRow.Raw. See FieldSelector.
 	Fields handy.StringSet // required, at least one field needed
+	// PreserveFieldCase keeps keys of ReadResultRow.Fields as they were requested, rather than lowercased.
+	PreserveFieldCase bool // optional, defaults to false
 	// NextPage is an opaque token that can be used to get the next page of results.
 	NextPage NextPageToken // optional, only set this if you want to read the next page of results
 	// Since is a timestamp that can be used to get only records that have changed since that time.
@@ -105,8 +109,15 @@ type ReadParams struct {
 	// Deleted is true if we want to read deleted records instead of active records.
 	Deleted bool // optional, defaults to false
 	// Filter is only supported for salesforce. It is a SOQL string that comes after the WHERE clause
-	// which will be used to filter the records.
+	// which will be used to filter the records. Prefer FilterBy, which is understood by other providers too.
 	Filter string // optional
+	// FilterBy is a provider-neutral expression used to filter the records, e.g. FilterEq("Name", "Acme").
+	// Connectors that cannot express the filter return ErrOperationNotSupportedForObject.
+	FilterBy *FilterExpr // optional
+	// AssociatedObjects lists objects whose linked records are returned in ReadResultRow.Associations,
+	// e.g. ["companies", "deals"] for HubSpot contacts.
+	// Connectors that cannot read them return ErrOperationNotSupportedForObject.
+	AssociatedObjects []string // optional
 }
 
 // WriteParams defines how we are writing data to a SaaS API.
@@ -119,7 +130,20 @@ type WriteParams struct {
 
 	// RecordData is a JSON node representing the record of data we want to insert in the case of CREATE
 	// or fields of data we want to modify in case of an update
-	RecordData any // required
+	RecordData any // required, unless there are ClearFields
+
+	// Mode chooses between create, update, replace and upsert.
+	// By default a record is created without RecordId and updated with RecordId.
+	// Connectors return ErrOperationNotSupportedForObject for modes they cannot perform.
+	Mode WriteMode // optional
+
+	// ExternalIdField is the field used to match an existing record on upsert, e.g. "external_id__c".
+	// RecordId then holds the value of this field.
+	ExternalIdField string // required for upsert only
+
+	// ClearFields lists fields which are set to null, or emptied in the way the provider understands.
+	// Dotted fields refer to nested values of RecordData, e.g. "properties.phone".
+	ClearFields []string // optional
 }
 
 // DeleteParams defines how we are deleting data in SaaS API.
@@ -161,6 +185,19 @@ type ReadResultRow struct {
 	Fields map[string]any `json:"fields"`
 	// Raw is the raw JSON response from the provider.
 	Raw map[string]any `json:"raw"`
+	// Associations maps names of associated objects to the linked records.
+	// Only present when ReadParams.AssociatedObjects is set.
+	Associations map[string][]Association `json:"associations,omitempty"`
+}
+
+// Association is a link between a record and a record of another object.
+type Association struct {
+	// ObjectId is the ID of the associated record.
+	ObjectId string `json:"objectId"`
+	// Labels describe the kind of the link, e.g. "Primary". Not every provider labels associations.
+	Labels []string `json:"labels,omitempty"`
+	// Raw is the raw JSON response from the provider.
+	Raw map[string]any `json:"raw,omitempty"`
 }
 
 // WriteResult is what's returned from writing data via the Write call.
@@ -173,6 +210,8 @@ type WriteResult struct {
 	Errors []any `json:"errors,omitempty"` // optional
 	// Data is a JSON node containing data about the properties that were updated.
 	Data map[string]any `json:"data,omitempty"` // optional
+	// Outcome tells if the record was created or updated. It is reported by upsert.
+	Outcome WriteOutcome `json:"outcome,omitempty"` // optional
 }
 
 // DeleteResult is what's returned from deleting data via the Delete call.
@@ -232,6 +271,10 @@ type ObjectMetadata struct {
 
 	// FieldsMap is a map of field names to field display names
 	FieldsMap map[string]string
+
+	// Fields is a map of field names to their detailed description.
+	// Keys match those of FieldsMap. It is empty for connectors which cannot describe fields.
+	Fields map[string]FieldMetadata
 }
 
 type PostAuthInfo struct {
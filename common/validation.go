@@ -1,6 +1,9 @@
 package common
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrMissingObjects is returned when no objects are provided in the request.
@@ -25,6 +28,21 @@ func (p ReadParams) ValidateParams(withRequiredFields bool) error {
 		return ErrMissingFields
 	}
 
+	for _, field := range p.Fields.List() {
+		if len(field) == 0 {
+			// Empty field selects nothing.
+			continue
+		}
+
+		if _, err := ParseFieldSelector(field); err != nil {
+			return err
+		}
+	}
+
+	if p.FilterBy != nil {
+		return p.FilterBy.Validate()
+	}
+
 	return nil
 }
 
@@ -33,10 +51,31 @@ func (p WriteParams) ValidateParams() error {
 		return ErrMissingObjects
 	}
 
-	if p.RecordData == nil {
+	if p.RecordData == nil && len(p.ClearFields) == 0 {
 		return ErrMissingRecordData
 	}
 
+	switch p.ResolveMode() {
+	case WriteModeCreate:
+		if len(p.RecordId) != 0 {
+			return ErrUnexpectedRecordID
+		}
+	case WriteModeUpdate, WriteModeReplace:
+		if len(p.RecordId) == 0 {
+			return ErrMissingRecordID
+		}
+	case WriteModeUpsert:
+		if len(p.ExternalIdField) == 0 {
+			return ErrMissingExternalIdField
+		}
+
+		if len(p.RecordId) == 0 {
+			return ErrMissingRecordID
+		}
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownWriteMode, p.Mode)
+	}
+
 	return nil
 }
 
@@ -51,3 +90,14 @@ func (p DeleteParams) ValidateParams() error {
 
 	return nil
 }
+
+// ValidateNoAssociations is used by connectors which cannot read associated records.
+// It returns ErrOperationNotSupportedForObject when AssociatedObjects is set, rather than omitting the associations.
+func (p ReadParams) ValidateNoAssociations() error {
+	if len(p.AssociatedObjects) != 0 {
+		return fmt.Errorf("%w: associated objects %v of %v",
+			ErrOperationNotSupportedForObject, p.AssociatedObjects, p.ObjectName)
+	}
+
+	return nil
+}
@@ -0,0 +1,276 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/naming"
+)
+
+// ParseHubSpot converts a batch of HubSpot events.
+// Association, merge and restore events are reported as updates.
+// https://developers.hubspot.com/docs/api/webhooks#webhooks-payloads
+func ParseHubSpot(body []byte) ([]Event, error) {
+	var payload []map[string]any
+	if err := decode(body, &payload); err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(payload))
+
+	for _, raw := range payload {
+		subscriptionType := text(raw, "subscriptionType")
+
+		object, action, found := strings.Cut(subscriptionType, ".")
+		if !found {
+			return nil, fmt.Errorf("%w: subscription type %q", ErrInvalidPayload, subscriptionType)
+		}
+
+		event := Event{
+			Change:     common.ChangeTypeUpdated,
+			ObjectName: naming.NewPluralString(object).String(),
+			RecordID:   text(raw, "objectId"),
+			OccurredAt: unixTime(raw, "occurredAt", time.UnixMilli),
+			Raw:        raw,
+		}
+
+		switch action {
+		case "creation":
+			event.Change = common.ChangeTypeCreated
+		case "deletion", "privacyDeletion":
+			event.Change = common.ChangeTypeDeleted
+		case "propertyChange":
+			event.ChangedFields = []string{text(raw, "propertyName")}
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// ParseIntercom converts an Intercom notification. Ping notifications carry no events.
+// Topics other than creation and deletion, such as replies to conversations or tagging, are reported as updates.
+// https://developers.intercom.com/docs/references/webhooks/webhook-models
+func ParseIntercom(body []byte) ([]Event, error) {
+	var payload map[string]any
+	if err := decode(body, &payload); err != nil {
+		return nil, err
+	}
+
+	topic := text(payload, "topic")
+	if topic == "ping" {
+		return nil, nil
+	}
+
+	item, _ := nested(payload, "data", "item").(map[string]any)
+	if item == nil {
+		return nil, fmt.Errorf("%w: notification %q has no item", ErrInvalidPayload, topic)
+	}
+
+	segments := strings.Split(topic, ".")
+	change := common.ChangeTypeUpdated
+
+	if !slices.Contains(segments, "tag") {
+		switch segments[len(segments)-1] {
+		case "created":
+			change = common.ChangeTypeCreated
+		case "deleted":
+			change = common.ChangeTypeDeleted
+		}
+	}
+
+	objectName := text(item, "type")
+	if len(objectName) == 0 {
+		objectName = segments[0]
+	}
+
+	return []Event{{
+		Change:     change,
+		ObjectName: naming.NewPluralString(objectName).String(),
+		RecordID:   text(item, "id"),
+		OccurredAt: unixTime(payload, "created_at", unixSeconds),
+		Raw:        payload,
+	}}, nil
+}
+
+// ParseZendesk converts an event of Zendesk webhooks.
+// Events named after a changed field, such as "user.name_changed", are updates of that field.
+// https://developer.zendesk.com/api-reference/webhooks/event-types/webhook-event-types/
+func ParseZendesk(body []byte) ([]Event, error) {
+	var payload map[string]any
+	if err := decode(body, &payload); err != nil {
+		return nil, err
+	}
+
+	eventType := text(payload, "type")
+
+	object, action, found := strings.Cut(strings.TrimPrefix(eventType, "zen:event-type:"), ".")
+	if !found {
+		return nil, fmt.Errorf("%w: event type %q", ErrInvalidPayload, eventType)
+	}
+
+	event := Event{
+		Change:     common.ChangeTypeUpdated,
+		ObjectName: naming.NewPluralString(object).String(),
+		RecordID:   text(payload, "detail", "id"),
+		Raw:        payload,
+	}
+
+	if len(event.RecordID) == 0 {
+		// Subject has the form "zen:user:123".
+		subject := text(payload, "subject")
+		event.RecordID = subject[strings.LastIndex(subject, ":")+1:]
+	}
+
+	if occurredAt, err := time.Parse(time.RFC3339, text(payload, "time")); err == nil {
+		event.OccurredAt = occurredAt
+	}
+
+	switch {
+	case action == "created":
+		event.Change = common.ChangeTypeCreated
+	case action == "deleted":
+		event.Change = common.ChangeTypeDeleted
+	case strings.HasSuffix(action, "_changed"):
+		event.ChangedFields = []string{strings.TrimSuffix(action, "_changed")}
+	}
+
+	return []Event{event}, nil
+}
+
+// pipedriveObjectNames lists objects whose API name is not the regular plural.
+var pipedriveObjectNames = map[string]string{ // nolint:gochecknoglobals
+	"person": "persons",
+}
+
+// ParsePipedrive converts an event of Pipedrive webhooks, both versions 1.0 and 2.0 are understood.
+// Merged records are reported as updates. Changed fields are those which differ from the previous state.
+// https://pipedrive.readme.io/docs/guide-for-webhooks-v2
+func ParsePipedrive(body []byte) ([]Event, error) {
+	var payload map[string]any
+	if err := decode(body, &payload); err != nil {
+		return nil, err
+	}
+
+	meta, _ := payload["meta"].(map[string]any)
+	if meta == nil {
+		return nil, fmt.Errorf("%w: missing meta", ErrInvalidPayload)
+	}
+
+	// Version 2.0 names the object "entity", version 1.0 names it "object".
+	object, current := text(meta, "entity"), "data"
+	recordID := text(meta, "entity_id")
+	occurredAt, _ := time.Parse(time.RFC3339, text(meta, "timestamp"))
+
+	if len(object) == 0 {
+		object, current = text(meta, "object"), "current"
+		recordID = text(meta, "id")
+		occurredAt = unixTime(meta, "timestamp", unixSeconds)
+	}
+
+	objectName, ok := pipedriveObjectNames[object]
+	if !ok {
+		objectName = naming.NewPluralString(object).String()
+	}
+
+	event := Event{
+		Change:     common.ChangeTypeUpdated,
+		ObjectName: objectName,
+		RecordID:   recordID,
+		OccurredAt: occurredAt,
+		Raw:        payload,
+	}
+
+	switch text(meta, "action") {
+	case "added", "create":
+		event.Change = common.ChangeTypeCreated
+	case "deleted", "delete":
+		event.Change = common.ChangeTypeDeleted
+	default:
+		event.ChangedFields = changedFields(payload[current], payload["previous"])
+	}
+
+	return []Event{event}, nil
+}
+
+// changedFields returns sorted keys of the previous state whose value differs from the current state.
+func changedFields(current, previous any) []string {
+	before, _ := previous.(map[string]any)
+	after, _ := current.(map[string]any)
+
+	var fields []string
+
+	for field, value := range before {
+		if !reflect.DeepEqual(value, after[field]) {
+			fields = append(fields, field)
+		}
+	}
+
+	slices.Sort(fields)
+
+	return fields
+}
+
+// decode keeps numbers as written, so that large ids are not rounded.
+func decode(body []byte, payload any) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(payload); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+
+	return nil
+}
+
+func nested(object map[string]any, path ...string) any {
+	var current any = object
+
+	for _, key := range path {
+		node, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		current = node[key]
+	}
+
+	return current
+}
+
+// text returns a string or number located by the path, empty string otherwise.
+func text(object map[string]any, path ...string) string {
+	switch value := nested(object, path...).(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	default:
+		return ""
+	}
+}
+
+func unixSeconds(seconds int64) time.Time {
+	return time.Unix(seconds, 0)
+}
+
+func unixTime(object map[string]any, key string, convert func(int64) time.Time) time.Time {
+	number, ok := object[key].(json.Number)
+	if !ok {
+		return time.Time{}
+	}
+
+	value, err := number.Int64()
+	if err != nil {
+		return time.Time{}
+	}
+
+	return convert(value).UTC()
+}
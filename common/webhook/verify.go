@@ -0,0 +1,200 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // nolint:gosec
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxAge is how old a signature may be, when the provider signs the time of the request.
+const DefaultMaxAge = 5 * time.Minute
+
+// HubSpotSignature verifies the v3 signature of HubSpot.
+// It is the HMAC SHA-256 of the method, URI, body and timestamp, keyed with the client secret of the app.
+// https://developers.hubspot.com/docs/api/webhooks/validating-requests
+type HubSpotSignature struct {
+	ClientSecret string
+	// BaseURL is the scheme and host at which HubSpot calls the webhook, such as "https://example.com".
+	// It is needed when the server is behind a proxy, otherwise it is taken from the request.
+	BaseURL string
+	// MaxAge of the signature, DefaultMaxAge is used when it is zero.
+	MaxAge time.Duration
+}
+
+// hubspotDecoder decodes characters which HubSpot doesn't encode when signing the URI.
+var hubspotDecoder = strings.NewReplacer( // nolint:gochecknoglobals
+	"%3A", ":", "%2F", "/", "%3F", "?", "%40", "@", "%21", "!", "%24", "$",
+	"%27", "'", "%28", "(", "%29", ")", "%2A", "*", "%2C", ",", "%3B", ";",
+)
+
+func (s HubSpotSignature) Verify(request *http.Request, body []byte) error {
+	if len(s.ClientSecret) == 0 {
+		return ErrMissingSecret
+	}
+
+	signature := request.Header.Get("X-HubSpot-Signature-v3")
+	timestamp := request.Header.Get("X-HubSpot-Request-Timestamp")
+
+	if len(signature) == 0 || len(timestamp) == 0 {
+		return ErrMissingSignature
+	}
+
+	millis, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: timestamp %q", ErrInvalidSignature, timestamp)
+	}
+
+	if err = checkAge(time.UnixMilli(millis), s.MaxAge); err != nil {
+		return err
+	}
+
+	baseURL := s.BaseURL
+	if len(baseURL) == 0 {
+		baseURL = requestScheme(request) + "://" + request.Host
+	}
+
+	uri := hubspotDecoder.Replace(strings.TrimSuffix(baseURL, "/") + request.URL.RequestURI())
+	message := request.Method + uri + string(body) + timestamp
+
+	return compare(signature, base64.StdEncoding.EncodeToString(sign(sha256Hash, s.ClientSecret, message)))
+}
+
+// IntercomSignature verifies the X-Hub-Signature header of Intercom.
+// It is the hex HMAC SHA-1 of the body, keyed with the client secret of the app.
+// https://developers.intercom.com/docs/webhooks/webhook-notifications#signed-notifications
+type IntercomSignature struct {
+	ClientSecret string
+}
+
+func (s IntercomSignature) Verify(request *http.Request, body []byte) error {
+	if len(s.ClientSecret) == 0 {
+		return ErrMissingSecret
+	}
+
+	signature, found := strings.CutPrefix(request.Header.Get("X-Hub-Signature"), "sha1=")
+	if !found || len(signature) == 0 {
+		return ErrMissingSignature
+	}
+
+	return compare(signature, hex.EncodeToString(sign(sha1Hash, s.ClientSecret, string(body))))
+}
+
+// ZendeskSignature verifies the signature of Zendesk webhooks.
+// It is the base64 HMAC SHA-256 of the timestamp and body, keyed with the signing secret of the webhook.
+// https://developer.zendesk.com/documentation/webhooks/verifying/
+type ZendeskSignature struct {
+	SigningSecret string
+	// MaxAge of the signature, DefaultMaxAge is used when it is zero.
+	MaxAge time.Duration
+}
+
+func (s ZendeskSignature) Verify(request *http.Request, body []byte) error {
+	if len(s.SigningSecret) == 0 {
+		return ErrMissingSecret
+	}
+
+	signature := request.Header.Get("X-Zendesk-Webhook-Signature")
+	timestamp := request.Header.Get("X-Zendesk-Webhook-Signature-Timestamp")
+
+	if len(signature) == 0 || len(timestamp) == 0 {
+		return ErrMissingSignature
+	}
+
+	signedAt, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return fmt.Errorf("%w: timestamp %q", ErrInvalidSignature, timestamp)
+	}
+
+	if err = checkAge(signedAt, s.MaxAge); err != nil {
+		return err
+	}
+
+	return compare(signature,
+		base64.StdEncoding.EncodeToString(sign(sha256Hash, s.SigningSecret, timestamp+string(body))))
+}
+
+// BasicAuth verifies credentials, which providers such as Pipedrive send instead of signing the request.
+// https://pipedrive.readme.io/docs/guide-for-webhooks#http-basic-authentication
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+func (a BasicAuth) Verify(request *http.Request, _ []byte) error {
+	if len(a.Username) == 0 || len(a.Password) == 0 {
+		return ErrMissingSecret
+	}
+
+	username, password, ok := request.BasicAuth()
+	if !ok {
+		return ErrMissingSignature
+	}
+
+	// Both are compared, so that the time taken doesn't tell which one is wrong.
+	usernameMatch := subtle.ConstantTimeCompare([]byte(username), []byte(a.Username))
+	passwordMatch := subtle.ConstantTimeCompare([]byte(password), []byte(a.Password))
+
+	if usernameMatch&passwordMatch != 1 {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+type hashAlgorithm int
+
+const (
+	sha1Hash hashAlgorithm = iota
+	sha256Hash
+)
+
+func sign(algorithm hashAlgorithm, secret, message string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	if algorithm == sha1Hash {
+		mac = hmac.New(sha1.New, []byte(secret))
+	}
+
+	mac.Write([]byte(message))
+
+	return mac.Sum(nil)
+}
+
+func compare(actual, expected string) error {
+	if !hmac.Equal([]byte(actual), []byte(expected)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func checkAge(signedAt time.Time, maxAge time.Duration) error {
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+
+	if age := time.Since(signedAt); age > maxAge || age < -maxAge {
+		return fmt.Errorf("%w: signed at %v", ErrExpiredSignature, signedAt)
+	}
+
+	return nil
+}
+
+func requestScheme(request *http.Request) string {
+	if proto := request.Header.Get("X-Forwarded-Proto"); len(proto) != 0 {
+		return proto
+	}
+
+	if request.TLS != nil {
+		return "https"
+	}
+
+	return "http"
+}
@@ -0,0 +1,124 @@
+// Package webhook receives events which providers push to subscriptions made by connectors.SubscribeConnector.
+// Requests are verified to come from the provider, then their payloads are normalized into change events.
+//
+//	handler := webhook.NewZendeskHandler(subscription.Secret)
+//
+//	http.HandleFunc("/webhooks/zendesk", func(w http.ResponseWriter, r *http.Request) {
+//		events, err := handler.Events(r)
+//		if errors.Is(err, webhook.ErrInvalidSignature) { ... }
+//		...
+//	})
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+var (
+	// ErrMissingSignature is returned when the request carries no signature or credentials.
+	ErrMissingSignature = errors.New("webhook request is not signed")
+	// ErrInvalidSignature is returned when the signature or credentials of the request don't match.
+	ErrInvalidSignature = errors.New("webhook signature is invalid")
+	// ErrExpiredSignature is returned when the request was signed too long ago, it may be replayed.
+	ErrExpiredSignature = errors.New("webhook signature has expired")
+	// ErrInvalidPayload is returned when the request body cannot be understood as events of the provider.
+	ErrInvalidPayload = errors.New("webhook payload is invalid")
+	// ErrMissingSecret is returned when the verifier was configured without a secret or credentials.
+	// Every request is rejected, as an empty key would let anyone sign them.
+	ErrMissingSecret = errors.New("webhook verifier has no secret")
+)
+
+// DefaultMaxBodySize limits the body of webhook requests, unless Handler.MaxBodySize is set.
+const DefaultMaxBodySize = 1 << 20
+
+// Event is a change of a record, as reported by the provider.
+type Event struct {
+	Change common.ChangeType `json:"change"`
+	// ObjectName is the object of the record, as named by the connector's read.
+	ObjectName string `json:"objectName"`
+	RecordID   string `json:"recordId"`
+	// ChangedFields lists fields of an updated record which were changed, when the provider reports them.
+	ChangedFields []string `json:"changedFields,omitempty"`
+	// OccurredAt is when the change happened, zero when the provider doesn't report it.
+	OccurredAt time.Time `json:"occurredAt"`
+	// Raw is the part of the payload describing this event.
+	Raw map[string]any `json:"raw,omitempty"`
+}
+
+// Verifier checks that the request was sent by the provider.
+type Verifier interface {
+	// Verify receives the request and its whole body, which has already been read.
+	Verify(request *http.Request, body []byte) error
+}
+
+// Parser converts the body of a verified request into events.
+type Parser func(body []byte) ([]Event, error)
+
+// Handler verifies webhook requests of one provider and normalizes their payloads.
+type Handler struct {
+	Verifier Verifier
+	Parser   Parser
+	// MaxBodySize in bytes, DefaultMaxBodySize is used when it is zero.
+	MaxBodySize int64
+}
+
+// Events reads the body of the request, verifies it and returns the events it carries.
+func (h Handler) Events(request *http.Request) ([]Event, error) {
+	limit := h.MaxBodySize
+	if limit <= 0 {
+		limit = DefaultMaxBodySize
+	}
+
+	body, err := io.ReadAll(io.LimitReader(request.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: body exceeds %d bytes", ErrInvalidPayload, limit)
+	}
+
+	if err = h.Verifier.Verify(request, body); err != nil {
+		return nil, err
+	}
+
+	return h.Parser(body)
+}
+
+// NewHubSpotHandler verifies requests with the client secret of the HubSpot app.
+func NewHubSpotHandler(clientSecret string) Handler {
+	return Handler{
+		Verifier: HubSpotSignature{ClientSecret: clientSecret},
+		Parser:   ParseHubSpot,
+	}
+}
+
+// NewIntercomHandler verifies requests with the client secret of the Intercom app.
+func NewIntercomHandler(clientSecret string) Handler {
+	return Handler{
+		Verifier: IntercomSignature{ClientSecret: clientSecret},
+		Parser:   ParseIntercom,
+	}
+}
+
+// NewZendeskHandler verifies requests with the signing secret of the Zendesk webhook.
+func NewZendeskHandler(signingSecret string) Handler {
+	return Handler{
+		Verifier: ZendeskSignature{SigningSecret: signingSecret},
+		Parser:   ParseZendesk,
+	}
+}
+
+// NewPipedriveHandler verifies requests with the basic auth credentials given to the Pipedrive webhook.
+func NewPipedriveHandler(username, password string) Handler {
+	return Handler{
+		Verifier: BasicAuth{Username: username, Password: password},
+		Parser:   ParsePipedrive,
+	}
+}
@@ -0,0 +1,248 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // nolint:gosec
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+const secret = "s3cr3t"
+
+func TestHandlerEvents(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		handler Handler
+		body    string
+		// sign adds signature or credentials to the request
+		sign         func(request *http.Request, body string)
+		expected     []Event
+		expectedErrs []error
+	}{
+		{
+			name:    "HubSpot property change",
+			handler: NewHubSpotHandler(secret),
+			body: `[{"eventId": 100, "subscriptionType": "contact.propertyChange", "objectId": 1246965,
+				"propertyName": "lifecyclestage", "propertyValue": "subscriber", "occurredAt": 1462216307945}]`,
+			sign: signHubSpot(now),
+			expected: []Event{{
+				Change:        common.ChangeTypeUpdated,
+				ObjectName:    "contacts",
+				RecordID:      "1246965",
+				ChangedFields: []string{"lifecyclestage"},
+				OccurredAt:    time.UnixMilli(1462216307945).UTC(),
+			}},
+		},
+		{
+			name:         "HubSpot stale signature",
+			handler:      NewHubSpotHandler(secret),
+			body:         `[]`,
+			sign:         signHubSpot(now.Add(-time.Hour)),
+			expectedErrs: []error{ErrExpiredSignature},
+		},
+		{
+			name:    "Intercom contact created",
+			handler: NewIntercomHandler(secret),
+			body: `{"type": "notification_event", "topic": "contact.user.created", "created_at": 1392731331,
+				"data": {"item": {"type": "contact", "id": "5f6ba02"}}}`,
+			sign: func(request *http.Request, body string) {
+				request.Header.Set("X-Hub-Signature", "sha1="+hex.EncodeToString(hmacOf(sha1Hash, body)))
+			},
+			expected: []Event{{
+				Change:     common.ChangeTypeCreated,
+				ObjectName: "contacts",
+				RecordID:   "5f6ba02",
+				OccurredAt: time.Unix(1392731331, 0).UTC(),
+			}},
+		},
+		{
+			name:    "Intercom tagging is an update",
+			handler: NewIntercomHandler(secret),
+			body:    `{"topic": "contact.tag.created", "data": {"item": {"type": "contact", "id": "5f6ba02"}}}`,
+			sign: func(request *http.Request, body string) {
+				request.Header.Set("X-Hub-Signature", "sha1="+hex.EncodeToString(hmacOf(sha1Hash, body)))
+			},
+			expected: []Event{{Change: common.ChangeTypeUpdated, ObjectName: "contacts", RecordID: "5f6ba02"}},
+		},
+		{
+			name:         "Intercom wrong secret",
+			handler:      NewIntercomHandler("other"),
+			body:         `{"topic": "ping"}`,
+			sign:         func(request *http.Request, _ string) { request.Header.Set("X-Hub-Signature", "sha1=00") },
+			expectedErrs: []error{ErrInvalidSignature},
+		},
+		{
+			name:    "Zendesk field change",
+			handler: NewZendeskHandler(secret),
+			body: `{"type": "zen:event-type:user.name_changed", "subject": "zen:user:31207417638931",
+				"time": "2024-10-17T08:00:00Z", "detail": {"id": "31207417638931"},
+				"event": {"current": "Ada", "previous": "Ada L."}}`,
+			sign: signZendesk(now),
+			expected: []Event{{
+				Change:        common.ChangeTypeUpdated,
+				ObjectName:    "users",
+				RecordID:      "31207417638931",
+				ChangedFields: []string{"name"},
+				OccurredAt:    time.Date(2024, 10, 17, 8, 0, 0, 0, time.UTC),
+			}},
+		},
+		{
+			name:         "Zendesk unsigned",
+			handler:      NewZendeskHandler(secret),
+			body:         `{}`,
+			sign:         func(*http.Request, string) {},
+			expectedErrs: []error{ErrMissingSignature},
+		},
+		{
+			name:    "Pipedrive v1 update",
+			handler: NewPipedriveHandler("amp", secret),
+			body: `{"v": 1, "meta": {"action": "updated", "object": "person", "id": 42, "timestamp": 1601986254},
+				"current": {"id": 42, "name": "Ada", "phone": "1"}, "previous": {"id": 42, "name": "Ada", "phone": "2"}}`,
+			sign: func(request *http.Request, _ string) { request.SetBasicAuth("amp", secret) },
+			expected: []Event{{
+				Change:        common.ChangeTypeUpdated,
+				ObjectName:    "persons",
+				RecordID:      "42",
+				ChangedFields: []string{"phone"},
+				OccurredAt:    time.Unix(1601986254, 0).UTC(),
+			}},
+		},
+		{
+			name:    "Pipedrive v2 deletion",
+			handler: NewPipedriveHandler("amp", secret),
+			body: `{"meta": {"action": "delete", "entity": "deal", "entity_id": "7",
+				"timestamp": "2024-10-17T08:00:00Z", "version": "2.0"}, "data": null}`,
+			sign: func(request *http.Request, _ string) { request.SetBasicAuth("amp", secret) },
+			expected: []Event{{
+				Change:     common.ChangeTypeDeleted,
+				ObjectName: "deals",
+				RecordID:   "7",
+				OccurredAt: time.Date(2024, 10, 17, 8, 0, 0, 0, time.UTC),
+			}},
+		},
+		{
+			name:    "Empty secret doesn't verify anything",
+			handler: NewIntercomHandler(""),
+			body:    `{"topic": "ping"}`,
+			sign: func(request *http.Request, body string) {
+				mac := hmac.New(sha1.New, nil)
+				mac.Write([]byte(body))
+				request.Header.Set("X-Hub-Signature", "sha1="+hex.EncodeToString(mac.Sum(nil)))
+			},
+			expectedErrs: []error{ErrMissingSecret},
+		},
+		{
+			name:         "Empty credentials don't verify anything",
+			handler:      NewPipedriveHandler("", ""),
+			body:         `{}`,
+			sign:         func(request *http.Request, _ string) { request.SetBasicAuth("", "") },
+			expectedErrs: []error{ErrMissingSecret},
+		},
+		{
+			name:         "Pipedrive wrong password",
+			handler:      NewPipedriveHandler("amp", secret),
+			body:         `{}`,
+			sign:         func(request *http.Request, _ string) { request.SetBasicAuth("amp", "guess") },
+			expectedErrs: []error{ErrInvalidSignature},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				events []Event
+				err    error
+			)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				events, err = tt.handler.Events(r)
+			}))
+			defer server.Close()
+
+			request, _ := http.NewRequest(http.MethodPost, server.URL+"/webhooks?tenant=1", strings.NewReader(tt.body))
+			tt.sign(request, tt.body)
+
+			response, sendErr := http.DefaultClient.Do(request)
+			if sendErr != nil {
+				t.Fatal(sendErr)
+			}
+
+			response.Body.Close()
+
+			for _, expectedErr := range tt.expectedErrs {
+				if !errors.Is(err, expectedErr) {
+					t.Fatalf("%s: expected error: (%v), got: (%v)", tt.name, expectedErr, err)
+				}
+			}
+
+			if len(tt.expectedErrs) != 0 {
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+
+			for i := range events {
+				events[i].Raw = nil
+			}
+
+			if !reflect.DeepEqual(events, tt.expected) {
+				actual, _ := json.Marshal(events)
+				t.Fatalf("%s: expected: (%v), got: (%s)", tt.name, tt.expected, actual)
+			}
+		})
+	}
+}
+
+func signHubSpot(at time.Time) func(request *http.Request, body string) {
+	return func(request *http.Request, body string) {
+		timestamp := strconv.FormatInt(at.UnixMilli(), 10)
+		message := request.Method + request.URL.String() + body + timestamp
+
+		request.Header.Set("X-HubSpot-Request-Timestamp", timestamp)
+		request.Header.Set("X-HubSpot-Signature-v3", base64.StdEncoding.EncodeToString(hmacOf(sha256Hash, message)))
+	}
+}
+
+func signZendesk(at time.Time) func(request *http.Request, body string) {
+	return func(request *http.Request, body string) {
+		timestamp := at.UTC().Format(time.RFC3339)
+
+		request.Header.Set("X-Zendesk-Webhook-Signature-Timestamp", timestamp)
+		request.Header.Set("X-Zendesk-Webhook-Signature",
+			base64.StdEncoding.EncodeToString(hmacOf(sha256Hash, timestamp+body)))
+	}
+}
+
+// hmacOf signs independently of the package implementation.
+func hmacOf(algorithm hashAlgorithm, message string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	if algorithm == sha1Hash {
+		mac = hmac.New(sha1.New, []byte(secret))
+	}
+
+	mac.Write([]byte(message))
+
+	return mac.Sum(nil)
+}
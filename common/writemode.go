@@ -0,0 +1,160 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrUnknownWriteMode is returned when WriteParams.Mode is not one of the known modes.
+	ErrUnknownWriteMode = errors.New("unknown write mode")
+	// ErrUnexpectedRecordID is returned when a record is created with RecordId.
+	ErrUnexpectedRecordID = errors.New("record id is not expected when creating a record")
+	// ErrRecordDataNotObject is returned when fields cannot be cleared because RecordData is not a JSON object.
+	ErrRecordDataNotObject = errors.New("record data is not an object")
+)
+
+// WriteMode is the operation a write applies to a record.
+type WriteMode string
+
+const (
+	// WriteModeDefault creates a record without RecordId and updates the record with RecordId.
+	WriteModeDefault WriteMode = ""
+	// WriteModeCreate creates a new record.
+	WriteModeCreate WriteMode = "create"
+	// WriteModeUpdate changes fields present in RecordData, other fields are untouched.
+	WriteModeUpdate WriteMode = "update"
+	// WriteModeReplace overwrites the record with RecordData, fields missing from RecordData are reset.
+	WriteModeReplace WriteMode = "replace"
+	// WriteModeUpsert updates the record matched by WriteParams.ExternalIdField or creates it when there is none.
+	WriteModeUpsert WriteMode = "upsert"
+)
+
+// WriteOutcome is what happened to the written record.
+type WriteOutcome string
+
+const (
+	WriteOutcomeCreated WriteOutcome = "created"
+	WriteOutcomeUpdated WriteOutcome = "updated"
+)
+
+// NewWriteOutcome returns created or updated outcome.
+func NewWriteOutcome(created bool) WriteOutcome {
+	if created {
+		return WriteOutcomeCreated
+	}
+
+	return WriteOutcomeUpdated
+}
+
+// ResolveMode returns the mode of the write. The default mode is resolved to create or update depending on RecordId.
+func (p WriteParams) ResolveMode() WriteMode {
+	if p.Mode != WriteModeDefault {
+		return p.Mode
+	}
+
+	if len(p.RecordId) == 0 {
+		return WriteModeCreate
+	}
+
+	return WriteModeUpdate
+}
+
+// WriteMethods tells which HTTP method a provider uses for every write mode.
+// Modes without a method are not supported.
+type WriteMethods map[WriteMode]WriteMethod
+
+// Select returns the HTTP method for the mode of the write.
+// Unsupported modes return ErrOperationNotSupportedForObject.
+func (m WriteMethods) Select(config WriteParams) (WriteMethod, error) {
+	mode := config.ResolveMode()
+
+	method, ok := m[mode]
+	if !ok || method == nil {
+		return nil, fmt.Errorf("%w: %v mode of %v", ErrOperationNotSupportedForObject, mode, config.ObjectName)
+	}
+
+	return method, nil
+}
+
+// RecordDataWithClearedFields returns RecordData where every field of ClearFields is set to the clear value,
+// which is nil for most providers. Dotted fields, such as "properties.phone", clear nested values.
+// When RecordData is a list of records, fields are cleared in each of them.
+// RecordData is returned as is, when there is nothing to clear.
+func (p WriteParams) RecordDataWithClearedFields(clearValue any) (any, error) {
+	if len(p.ClearFields) == 0 {
+		return p.RecordData, nil
+	}
+
+	data, err := copyRecordData(p.RecordData)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []any{data}
+	if list, ok := data.([]any); ok {
+		records = list
+	}
+
+	for _, record := range records {
+		object, ok := record.(map[string]any)
+		if !ok {
+			return nil, ErrRecordDataNotObject
+		}
+
+		for _, field := range p.ClearFields {
+			if err = clearField(object, strings.Split(field, "."), clearValue); err != nil {
+				return nil, fmt.Errorf("%w: %v", err, field)
+			}
+		}
+	}
+
+	return data, nil
+}
+
+// copyRecordData makes a copy of RecordData made of maps and lists, so that the caller's data is not modified.
+// Numbers are kept as json.Number, so that large ids are not rounded.
+func copyRecordData(data any) (any, error) {
+	if data == nil {
+		return make(map[string]any), nil
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var result any
+	if err = decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func clearField(record map[string]any, path []string, clearValue any) error {
+	if len(path) == 1 {
+		record[path[0]] = clearValue
+
+		return nil
+	}
+
+	nested, found := record[path[0]]
+	if !found || nested == nil {
+		nested = make(map[string]any)
+		record[path[0]] = nested
+	}
+
+	object, ok := nested.(map[string]any)
+	if !ok {
+		return ErrRecordDataNotObject
+	}
+
+	return clearField(object, path[1:], clearValue)
+}
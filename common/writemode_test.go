@@ -0,0 +1,178 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestWriteParamsValidateMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        WriteParams
+		expectedErrs []error
+	}{
+		{
+			name:  "Default mode creates",
+			input: WriteParams{ObjectName: "contacts", RecordData: map[string]any{}},
+		},
+		{
+			name:         "Create rejects record id",
+			input:        WriteParams{ObjectName: "contacts", RecordId: "1", RecordData: map[string]any{}, Mode: WriteModeCreate},
+			expectedErrs: []error{ErrUnexpectedRecordID},
+		},
+		{
+			name:         "Replace requires record id",
+			input:        WriteParams{ObjectName: "contacts", RecordData: map[string]any{}, Mode: WriteModeReplace},
+			expectedErrs: []error{ErrMissingRecordID},
+		},
+		{
+			name:         "Upsert requires external id field",
+			input:        WriteParams{ObjectName: "contacts", RecordData: map[string]any{}, Mode: WriteModeUpsert},
+			expectedErrs: []error{ErrMissingExternalIdField},
+		},
+		{
+			name: "Upsert requires external id value",
+			input: WriteParams{
+				ObjectName: "contacts", RecordData: map[string]any{}, Mode: WriteModeUpsert, ExternalIdField: "email",
+			},
+			expectedErrs: []error{ErrMissingRecordID},
+		},
+		{
+			name:         "Unknown mode",
+			input:        WriteParams{ObjectName: "contacts", RecordData: map[string]any{}, Mode: "merge"},
+			expectedErrs: []error{ErrUnknownWriteMode},
+		},
+		{
+			name:  "Clearing fields needs no record data",
+			input: WriteParams{ObjectName: "contacts", RecordId: "1", ClearFields: []string{"phone"}},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			testutils.CheckErrors(t, tt.name, tt.expectedErrs, tt.input.ValidateParams())
+		})
+	}
+}
+
+func TestWriteMethodsSelect(t *testing.T) {
+	t.Parallel()
+
+	var used WriteMode
+
+	methodFor := func(mode WriteMode) WriteMethod {
+		return func(context.Context, string, any, ...Header) (*JSONHTTPResponse, error) {
+			used = mode
+
+			return nil, nil // nolint:nilnil
+		}
+	}
+
+	methods := WriteMethods{
+		WriteModeCreate: methodFor(WriteModeCreate),
+		WriteModeUpdate: methodFor(WriteModeUpdate),
+	}
+
+	write, err := methods.Select(WriteParams{ObjectName: "contacts", RecordId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _ = write(context.Background(), "", nil)
+
+	if used != WriteModeUpdate {
+		t.Fatalf("expected update method, got: %v", used)
+	}
+
+	_, err = methods.Select(WriteParams{ObjectName: "contacts", RecordId: "1", Mode: WriteModeReplace})
+	if !errors.Is(err, ErrOperationNotSupportedForObject) {
+		t.Fatalf("expected: (%v), got: (%v)", ErrOperationNotSupportedForObject, err)
+	}
+}
+
+func TestRecordDataWithClearedFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        WriteParams
+		clearValue   any
+		expected     any
+		expectedErrs []error
+	}{
+		{
+			name:     "Nothing to clear",
+			input:    WriteParams{RecordData: []string{"as", "is"}},
+			expected: []string{"as", "is"},
+		},
+		{
+			name:     "Top level and nested fields",
+			input:    WriteParams{RecordData: map[string]any{"name": "Ada"}, ClearFields: []string{"phone", "address.city"}},
+			expected: map[string]any{"name": "Ada", "phone": nil, "address": map[string]any{"city": nil}},
+		},
+		{
+			name:       "Provider clear value",
+			input:      WriteParams{ClearFields: []string{"phone"}},
+			clearValue: "",
+			expected:   map[string]any{"phone": ""},
+		},
+		{
+			name:     "Every record of a list",
+			input:    WriteParams{RecordData: []any{map[string]any{"id": "1"}}, ClearFields: []string{"phone"}},
+			expected: []any{map[string]any{"id": "1", "phone": nil}},
+		},
+		{
+			name: "Large numbers are not rounded",
+			input: WriteParams{
+				RecordData:  map[string]any{"id": uint64(9007199254740993)},
+				ClearFields: []string{"phone"},
+			},
+			expected: map[string]any{"id": json.Number("9007199254740993"), "phone": nil},
+		},
+		{
+			name:         "Nested field of a scalar",
+			input:        WriteParams{RecordData: map[string]any{"address": "Paris"}, ClearFields: []string{"address.city"}},
+			expectedErrs: []error{ErrRecordDataNotObject},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := tt.input.RecordDataWithClearedFields(tt.clearValue)
+			testutils.CheckErrors(t, tt.name, tt.expectedErrs, err)
+
+			if !reflect.DeepEqual(output, tt.expected) {
+				t.Fatalf("%s: expected: (%v), got: (%v)", tt.name, tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestRecordDataWithClearedFieldsKeepsInput(t *testing.T) {
+	t.Parallel()
+
+	data := map[string]any{"name": "Ada"}
+
+	if _, err := (WriteParams{RecordData: data, ClearFields: []string{"phone"}}).RecordDataWithClearedFields(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(data) != 1 {
+		t.Fatalf("record data of the caller was modified: %v", data)
+	}
+}
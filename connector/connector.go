@@ -6,10 +6,13 @@ import (
 	"github.com/amp-labs/connectors/providers"
 )
 
+// Connector offers proxy calls to any provider in the catalog.
+// Providers described by a Spec can also be read, written and deleted from.
 type Connector struct {
 	ProviderInfo *providers.ProviderInfo
 	Client       *common.JSONHTTPClient
 	provider     providers.Provider
+	spec         *Spec
 }
 
 func NewConnector(
@@ -45,7 +48,18 @@ func NewConnector(
 	conn.Client.HTTPClient.ErrorHandler = conn.interpretError
 
 	// Set base URL
-	conn.Client.HTTPClient.Base = conn.ProviderInfo.BaseURL
+	conn.setBaseURL(conn.ProviderInfo.BaseURL)
+
+	// Objects are described by the given spec, otherwise by the one bundled for the provider
+	conn.spec = params.spec
+	if conn.spec == nil {
+		conn.spec, _ = LookupSpec(conn.provider)
+	}
 
 	return conn, nil
 }
+
+func (c *Connector) setBaseURL(newURL string) {
+	c.ProviderInfo.BaseURL = newURL
+	c.Client.HTTPClient.Base = newURL
+}
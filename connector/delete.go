@@ -0,0 +1,34 @@
+package connector
+
+import (
+	"context"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// Delete removes a record located at the object path followed by the record ID.
+func (c *Connector) Delete(ctx context.Context, config common.DeleteParams) (*common.DeleteResult, error) {
+	if err := config.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	object, err := c.lookupObject(config.ObjectName, OperationDelete)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := c.getURL(object.Path, config.RecordId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 200 OK or 204 NoContent is expected
+	_, err = c.Client.Delete(ctx, url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.DeleteResult{
+		Success: true,
+	}, nil
+}
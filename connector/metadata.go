@@ -0,0 +1,23 @@
+package connector
+
+import (
+	"context"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/staticschema"
+)
+
+// ListObjectMetadata describes objects using static schemas attached to the spec.
+func (c *Connector) ListObjectMetadata(
+	ctx context.Context, objectNames []string,
+) (*common.ListObjectMetadataResult, error) {
+	if c.spec == nil {
+		return nil, ErrMissingSpec
+	}
+
+	if c.spec.Schemas == nil {
+		return nil, common.ErrNotImplemented
+	}
+
+	return c.spec.Schemas.Select(staticschema.RootModuleID, objectNames)
+}
@@ -23,6 +23,7 @@ type Option = func(*parameters)
 
 type parameters struct {
 	provider providers.Provider
+	spec     *Spec
 	paramsbuilder.Client
 	paramsbuilder.Workspace
 }
@@ -34,6 +35,12 @@ func (p parameters) ValidateParams() error {
 
 	// workspace is optional
 
+	if p.spec != nil {
+		if err := p.spec.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return errors.Join(
 		p.Client.ValidateParams(),
 	)
@@ -61,3 +68,11 @@ func WithWorkspace(workspaceRef string) Option {
 		params.WithWorkspace(workspaceRef)
 	}
 }
+
+// WithSpec sets the spec describing how objects are read and written.
+// It replaces the spec bundled for the provider, if any.
+func WithSpec(spec *Spec) Option {
+	return func(params *parameters) {
+		params.spec = spec
+	}
+}
@@ -0,0 +1,27 @@
+package connector
+
+import (
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/jsonquery"
+	"github.com/amp-labs/connectors/common/pagination"
+	"github.com/spyzhov/ajson"
+)
+
+// getRecords returns records located under the dotted path, empty path refers to the body itself.
+func getRecords(path string) common.RecordsFunc {
+	return func(node *ajson.Node) ([]map[string]any, error) {
+		zoom, key := pagination.SplitPath(path)
+
+		arr, err := jsonquery.New(node, zoom...).Array(key, false)
+		if err != nil {
+			return nil, err
+		}
+
+		return jsonquery.Convertor.ArrayToMap(arr)
+	}
+}
+
+// noNextPage is used by objects returned in a single response.
+func noNextPage(*ajson.Node) (string, error) {
+	return "", nil
+}
@@ -0,0 +1,84 @@
+package connector
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/urlbuilder"
+)
+
+// Read returns a page of records of an object described by the spec.
+// The next page token is encoded by the pagination strategy of the object.
+func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common.ReadResult, error) {
+	if err := config.ValidateParams(true); err != nil {
+		return nil, err
+	}
+
+	object, err := c.lookupObject(config.ObjectName, OperationRead)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.FilterBy != nil || len(config.AssociatedObjects) != 0 || config.Deleted {
+		return nil, common.ErrOperationNotSupportedForObject
+	}
+
+	if !config.Since.IsZero() && len(object.SinceParam) == 0 {
+		return nil, common.ErrOperationNotSupportedForObject
+	}
+
+	url, err := c.buildReadURL(config, object)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := c.Client.Get(ctx, url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	nextPage := noNextPage
+	if strategy := object.strategy(); strategy != nil {
+		nextPage = strategy.NextPage(url, rsp.Headers)
+	}
+
+	return common.ParseResult(rsp,
+		getRecords(object.recordsPath()),
+		nextPage,
+		common.MakeMarshaledDataFunc(config.PreserveFieldCase),
+		config.Fields,
+	)
+}
+
+// buildReadURL makes the URL of the first page, the pagination strategy moves it to the requested page.
+// The same ReadParams, including Since, are expected for every page.
+func (c *Connector) buildReadURL(config common.ReadParams, object ObjectSpec) (*urlbuilder.URL, error) {
+	url, err := c.getURL(object.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !config.Since.IsZero() {
+		url.WithQueryParam(object.SinceParam, formatSince(config.Since, object.SinceFormat))
+	}
+
+	strategy := object.strategy()
+	if strategy == nil {
+		return url, nil
+	}
+
+	return strategy.PageURL(url, config.NextPage)
+}
+
+func formatSince(since time.Time, format string) string {
+	switch format {
+	case "":
+		return since.UTC().Format(time.RFC3339)
+	case SinceFormatUnix:
+		return strconv.FormatInt(since.Unix(), 10)
+	default:
+		return since.UTC().Format(format)
+	}
+}
@@ -0,0 +1,317 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/handy"
+	"github.com/amp-labs/connectors/common/jsonquery"
+	"github.com/amp-labs/connectors/common/pagination"
+	"github.com/amp-labs/connectors/providers"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+)
+
+// testSpec has an object for every pagination style.
+var testSpec = &Spec{ // nolint:gochecknoglobals
+	Defaults: ObjectSpec{
+		RecordsPath: handy.Pointers.Str("data"),
+	},
+	Objects: map[string]ObjectSpec{
+		"contacts": {
+			Path:        "v1/contacts",
+			RecordsPath: handy.Pointers.Str("data.items"),
+			Pagination: &PaginationSpec{
+				Style:         PaginationCursor,
+				CursorPath:    "meta.next_cursor",
+				CursorParam:   "cursor",
+				PageSizeParam: "limit",
+				PageSize:      50,
+			},
+			SinceParam:    "updated_after",
+			SinceFormat:   SinceFormatUnix,
+			ReplaceMethod: http.MethodPut,
+			Operations:    []Operation{OperationRead, OperationCreate, OperationUpdate, OperationDelete},
+		},
+		"deals": {
+			Path: "v1/deals",
+			Pagination: &PaginationSpec{
+				Style:         PaginationOffset,
+				OffsetParam:   "skip",
+				PageSizeParam: "take",
+				PageSize:      2,
+			},
+		},
+		"repos": {
+			Path:        "v1/repos",
+			RecordsPath: handy.Pointers.Str(""),
+			Pagination:  &PaginationSpec{Style: PaginationLinkHeader},
+			SinceParam:  "since",
+		},
+		"tickets": {
+			Path: "v1/tickets",
+			Pagination: &PaginationSpec{
+				Style:       PaginationNextURL,
+				NextURLPath: "links.next",
+			},
+			IDField:            "ticket_id",
+			UpdateMethod:       http.MethodPut,
+			RequestWrapper:     "ticket",
+			ResponseRecordPath: handy.Pointers.Str("ticket"),
+			Operations:         []Operation{OperationCreate, OperationUpdate},
+		},
+	},
+}
+
+func TestRead(t *testing.T) { // nolint:funlen,gocognit,cyclop
+	t.Parallel()
+
+	tests := []testroutines.Read{
+		{
+			Name:         "Read object must be included",
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingObjects},
+		},
+		{
+			Name:         "Unknown object is not supported",
+			Input:        common.ReadParams{ObjectName: "butterflies", Fields: connectors.Fields("id")},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			Name:         "Object may disallow reading",
+			Input:        common.ReadParams{ObjectName: "tickets", Fields: connectors.Fields("id")},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			Name: "Since is rejected without since parameter",
+			Input: common.ReadParams{
+				ObjectName: "deals",
+				Fields:     connectors.Fields("id"),
+				Since:      time.Now(),
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			Name:  "Records must be under the records path",
+			Input: common.ReadParams{ObjectName: "contacts", Fields: connectors.Fields("id")},
+			Server: mockserver.Fixed{
+				Setup:  mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusOK, `{"data": {}}`),
+			}.Server(),
+			ExpectedErrs: []error{jsonquery.ErrKeyNotFound},
+		},
+		{
+			Name: "Cursor is read from the body and since is sent as epoch",
+			Input: common.ReadParams{
+				ObjectName: "contacts",
+				Fields:     connectors.Fields("name"),
+				Since:      time.Date(2024, 9, 19, 4, 30, 45, 0, time.UTC),
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.PathSuffix("/v1/contacts"),
+					mockcond.QueryParam("limit", "50"),
+					mockcond.QueryParam("updated_after", "1726720245"),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{
+					"data": {"items": [{"id": "1", "Name": "Ada"}]},
+					"meta": {"next_cursor": "c2"}
+				}`),
+			}.Server(),
+			Comparator: func(serverURL string, actual, expected *common.ReadResult) bool {
+				return actual.Rows == expected.Rows &&
+					actual.Data[0].Fields["name"] == "Ada" &&
+					actual.NextPage == expected.NextPage
+			},
+			Expected:     &common.ReadResult{Rows: 1, NextPage: "eyJjIjoiYzIifQ"}, // {"c":"c2"}
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Next page sends the cursor",
+			Input: common.ReadParams{
+				ObjectName: "contacts",
+				Fields:     connectors.Fields("id"),
+				NextPage:   "eyJjIjoiYzIifQ",
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.QueryParam("cursor", "c2"),
+					mockcond.QueryParam("limit", "50"),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{"data": {"items": []}}`),
+			}.Server(),
+			Expected:     &common.ReadResult{Data: []common.ReadResultRow{}, Done: true},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Next page token must be valid",
+			Input: common.ReadParams{
+				ObjectName: "contacts",
+				Fields:     connectors.Fields("id"),
+				NextPage:   "https://example.com/v1/contacts?cursor=c2",
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{pagination.ErrInvalidToken},
+		},
+		{
+			Name:  "Missing cursor is the last page",
+			Input: common.ReadParams{ObjectName: "contacts", Fields: connectors.Fields("id")},
+			Server: mockserver.Fixed{
+				Setup: mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusOK, `{
+					"data": {"items": [{"id": "1"}]},
+					"meta": {"next_cursor": null}
+				}`),
+			}.Server(),
+			Expected: &common.ReadResult{
+				Rows: 1,
+				Data: []common.ReadResultRow{{
+					Fields: map[string]any{"id": "1"},
+					Raw:    map[string]any{"id": "1"},
+				}},
+				Done: true,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Offset advances by full page",
+			Input: common.ReadParams{ObjectName: "deals", Fields: connectors.Fields("id")},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.QueryParam("take", "2"),
+					mockcond.QueryParam("skip", "0"),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{"data": [{"id": "1"}, {"id": "2"}]}`),
+			}.Server(),
+			Comparator: func(serverURL string, actual, expected *common.ReadResult) bool {
+				return actual.Rows == expected.Rows && !actual.Done && actual.NextPage == expected.NextPage
+			},
+			Expected:     &common.ReadResult{Rows: 2, NextPage: "eyJvIjoyfQ"}, // {"o":2}
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Next page skips read records",
+			Input: common.ReadParams{
+				ObjectName: "deals",
+				Fields:     connectors.Fields("id"),
+				NextPage:   "eyJvIjoyfQ",
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.QueryParam("skip", "2"),
+				Then:  mockserver.ResponseString(http.StatusOK, `{"data": []}`),
+			}.Server(),
+			Expected:     &common.ReadResult{Data: []common.ReadResultRow{}, Done: true},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Offset stops at partial page",
+			Input: common.ReadParams{ObjectName: "deals", Fields: connectors.Fields("id")},
+			Server: mockserver.Fixed{
+				Setup:  mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusOK, `{"data": [{"id": "1"}]}`),
+			}.Server(),
+			Comparator: func(serverURL string, actual, expected *common.ReadResult) bool {
+				return actual.Rows == expected.Rows && actual.Done && len(actual.NextPage) == 0
+			},
+			Expected:     &common.ReadResult{Rows: 1},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Link header points to the next page",
+			Input: common.ReadParams{ObjectName: "repos", Fields: connectors.Fields("id")},
+			Server: mockserver.Fixed{
+				Setup: func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.Header().Set("Link", `</v1/repos?page=5>; rel="last", </v1/repos?page=2>; rel="next"`)
+				},
+				Always: mockserver.ResponseString(http.StatusOK, `[{"id": 1}, {"id": 2}]`),
+			}.Server(),
+			Comparator: func(serverURL string, actual, expected *common.ReadResult) bool {
+				return actual.Rows == expected.Rows && !actual.Done && len(actual.NextPage) != 0
+			},
+			Expected:     &common.ReadResult{Rows: 2},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Next page at another host is not followed",
+			Input: common.ReadParams{
+				ObjectName: "repos",
+				Fields:     connectors.Fields("id"),
+				NextPage:   "eyJ1IjoiaHR0cHM6Ly9leGFtcGxlLmNvbS92MS9yZXBvcz9wYWdlPTIifQ", // https://example.com/v1/repos?page=2
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{pagination.ErrForeignURL},
+		},
+		{
+			Name: "Since is sent in RFC 3339 by default",
+			Input: common.ReadParams{
+				ObjectName: "repos",
+				Fields:     connectors.Fields("id"),
+				Since:      time.Date(2024, 9, 19, 4, 30, 45, 0, time.FixedZone("UTC-8", -8*60*60)),
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.QueryParam("since", "2024-09-19T12:30:45Z"),
+				Then:  mockserver.ResponseString(http.StatusOK, `[]`),
+			}.Server(),
+			Expected:     &common.ReadResult{Data: []common.ReadResultRow{}, Done: true},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.ReadConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestReadWithoutSpec(t *testing.T) {
+	t.Parallel()
+
+	conn, err := NewConnector(providers.Salesforce,
+		WithAuthenticatedClient(http.DefaultClient),
+		WithWorkspace("test-workspace"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = conn.Read(context.Background(), common.ReadParams{ObjectName: "Account", Fields: connectors.Fields("id")})
+	if err == nil || err.Error() != ErrMissingSpec.Error() {
+		t.Fatalf("expected %v, got %v", ErrMissingSpec, err)
+	}
+}
+
+func constructTestConnector(serverURL string) (*Connector, error) {
+	connector, err := NewConnector(providers.Asana,
+		WithAuthenticatedClient(http.DefaultClient),
+		WithSpec(testSpec),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// for testing we want to redirect calls to our mock server
+	connector.setBaseURL(serverURL)
+
+	return connector, nil
+}
@@ -0,0 +1,377 @@
+package connector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/amp-labs/connectors/common/pagination"
+	"github.com/amp-labs/connectors/internal/staticschema"
+)
+
+var (
+	// ErrMissingSpec is returned when the provider has no spec describing its objects.
+	ErrMissingSpec = errors.New("provider has no connector spec")
+
+	// ErrInvalidSpec is returned when the spec cannot be used to make requests.
+	ErrInvalidSpec = errors.New("invalid connector spec")
+)
+
+// Operation is an action which can be performed on an object.
+type Operation string
+
+const (
+	OperationRead   Operation = "read"
+	OperationCreate Operation = "create"
+	OperationUpdate Operation = "update"
+	OperationDelete Operation = "delete"
+)
+
+// PaginationStyle tells where the next page is found in the list response.
+type PaginationStyle string
+
+const (
+	// PaginationNone means every object is returned in a single response.
+	PaginationNone PaginationStyle = "none"
+	// PaginationCursor reads an opaque cursor from the body, it is sent back as a query parameter.
+	PaginationCursor PaginationStyle = "cursor"
+	// PaginationOffset counts records already read. It stops once a page has fewer records than its size.
+	PaginationOffset PaginationStyle = "offset"
+	// PaginationLinkHeader follows the "next" relation of the Link header, see RFC 8288.
+	PaginationLinkHeader PaginationStyle = "linkHeader"
+	// PaginationNextURL follows the URL of the next page found in the body.
+	PaginationNextURL PaginationStyle = "nextURL"
+	// PaginationPageNumber requests pages by their number. It stops once a page has fewer records than its size.
+	PaginationPageNumber PaginationStyle = "pageNumber"
+)
+
+// SinceFormatUnix sends the time as seconds since the Unix epoch.
+const SinceFormatUnix = "unix"
+
+// Spec declares how objects of a REST API are read, written and deleted.
+// Settings of an object fall back to Defaults, so that only the differences are listed per object.
+//
+// Example of a spec stored as JSON:
+//
+//	{
+//	  "defaults": {
+//	    "recordsPath": "data",
+//	    "pagination": {"style": "cursor", "cursorPath": "next_page.offset", "cursorParam": "offset"},
+//	    "operations": ["read"]
+//	  },
+//	  "objects": {
+//	    "projects": {"path": "1.0/projects", "operations": ["read", "create", "update", "delete"]}
+//	  }
+//	}
+type Spec struct {
+	// Defaults apply to every object unless the object overrides them.
+	Defaults ObjectSpec `json:"defaults"`
+	// Objects is a map of object names to their settings.
+	Objects map[string]ObjectSpec `json:"objects"`
+	// Schemas is optional static metadata, which serves ListObjectMetadata.
+	// Objects without Path resolve their URL path from the root module of Schemas.
+	Schemas *staticschema.Metadata `json:"-"`
+}
+
+// ObjectSpec describes requests made for a single object. Empty values fall back to Spec.Defaults.
+type ObjectSpec struct {
+	// Path is the URL path of the object relative to the provider base URL, e.g. "v1/contacts".
+	// Records are updated and deleted at Path followed by the record ID.
+	Path string `json:"path,omitempty"`
+	// Operations lists allowed actions. Reading is the only action allowed when it is empty.
+	Operations []Operation `json:"operations,omitempty"`
+	// RecordsPath is the dotted location of the records array in the list response, e.g. "data.items".
+	// Empty string means the response itself is an array, nil falls back to defaults.
+	RecordsPath *string `json:"recordsPath,omitempty"`
+	// Pagination describes how to move to the next page. No pagination is used when absent.
+	Pagination *PaginationSpec `json:"pagination,omitempty"`
+	// SinceParam is the query parameter for incremental reading, e.g. "updated_since".
+	// Objects without it reject ReadParams.Since.
+	SinceParam string `json:"sinceParam,omitempty"`
+	// SinceFormat is the Go layout of SinceParam value or "unix" for epoch seconds. Defaults to RFC 3339 in UTC.
+	SinceFormat string `json:"sinceFormat,omitempty"`
+	// IDField is the field holding the record identifier. Defaults to "id".
+	IDField string `json:"idField,omitempty"`
+	// CreateMethod is the HTTP method used to create records. Defaults to POST.
+	CreateMethod string `json:"createMethod,omitempty"`
+	// UpdateMethod is the HTTP method used to update records. Defaults to PATCH.
+	UpdateMethod string `json:"updateMethod,omitempty"`
+	// ReplaceMethod is the HTTP method used to overwrite records, when the provider tells it apart from update.
+	// Objects without it reject the replace write mode.
+	ReplaceMethod string `json:"replaceMethod,omitempty"`
+	// RequestWrapper is the key the record is nested under in the write payload, e.g. "data".
+	RequestWrapper string `json:"requestWrapper,omitempty"`
+	// ResponseRecordPath is the dotted location of the written record in the write response.
+	// Empty string means the response itself is the record, nil falls back to defaults.
+	ResponseRecordPath *string `json:"responseRecordPath,omitempty"`
+}
+
+// PaginationSpec describes how pages of records are requested.
+type PaginationSpec struct {
+	Style PaginationStyle `json:"style"`
+	// CursorPath is the dotted location of the cursor in the body. Used by PaginationCursor.
+	CursorPath string `json:"cursorPath,omitempty"`
+	// CursorParam is the query parameter carrying the cursor. Used by PaginationCursor.
+	CursorParam string `json:"cursorParam,omitempty"`
+	// NextURLPath is the dotted location of the next page URL in the body. Used by PaginationNextURL.
+	NextURLPath string `json:"nextURLPath,omitempty"`
+	// OffsetParam is the query parameter with the number of records to skip. Used by PaginationOffset.
+	OffsetParam string `json:"offsetParam,omitempty"`
+	// PageParam is the query parameter with the page number. Used by PaginationPageNumber.
+	PageParam string `json:"pageParam,omitempty"`
+	// FirstPage is the number of the first page, defaults to 1. Used by PaginationPageNumber.
+	FirstPage int64 `json:"firstPage,omitempty"`
+	// HasMorePath is the dotted location of a boolean telling if more pages exist.
+	// Optional for PaginationCursor, PaginationOffset and PaginationPageNumber.
+	HasMorePath string `json:"hasMorePath,omitempty"`
+	// PageSizeParam is the query parameter with the number of records per page. Optional for all styles.
+	PageSizeParam string `json:"pageSizeParam,omitempty"`
+	// PageSize is sent via PageSizeParam. Required by PaginationOffset and PaginationPageNumber.
+	PageSize int `json:"pageSize,omitempty"`
+}
+
+// LoadSpec parses the spec stored as JSON and validates it.
+func LoadSpec(data []byte) (*Spec, error) {
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSpec, err)
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &spec, nil
+}
+
+// Validate checks that every object has what is needed to build requests.
+func (s *Spec) Validate() error {
+	for name := range s.Objects {
+		object, _ := s.Object(name)
+		if err := object.validate(); err != nil {
+			return fmt.Errorf("%w: object [%v]: %w", ErrInvalidSpec, name, err)
+		}
+	}
+
+	return nil
+}
+
+// Object returns settings of the object merged with defaults.
+func (s *Spec) Object(objectName string) (ObjectSpec, bool) {
+	object, ok := s.Objects[objectName]
+	if !ok {
+		return ObjectSpec{}, false
+	}
+
+	return object.withDefaults(s.Defaults), true
+}
+
+// ObjectNames returns sorted names of all objects.
+func (s *Spec) ObjectNames() []string {
+	names := make([]string, 0, len(s.Objects))
+	for name := range s.Objects {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Supports tells whether the action is allowed.
+func (o ObjectSpec) Supports(operation Operation) bool {
+	if len(o.Operations) == 0 {
+		return operation == OperationRead
+	}
+
+	return slices.Contains(o.Operations, operation)
+}
+
+func (o ObjectSpec) withDefaults(defaults ObjectSpec) ObjectSpec { // nolint:cyclop
+	if len(o.Path) == 0 {
+		o.Path = defaults.Path
+	}
+
+	if len(o.Operations) == 0 {
+		o.Operations = defaults.Operations
+	}
+
+	if o.RecordsPath == nil {
+		o.RecordsPath = defaults.RecordsPath
+	}
+
+	if o.Pagination == nil {
+		o.Pagination = defaults.Pagination
+	}
+
+	if len(o.SinceParam) == 0 {
+		o.SinceParam = defaults.SinceParam
+	}
+
+	if len(o.SinceFormat) == 0 {
+		o.SinceFormat = defaults.SinceFormat
+	}
+
+	if len(o.IDField) == 0 {
+		o.IDField = firstNonEmpty(defaults.IDField, "id")
+	}
+
+	if len(o.CreateMethod) == 0 {
+		o.CreateMethod = firstNonEmpty(defaults.CreateMethod, http.MethodPost)
+	}
+
+	if len(o.UpdateMethod) == 0 {
+		o.UpdateMethod = firstNonEmpty(defaults.UpdateMethod, http.MethodPatch)
+	}
+
+	if len(o.ReplaceMethod) == 0 {
+		o.ReplaceMethod = defaults.ReplaceMethod
+	}
+
+	if len(o.RequestWrapper) == 0 {
+		o.RequestWrapper = defaults.RequestWrapper
+	}
+
+	if o.ResponseRecordPath == nil {
+		o.ResponseRecordPath = defaults.ResponseRecordPath
+	}
+
+	return o
+}
+
+// recordsPath is the location of records, the response itself by default.
+func (o ObjectSpec) recordsPath() string {
+	if o.RecordsPath == nil {
+		return ""
+	}
+
+	return *o.RecordsPath
+}
+
+// responseRecordPath is the location of the written record, the response itself by default.
+func (o ObjectSpec) responseRecordPath() string {
+	if o.ResponseRecordPath == nil {
+		return ""
+	}
+
+	return *o.ResponseRecordPath
+}
+
+func (o ObjectSpec) validate() error {
+	for _, operation := range o.Operations {
+		switch operation {
+		case OperationRead, OperationCreate, OperationUpdate, OperationDelete:
+		default:
+			return fmt.Errorf("unknown operation %q", operation) // nolint:goerr113
+		}
+	}
+
+	methods := []string{o.CreateMethod, o.UpdateMethod}
+	if len(o.ReplaceMethod) != 0 {
+		methods = append(methods, o.ReplaceMethod)
+	}
+
+	for _, method := range methods {
+		switch method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+		default:
+			return fmt.Errorf("unsupported write method %q", method) // nolint:goerr113
+		}
+	}
+
+	if o.Pagination == nil {
+		return nil
+	}
+
+	return o.Pagination.validate()
+}
+
+func (p PaginationSpec) validate() error {
+	switch p.Style {
+	case PaginationNone, PaginationLinkHeader:
+	case PaginationCursor:
+		if len(p.CursorPath) == 0 || len(p.CursorParam) == 0 {
+			return errors.New("cursor pagination requires cursorPath and cursorParam") // nolint:goerr113
+		}
+	case PaginationOffset:
+		if len(p.OffsetParam) == 0 || len(p.PageSizeParam) == 0 || p.PageSize <= 0 {
+			return errors.New("offset pagination requires offsetParam, pageSizeParam and pageSize") // nolint:goerr113
+		}
+	case PaginationPageNumber:
+		if len(p.PageParam) == 0 || len(p.PageSizeParam) == 0 || p.PageSize <= 0 {
+			return errors.New("page number pagination requires pageParam, pageSizeParam and pageSize") // nolint:goerr113
+		}
+	case PaginationNextURL:
+		if len(p.NextURLPath) == 0 {
+			return errors.New("next URL pagination requires nextURLPath") // nolint:goerr113
+		}
+	default:
+		return fmt.Errorf("unknown pagination style %q", p.Style) // nolint:goerr113
+	}
+
+	return nil
+}
+
+// strategy returns how pages of the object are requested. Nil means there is a single page.
+func (o ObjectSpec) strategy() pagination.Strategy { // nolint:ireturn
+	if o.Pagination == nil {
+		return nil
+	}
+
+	spec := o.Pagination
+
+	switch spec.Style {
+	case PaginationCursor:
+		return pagination.Cursor{
+			Path:        spec.CursorPath,
+			Param:       spec.CursorParam,
+			HasMorePath: spec.HasMorePath,
+			SizeParam:   spec.PageSizeParam,
+			PageSize:    spec.PageSize,
+		}
+	case PaginationOffset:
+		return pagination.Offset{
+			Param:       spec.OffsetParam,
+			SizeParam:   spec.PageSizeParam,
+			PageSize:    spec.PageSize,
+			RecordsPath: o.recordsPath(),
+			HasMorePath: spec.HasMorePath,
+		}
+	case PaginationPageNumber:
+		return pagination.PageNumber{
+			Param:       spec.PageParam,
+			FirstPage:   spec.FirstPage,
+			SizeParam:   spec.PageSizeParam,
+			PageSize:    spec.PageSize,
+			RecordsPath: o.recordsPath(),
+			HasMorePath: spec.HasMorePath,
+		}
+	case PaginationLinkHeader:
+		return pagination.LinkHeader{
+			SizeParam: spec.PageSizeParam,
+			PageSize:  spec.PageSize,
+		}
+	case PaginationNextURL:
+		return pagination.NextURL{
+			Path:      spec.NextURLPath,
+			SizeParam: spec.PageSizeParam,
+			PageSize:  spec.PageSize,
+		}
+	case PaginationNone:
+		return nil
+	default:
+		return nil
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if len(value) != 0 {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,100 @@
+package connector
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/amp-labs/connectors/providers"
+)
+
+func TestBundledSpecs(t *testing.T) {
+	t.Parallel()
+
+	files, err := filepath.Glob("specs/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := LoadSpec(data); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+
+		provider := strings.TrimSuffix(filepath.Base(file), ".json")
+		if _, err := providers.ReadInfo(provider); err != nil {
+			t.Fatalf("%s: spec must be named after a provider: %v", file, err)
+		}
+
+		if _, ok := LookupSpec(provider); !ok {
+			t.Fatalf("%s: spec is not registered", file)
+		}
+	}
+}
+
+func TestLoadSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       string
+		expectedErr error
+	}{
+		{
+			name:        "Malformed JSON",
+			input:       `{"objects": [`,
+			expectedErr: ErrInvalidSpec,
+		},
+		{
+			name:        "Unknown pagination style",
+			input:       `{"objects": {"users": {"pagination": {"style": "pages"}}}}`,
+			expectedErr: ErrInvalidSpec,
+		},
+		{
+			name:        "Defaults are validated per object",
+			input:       `{"defaults": {"pagination": {"style": "cursor"}}, "objects": {"users": {}}}`,
+			expectedErr: ErrInvalidSpec,
+		},
+		{
+			name:        "Unknown write method",
+			input:       `{"objects": {"users": {"updateMethod": "GET"}}}`,
+			expectedErr: ErrInvalidSpec,
+		},
+		{
+			name: "Object overrides defaults",
+			input: `{
+				"defaults": {"recordsPath": "data", "idField": "gid"},
+				"objects": {"users": {"path": "users", "recordsPath": ""}}
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec, err := LoadSpec([]byte(tt.input))
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			users, _ := spec.Object("users")
+			if users.recordsPath() != "" || users.IDField != "gid" || users.UpdateMethod != "PATCH" {
+				t.Fatalf("unexpected object spec %+v", users)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package connector
+
+import (
+	"embed"
+	"path"
+	"strings"
+
+	"github.com/amp-labs/connectors/providers"
+)
+
+// Specs of providers which are served by this connector. A file is named after the provider, e.g. "asana.json".
+//
+//go:embed specs/*.json
+var specFiles embed.FS
+
+// registeredSpecs maps providers to their specs. Invalid files are caught by tests.
+var registeredSpecs = loadSpecs() // nolint:gochecknoglobals
+
+// LookupSpec returns the spec bundled for the provider.
+func LookupSpec(provider providers.Provider) (*Spec, bool) {
+	spec, ok := registeredSpecs[provider]
+
+	return spec, ok
+}
+
+func loadSpecs() map[providers.Provider]*Spec {
+	specs := make(map[providers.Provider]*Spec)
+
+	entries, err := specFiles.ReadDir("specs")
+	if err != nil {
+		return specs
+	}
+
+	for _, entry := range entries {
+		data, err := specFiles.ReadFile(path.Join("specs", entry.Name()))
+		if err != nil {
+			continue
+		}
+
+		spec, err := LoadSpec(data)
+		if err != nil {
+			continue
+		}
+
+		specs[strings.TrimSuffix(entry.Name(), ".json")] = spec
+	}
+
+	return specs
+}
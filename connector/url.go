@@ -0,0 +1,39 @@
+package connector
+
+import (
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/urlbuilder"
+	"github.com/amp-labs/connectors/internal/staticschema"
+)
+
+func (c *Connector) getURL(parts ...string) (*urlbuilder.URL, error) {
+	return urlbuilder.New(c.ProviderInfo.BaseURL, parts...)
+}
+
+// lookupObject returns object settings if the operation is allowed.
+// The URL path is resolved from static schemas when the spec doesn't list it.
+func (c *Connector) lookupObject(objectName string, operation Operation) (ObjectSpec, error) {
+	if c.spec == nil {
+		return ObjectSpec{}, ErrMissingSpec
+	}
+
+	object, ok := c.spec.Object(objectName)
+	if !ok || !object.Supports(operation) {
+		return ObjectSpec{}, common.ErrOperationNotSupportedForObject
+	}
+
+	if len(object.Path) == 0 && c.spec.Schemas != nil {
+		path, err := c.spec.Schemas.LookupURLPath(staticschema.RootModuleID, objectName)
+		if err != nil {
+			return ObjectSpec{}, err
+		}
+
+		object.Path = path
+	}
+
+	if len(object.Path) == 0 {
+		return ObjectSpec{}, common.ErrResolvingURLPathForObject
+	}
+
+	return object, nil
+}
@@ -0,0 +1,127 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/jsonquery"
+	"github.com/amp-labs/connectors/common/pagination"
+	"github.com/spyzhov/ajson"
+)
+
+// Write creates a record, or updates it when RecordId is given.
+// Records are created at the object path, and updated or replaced at the object path followed by the record ID.
+// Upsert is not supported.
+func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*common.WriteResult, error) {
+	if err := config.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	operation := OperationCreate
+	if config.ResolveMode() != common.WriteModeCreate {
+		operation = OperationUpdate
+	}
+
+	object, err := c.lookupObject(config.ObjectName, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	methods := common.WriteMethods{
+		common.WriteModeCreate: c.writeMethod(object.CreateMethod),
+		common.WriteModeUpdate: c.writeMethod(object.UpdateMethod),
+	}
+	if len(object.ReplaceMethod) != 0 {
+		methods[common.WriteModeReplace] = c.writeMethod(object.ReplaceMethod)
+	}
+
+	write, err := methods.Select(config)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := c.getURL(object.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if operation == OperationUpdate {
+		url.AddPath(config.RecordId)
+	}
+
+	payload, err := config.RecordDataWithClearedFields(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(object.RequestWrapper) != 0 {
+		payload = map[string]any{object.RequestWrapper: payload}
+	}
+
+	rsp, err := write(ctx, url.String(), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	body, ok := rsp.Body()
+	if !ok {
+		// it is unlikely to have no payload
+		return &common.WriteResult{
+			Success:  true,
+			RecordId: config.RecordId,
+		}, nil
+	}
+
+	return constructWriteResult(body, object, config.RecordId)
+}
+
+func (c *Connector) writeMethod(method string) common.WriteMethod {
+	switch method {
+	case http.MethodPut:
+		return c.Client.Put
+	case http.MethodPatch:
+		return c.Client.Patch
+	default:
+		return c.Client.Post
+	}
+}
+
+// constructWriteResult reads the written record from the response.
+// The record ID from the response takes precedence over the one which was sent.
+func constructWriteResult(body *ajson.Node, object ObjectSpec, recordID string) (*common.WriteResult, error) {
+	zoom, key := pagination.SplitPath(object.responseRecordPath())
+
+	record, err := jsonquery.New(body, zoom...).Object(key, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if record == nil {
+		return &common.WriteResult{
+			Success:  true,
+			RecordId: recordID,
+		}, nil
+	}
+
+	data, err := jsonquery.Convertor.ObjectToMap(record)
+	if err != nil {
+		return nil, err
+	}
+
+	// Identifiers are either strings or numbers.
+	switch id := data[object.IDField].(type) {
+	case string:
+		recordID = id
+	case float64:
+		recordID = strconv.FormatFloat(id, 'f', -1, 64)
+	}
+
+	return &common.WriteResult{
+		Success:  true,
+		RecordId: recordID,
+		Errors:   nil,
+		Data:     data,
+	}, nil
+}
@@ -0,0 +1,233 @@
+package connector
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+)
+
+func TestWrite(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []testroutines.Write{
+		{
+			Name:         "Write object must be included",
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingObjects},
+		},
+		{
+			Name:         "Object may disallow writing",
+			Input:        common.WriteParams{ObjectName: "deals", RecordData: map[string]any{}},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			Name: "Record is created with POST and read from the response",
+			Input: common.WriteParams{
+				ObjectName: "contacts",
+				RecordData: map[string]any{"name": "Ada"},
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPOST(),
+					mockcond.PathSuffix("/v1/contacts"),
+					mockcond.Body(`{"name":"Ada"}`),
+				},
+				Then: mockserver.ResponseString(http.StatusCreated, `{"id": 42, "name": "Ada"}`),
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success:  true,
+				RecordId: "42",
+				Data:     map[string]any{"id": float64(42), "name": "Ada"},
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Record is updated with configured method and wrapper",
+			Input: common.WriteParams{
+				ObjectName: "tickets",
+				RecordId:   "t-7",
+				RecordData: map[string]any{"status": "closed"},
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPUT(),
+					mockcond.PathSuffix("/v1/tickets/t-7"),
+					mockcond.Body(`{"ticket":{"status":"closed"}}`),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{
+					"ticket": {"ticket_id": "t-7", "status": "closed"}
+				}`),
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success:  true,
+				RecordId: "t-7",
+				Data:     map[string]any{"ticket_id": "t-7", "status": "closed"},
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Empty response keeps the record ID",
+			Input: common.WriteParams{
+				ObjectName: "contacts",
+				RecordId:   "42",
+				RecordData: map[string]any{"name": "Ada"},
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPATCH(),
+					mockcond.PathSuffix("/v1/contacts/42"),
+				},
+				Then: mockserver.Response(http.StatusNoContent),
+			}.Server(),
+			Expected:     &common.WriteResult{Success: true, RecordId: "42"},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Record is replaced with configured method",
+			Input: common.WriteParams{
+				ObjectName: "contacts",
+				RecordId:   "42",
+				RecordData: map[string]any{"name": "Ada"},
+				Mode:       common.WriteModeReplace,
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPUT(),
+					mockcond.PathSuffix("/v1/contacts/42"),
+				},
+				Then: mockserver.Response(http.StatusNoContent),
+			}.Server(),
+			Expected:     &common.WriteResult{Success: true, RecordId: "42"},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Object without replace method cannot be replaced",
+			Input: common.WriteParams{
+				ObjectName: "tickets",
+				RecordId:   "t-7",
+				RecordData: map[string]any{"status": "closed"},
+				Mode:       common.WriteModeReplace,
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			Name: "Upsert is not supported",
+			Input: common.WriteParams{
+				ObjectName:      "contacts",
+				RecordId:        "ada@example.com",
+				RecordData:      map[string]any{"name": "Ada"},
+				Mode:            common.WriteModeUpsert,
+				ExternalIdField: "email",
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			Name: "Cleared fields are sent as null inside the wrapper",
+			Input: common.WriteParams{
+				ObjectName:  "tickets",
+				RecordId:    "t-7",
+				RecordData:  map[string]any{"status": "closed"},
+				ClearFields: []string{"assignee"},
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPUT(),
+					mockcond.Body(`{"ticket":{"status":"closed","assignee":null}}`),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{
+					"ticket": {"ticket_id": "t-7", "status": "closed", "assignee": null}
+				}`),
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success:  true,
+				RecordId: "t-7",
+				Data:     map[string]any{"ticket_id": "t-7", "status": "closed", "assignee": nil},
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Record ID is not expected on create",
+			Input: common.WriteParams{
+				ObjectName: "contacts",
+				RecordId:   "42",
+				RecordData: map[string]any{"name": "Ada"},
+				Mode:       common.WriteModeCreate,
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrUnexpectedRecordID},
+		},
+		{
+			Name:  "Error response is understood",
+			Input: common.WriteParams{ObjectName: "contacts", RecordData: map[string]any{}},
+			Server: mockserver.Fixed{
+				Setup:  mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusUnprocessableEntity, `{"error": "name is required"}`),
+			}.Server(),
+			ExpectedErrs: []error{common.ErrCaller},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.WriteConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	tests := []testroutines.Delete{
+		{
+			Name:         "Object may disallow deleting",
+			Input:        common.DeleteParams{ObjectName: "tickets", RecordId: "t-7"},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			Name:  "Successful delete",
+			Input: common.DeleteParams{ObjectName: "contacts", RecordId: "42"},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodDELETE(),
+					mockcond.PathSuffix("/v1/contacts/42"),
+				},
+				Then: mockserver.Response(http.StatusNoContent),
+			}.Server(),
+			Expected:     &common.DeleteResult{Success: true},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.DeleteConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
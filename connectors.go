@@ -71,6 +71,47 @@ type DeleteConnector interface {
 	Delete(ctx context.Context, params DeleteParams) (*DeleteResult, error)
 }
 
+// BulkWriteConnector is an interface that extends the Connector interface with the ability
+// to write many records asynchronously. A job is started with a stream of records, then its status
+// is polled until it is done, after which results are available per record.
+// Providers offering synchronous batch APIs can implement it by returning an already complete job.
+// Modes supported by a provider are listed in providers.Support.BulkWrite.
+type BulkWriteConnector interface {
+	Connector
+
+	// StartBulkWrite uploads records and starts the job.
+	StartBulkWrite(ctx context.Context, params BulkWriteParams) (*common.BulkJob, error)
+	// GetBulkWriteStatus reports the progress of the job.
+	GetBulkWriteStatus(ctx context.Context, jobId string) (*common.BulkJobStatus, error)
+	// GetBulkWriteResults returns the outcome of every processed record. The job must be done.
+	GetBulkWriteResults(ctx context.Context, jobId string) (*common.BulkWriteResults, error)
+}
+
+// HealthCheckConnector is an interface that extends the Connector interface with the ability
+// to validate credentials without reading any records.
+type HealthCheckConnector interface {
+	Connector
+
+	// CheckConnection calls a cheap provider endpoint. The report is returned even when the check fails,
+	// telling how long it took. Rejected credentials are reported by an error matching one of
+	// common.ErrAccessToken, common.ErrInvalidGrant, common.ErrForbidden or common.ErrApiDisabled.
+	CheckConnection(ctx context.Context) (*HealthReport, error)
+}
+
+// SubscribeConnector is an interface that extends the Connector interface with the ability
+// to manage webhooks, through which the provider pushes changes of records.
+// Events delivered to the webhook are verified and normalized by the webhook package.
+type SubscribeConnector interface {
+	Connector
+
+	// Subscribe registers a webhook. Providers which need one webhook per change type return many subscriptions.
+	Subscribe(ctx context.Context, params SubscribeParams) ([]common.Subscription, error)
+	// ListSubscriptions returns webhooks registered at the provider.
+	ListSubscriptions(ctx context.Context) ([]common.Subscription, error)
+	// DeleteSubscription removes the webhook.
+	DeleteSubscription(ctx context.Context, id string) error
+}
+
 // ObjectMetadataConnector is an interface that extends the Connector interface with
 // the ability to list object metadata.
 type ObjectMetadataConnector interface {
@@ -94,9 +135,14 @@ type (
 	WriteParams              = common.WriteParams
 	DeleteParams             = common.DeleteParams
 	ReadResult               = common.ReadResult
+	ReadResultRow            = common.ReadResultRow
 	WriteResult              = common.WriteResult
 	DeleteResult             = common.DeleteResult
 	ListObjectMetadataResult = common.ListObjectMetadataResult
+	BulkWriteParams          = common.BulkWriteParams
+	HealthReport             = common.HealthReport
+	SubscribeParams          = common.SubscribeParams
+	Subscription             = common.Subscription
 
 	ErrorWithStatus = common.HTTPStatusError
 )
@@ -1,6 +1,6 @@
 module github.com/amp-labs/connectors
 
-go 1.21.0
+go 1.23.0
 
 require (
 	github.com/PuerkitoBio/goquery v1.9.2
@@ -16,6 +16,7 @@ require (
 	github.com/spyzhov/ajson v0.9.5
 	github.com/stretchr/testify v1.9.0
 	golang.org/x/oauth2 v0.23.0
+	golang.org/x/sync v0.8.0
 	golang.org/x/text v0.19.0
 )
 
@@ -38,6 +38,7 @@ func (r *Metadata) Select(
 			list.Result[objectName] = common.ObjectMetadata{
 				DisplayName: v.DisplayName,
 				FieldsMap:   v.FieldsMap,
+				Fields:      v.Fields,
 			}
 		} else {
 			return nil, fmt.Errorf("%w: unknown object [%v]", ErrObjectNotFound, objectName)
@@ -68,6 +69,7 @@ func (r *Metadata) SelectOne(
 		mtd = common.ObjectMetadata{
 			DisplayName: v.DisplayName,
 			FieldsMap:   v.FieldsMap,
+			Fields:      v.Fields,
 		}
 	} else {
 		return nil, fmt.Errorf("%w: unknown object [%v]", ErrObjectNotFound, objectName)
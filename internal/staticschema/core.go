@@ -1,6 +1,7 @@
 package staticschema
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/amp-labs/connectors/common"
@@ -44,6 +45,9 @@ type Object struct {
 	// FieldsMap is a map of field names to field display names
 	FieldsMap map[string]string `json:"fields"`
 
+	// Fields is a map of field names to their detailed description. Optional.
+	Fields map[string]common.FieldMetadata `json:"fieldMetadata,omitempty"`
+
 	// DocsURL points to docs endpoint. Optional.
 	DocsURL *string `json:"docs,omitempty"`
 }
@@ -73,6 +77,32 @@ func (r *Metadata) Add(
 	data.FieldsMap[fieldName] = fieldName
 }
 
+// AddFieldMetadata will store detailed description of the field.
+// The object must be registered beforehand using Metadata.Add.
+// NOTE: empty module id is treated as root module.
+func (r *Metadata) AddFieldMetadata(
+	moduleID common.ModuleID,
+	objectName, fieldName string,
+	field common.FieldMetadata,
+) error {
+	moduleID = moduleIdentifier(moduleID)
+
+	data, ok := r.Modules[moduleID].Objects[objectName]
+	if !ok {
+		return fmt.Errorf("%w: unknown object [%v]", ErrObjectNotFound, objectName)
+	}
+
+	if data.Fields == nil {
+		data.Fields = make(map[string]common.FieldMetadata)
+	}
+
+	data.FieldsMap[fieldName] = field.DisplayName
+	data.Fields[fieldName] = field
+	r.Modules[moduleID].Objects[objectName] = data
+
+	return nil
+}
+
 func (r *Metadata) refactorLongestCommonPath() {
 	for moduleID, module := range r.Modules {
 		var (
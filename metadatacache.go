@@ -0,0 +1,367 @@
+package connectors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultMetadataCacheTTL is how long object metadata is kept unless configured otherwise.
+const DefaultMetadataCacheTTL = time.Hour
+
+// MetadataCacheEntry is object metadata as kept by MetadataCacheStorage.
+type MetadataCacheEntry struct {
+	// ObjectName is the key under which the connector reported the metadata.
+	ObjectName string `json:"objectName"`
+	// Metadata describes the object.
+	Metadata common.ObjectMetadata `json:"metadata"`
+	// ExpiresAt is the moment the entry becomes stale. Zero time means it never expires.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// IsExpired reports whether the entry is stale at the given moment.
+func (e MetadataCacheEntry) IsExpired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && !now.Before(e.ExpiresAt)
+}
+
+// MetadataCacheStorage keeps cached object metadata.
+// Implementations must be safe for concurrent use.
+type MetadataCacheStorage interface {
+	// Get returns the entry stored under the key. The boolean is false if there is none.
+	Get(ctx context.Context, key string) (*MetadataCacheEntry, bool, error)
+	// Set stores the entry under the key, replacing any previous one.
+	Set(ctx context.Context, key string, entry *MetadataCacheEntry) error
+	// Delete removes the entry. Deleting a missing entry is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// MetadataCacheOption configures MetadataCacheConnector.
+type MetadataCacheOption = func(params *metadataCacheParams)
+
+type metadataCacheParams struct {
+	ttl       time.Duration
+	storage   MetadataCacheStorage
+	namespace string
+	now       func() time.Time
+}
+
+// WithMetadataCacheTTL sets how long metadata is kept. Zero means entries never expire.
+func WithMetadataCacheTTL(ttl time.Duration) MetadataCacheOption {
+	return func(params *metadataCacheParams) {
+		params.ttl = ttl
+	}
+}
+
+// WithMetadataCacheStorage replaces the default in-memory storage.
+func WithMetadataCacheStorage(storage MetadataCacheStorage) MetadataCacheOption {
+	return func(params *metadataCacheParams) {
+		params.storage = storage
+	}
+}
+
+// WithMetadataCacheNamespace prefixes storage keys, which defaults to the provider name.
+// Use it when several connections of the same provider share the storage, ex: one namespace per customer.
+func WithMetadataCacheNamespace(namespace string) MetadataCacheOption {
+	return func(params *metadataCacheParams) {
+		params.namespace = namespace
+	}
+}
+
+// MetadataCacheConnector wraps ObjectMetadataConnector and keeps object metadata between calls.
+// Only objects missing from the cache are requested from the connector, all in a single call.
+// Identical concurrent requests are merged into one. Failures, including the ones reported
+// per object in ListObjectMetadataResult.Errors, are never cached.
+type MetadataCacheConnector struct {
+	ObjectMetadataConnector
+
+	params metadataCacheParams
+	group  singleflight.Group
+}
+
+// NewMetadataCacheConnector adds caching to ListObjectMetadata of the given connector.
+// By default, metadata is kept in memory for DefaultMetadataCacheTTL.
+func NewMetadataCacheConnector(
+	conn ObjectMetadataConnector, opts ...MetadataCacheOption,
+) *MetadataCacheConnector {
+	params := metadataCacheParams{
+		ttl:       DefaultMetadataCacheTTL,
+		namespace: string(conn.Provider()),
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	if params.storage == nil {
+		params.storage = NewInMemoryMetadataCacheStorage()
+	}
+
+	return &MetadataCacheConnector{
+		ObjectMetadataConnector: conn,
+		params:                  params,
+	}
+}
+
+// ListObjectMetadata returns cached metadata, requesting only the missing or expired objects.
+func (c *MetadataCacheConnector) ListObjectMetadata(
+	ctx context.Context, objectNames []string,
+) (*ListObjectMetadataResult, error) {
+	result := &ListObjectMetadataResult{
+		Result: make(map[string]common.ObjectMetadata),
+		Errors: make(map[string]error),
+	}
+
+	missing := make([]string, 0)
+
+	for _, objectName := range objectNames {
+		entry, ok, err := c.params.storage.Get(ctx, c.key(objectName))
+		if err != nil {
+			return nil, err
+		}
+
+		if ok && !entry.IsExpired(c.params.now()) {
+			result.Result[entry.ObjectName] = entry.Metadata
+		} else {
+			missing = append(missing, objectName)
+		}
+	}
+
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	fetched, err := c.fetch(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, metadata := range fetched.Result {
+		result.Result[name] = metadata
+	}
+
+	for name, objectErr := range fetched.Errors {
+		result.Errors[name] = objectErr
+	}
+
+	return result, nil
+}
+
+// Invalidate removes objects from the cache, they will be requested again on next use.
+func (c *MetadataCacheConnector) Invalidate(ctx context.Context, objectNames ...string) error {
+	for _, objectName := range objectNames {
+		if err := c.params.storage.Delete(ctx, c.key(objectName)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetch requests metadata from the connector and stores successful results.
+// Concurrent calls for the same set of objects share a single request.
+// The shared request outlives cancellation of the caller who started it, so that other callers
+// are not failed on its behalf. Each caller stops waiting once its own context is done.
+func (c *MetadataCacheConnector) fetch(
+	ctx context.Context, objectNames []string,
+) (*ListObjectMetadataResult, error) {
+	sorted := slices.Clone(objectNames)
+	slices.Sort(sorted)
+
+	sharedCtx := context.WithoutCancel(ctx)
+
+	response := c.group.DoChan(strings.Join(sorted, ","), func() (any, error) {
+		result, err := c.ObjectMetadataConnector.ListObjectMetadata(sharedCtx, objectNames)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.store(sharedCtx, objectNames, result); err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case shared := <-response:
+		if shared.Err != nil {
+			return nil, shared.Err
+		}
+
+		return shared.Val.(*ListObjectMetadataResult), nil // nolint:forcetypeassert
+	}
+}
+
+// store saves metadata of every requested object which was described without an error.
+// Connectors may report objects under a different case, therefore both forms are looked up.
+func (c *MetadataCacheConnector) store(
+	ctx context.Context, objectNames []string, result *ListObjectMetadataResult,
+) error {
+	var expiresAt time.Time
+	if c.params.ttl > 0 {
+		expiresAt = c.params.now().Add(c.params.ttl)
+	}
+
+	for _, objectName := range objectNames {
+		for _, name := range []string{objectName, strings.ToLower(objectName)} {
+			if _, failed := result.Errors[name]; failed {
+				break
+			}
+
+			metadata, ok := result.Result[name]
+			if !ok {
+				continue
+			}
+
+			if err := c.params.storage.Set(ctx, c.key(objectName), &MetadataCacheEntry{
+				ObjectName: name,
+				Metadata:   metadata,
+				ExpiresAt:  expiresAt,
+			}); err != nil {
+				return err
+			}
+
+			break
+		}
+	}
+
+	return nil
+}
+
+func (c *MetadataCacheConnector) key(objectName string) string {
+	return c.params.namespace + "/" + objectName
+}
+
+// InMemoryMetadataCacheStorage keeps metadata in a map for the lifetime of the process.
+type InMemoryMetadataCacheStorage struct {
+	mutex   sync.RWMutex
+	entries map[string]MetadataCacheEntry
+}
+
+// NewInMemoryMetadataCacheStorage creates an empty in-memory storage.
+func NewInMemoryMetadataCacheStorage() *InMemoryMetadataCacheStorage {
+	return &InMemoryMetadataCacheStorage{
+		entries: make(map[string]MetadataCacheEntry),
+	}
+}
+
+func (s *InMemoryMetadataCacheStorage) Get(_ context.Context, key string) (*MetadataCacheEntry, bool, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	return &entry, true, nil
+}
+
+func (s *InMemoryMetadataCacheStorage) Set(_ context.Context, key string, entry *MetadataCacheEntry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.entries[key] = *entry
+
+	return nil
+}
+
+func (s *InMemoryMetadataCacheStorage) Delete(_ context.Context, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.entries, key)
+
+	return nil
+}
+
+// FileMetadataCacheStorage keeps every entry as a JSON file in a directory,
+// which lets the cache survive restarts and be shared between processes.
+// Files that cannot be parsed are treated as missing.
+type FileMetadataCacheStorage struct {
+	dir string
+}
+
+// NewFileMetadataCacheStorage uses the given directory, creating it if needed.
+func NewFileMetadataCacheStorage(dir string) (*FileMetadataCacheStorage, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil { // nolint:gomnd,mnd
+		return nil, fmt.Errorf("failed to create metadata cache directory: %w", err)
+	}
+
+	return &FileMetadataCacheStorage{dir: dir}, nil
+}
+
+func (s *FileMetadataCacheStorage) Get(_ context.Context, key string) (*MetadataCacheEntry, bool, error) {
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, false, nil
+		}
+
+		return nil, false, fmt.Errorf("failed to read metadata cache: %w", err)
+	}
+
+	var entry MetadataCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false, nil //nolint:nilerr
+	}
+
+	return &entry, true, nil
+}
+
+// Set writes to a temporary file first, so that readers never observe a partially written entry.
+func (s *FileMetadataCacheStorage) Set(_ context.Context, key string, entry *MetadataCacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write metadata cache: %w", err)
+	}
+
+	defer os.Remove(file.Name()) // nolint:errcheck
+
+	if _, err = file.Write(data); err != nil {
+		file.Close()
+
+		return fmt.Errorf("failed to write metadata cache: %w", err)
+	}
+
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("failed to write metadata cache: %w", err)
+	}
+
+	if err = os.Rename(file.Name(), s.path(key)); err != nil {
+		return fmt.Errorf("failed to write metadata cache: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileMetadataCacheStorage) Delete(_ context.Context, key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete metadata cache: %w", err)
+	}
+
+	return nil
+}
+
+// path escapes the key, so that it is a single file name even if it contains slashes.
+func (s *FileMetadataCacheStorage) path(key string) string {
+	return filepath.Join(s.dir, url.PathEscape(key)+".json")
+}
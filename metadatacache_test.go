@@ -0,0 +1,246 @@
+package connectors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/mock"
+)
+
+var errDescribeFailed = errors.New("describe failed")
+
+// countingMetadataConnector describes any object except "broken", recording every requested object.
+func countingMetadataConnector(t *testing.T, requested *[][]string, mutex *sync.Mutex) *mock.Connector {
+	t.Helper()
+
+	conn, err := mock.NewConnector(
+		mock.WithClient(http.DefaultClient),
+		mock.WithListObjectMetadata(func(ctx context.Context, objectNames []string) (*ListObjectMetadataResult, error) {
+			mutex.Lock()
+			*requested = append(*requested, objectNames)
+			mutex.Unlock()
+
+			result := &ListObjectMetadataResult{
+				Result: make(map[string]common.ObjectMetadata),
+				Errors: make(map[string]error),
+			}
+
+			for _, name := range objectNames {
+				if name == "broken" {
+					result.Errors[name] = errDescribeFailed
+
+					continue
+				}
+
+				result.Result[name] = common.ObjectMetadata{
+					DisplayName: name,
+					FieldsMap:   map[string]string{"id": "ID"},
+				}
+			}
+
+			return result, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create mock connector: %v", err)
+	}
+
+	return conn
+}
+
+func TestMetadataCacheConnector(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	fileStorage, err := NewFileMetadataCacheStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	storages := map[string]MetadataCacheStorage{
+		"In memory":  NewInMemoryMetadataCacheStorage(),
+		"Filesystem": fileStorage,
+	}
+
+	for name, storage := range storages {
+		storage := storage // rebind, omit loop side effects for parallel goroutine
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				ctx       = context.Background()
+				mutex     sync.Mutex
+				requested [][]string
+				now       = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			)
+
+			conn := NewMetadataCacheConnector(countingMetadataConnector(t, &requested, &mutex),
+				WithMetadataCacheStorage(storage),
+				WithMetadataCacheTTL(time.Minute),
+			)
+			conn.params.now = func() time.Time { return now }
+
+			list := func(objectNames ...string) *ListObjectMetadataResult {
+				result, err := conn.ListObjectMetadata(ctx, objectNames)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return result
+			}
+
+			list("account", "broken")
+			result := list("account", "contact", "broken")
+
+			if len(result.Result) != 2 || !errors.Is(result.Errors["broken"], errDescribeFailed) {
+				t.Fatalf("unexpected result: %v", result)
+			}
+
+			now = now.Add(time.Minute)
+
+			list("contact")
+
+			if err := conn.Invalidate(ctx, "account"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			list("account")
+
+			expected := [][]string{
+				{"account", "broken"},
+				{"contact", "broken"}, // account is cached, failure is not.
+				{"contact"},           // TTL has passed.
+				{"account"},           // invalidated.
+			}
+
+			if !reflect.DeepEqual(requested, expected) {
+				t.Fatalf("expected requests (%v), got (%v)", expected, requested)
+			}
+		})
+	}
+}
+
+func TestMetadataCacheConnectorMergesConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls   atomic.Int32
+		release = make(chan struct{})
+	)
+
+	mockConn, err := mock.NewConnector(
+		mock.WithClient(http.DefaultClient),
+		mock.WithListObjectMetadata(func(ctx context.Context, objectNames []string) (*ListObjectMetadataResult, error) {
+			calls.Add(1)
+			<-release
+
+			return &ListObjectMetadataResult{
+				Result: map[string]common.ObjectMetadata{"account": {DisplayName: "Account"}},
+				Errors: map[string]error{},
+			}, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create mock connector: %v", err)
+	}
+
+	conn := NewMetadataCacheConnector(mockConn)
+
+	var group sync.WaitGroup
+
+	for range 5 {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			if _, err := conn.ListObjectMetadata(context.Background(), []string{"account"}); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+
+	// Give goroutines a chance to join the in-flight request.
+	time.Sleep(50 * time.Millisecond) // nolint:gomnd,mnd
+	close(release)
+	group.Wait()
+
+	if calls.Load() != 1 {
+		t.Fatalf("expected a single request, got (%v)", calls.Load())
+	}
+}
+
+func TestMetadataCacheConnectorCancelledCallerDoesNotFailOthers(t *testing.T) {
+	t.Parallel()
+
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+
+	mockConn, err := mock.NewConnector(
+		mock.WithClient(http.DefaultClient),
+		mock.WithListObjectMetadata(func(ctx context.Context, objectNames []string) (*ListObjectMetadataResult, error) {
+			close(started)
+			<-release
+
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			return &ListObjectMetadataResult{
+				Result: map[string]common.ObjectMetadata{"account": {DisplayName: "Account"}},
+				Errors: map[string]error{},
+			}, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create mock connector: %v", err)
+	}
+
+	conn := NewMetadataCacheConnector(mockConn)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+
+	go func() {
+		_, err := conn.ListObjectMetadata(ctx, []string{"account"})
+		firstErr <- err
+	}()
+
+	<-started
+
+	secondResult := make(chan *ListObjectMetadataResult, 1)
+
+	go func() {
+		result, err := conn.ListObjectMetadata(context.Background(), []string{"account"})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		secondResult <- result
+	}()
+
+	// The caller who started the request gives up, it must not wait for the shared request.
+	cancel()
+
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation of the first caller, got (%v)", err)
+	}
+
+	// Give the second caller a chance to join the in-flight request.
+	time.Sleep(50 * time.Millisecond) // nolint:gomnd,mnd
+	close(release)
+
+	result := <-secondResult
+	if result == nil || result.Result["account"].DisplayName != "Account" {
+		t.Fatalf("expected metadata for the second caller, got (%v)", result)
+	}
+}
@@ -0,0 +1,181 @@
+package connectors
+
+import (
+	"context"
+	"iter"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// ReadProgress describes how far a paginated read has advanced.
+// It is reported to the progress callback after every page.
+type ReadProgress struct {
+	// Pages is the number of pages read so far.
+	Pages int
+	// Rows is the number of rows read so far.
+	Rows int64
+	// NextPage is the token of the page that would be read next.
+	// Save it to resume reading later by passing it as ReadParams.NextPage.
+	NextPage common.NextPageToken
+	// Done is true when the provider reported that there are no more pages.
+	Done bool
+}
+
+// PageResult is a single item produced by StreamPages.
+// Exactly one of Result and Err is set.
+type PageResult struct {
+	Result *ReadResult
+	Err    error
+}
+
+// PaginationOption configures how ReadPages, ReadRows and StreamPages traverse pages.
+type PaginationOption = func(params *paginationParams)
+
+type paginationParams struct {
+	maxPages   int
+	maxRows    int64
+	onProgress func(ReadProgress)
+}
+
+// WithMaxPages stops reading after the given number of pages. Zero means no limit.
+func WithMaxPages(maxPages int) PaginationOption {
+	return func(params *paginationParams) {
+		params.maxPages = maxPages
+	}
+}
+
+// WithMaxRows stops reading once the given number of rows was produced. Zero means no limit.
+// Pages are never split, so ReadPages and StreamPages may return more rows than the limit
+// within the last page. ReadRows stops exactly at the limit.
+func WithMaxRows(maxRows int64) PaginationOption {
+	return func(params *paginationParams) {
+		params.maxRows = maxRows
+	}
+}
+
+// WithProgress registers a callback which is invoked after every page is read.
+func WithProgress(callback func(ReadProgress)) PaginationOption {
+	return func(params *paginationParams) {
+		params.onProgress = callback
+	}
+}
+
+// ReadPages returns an iterator over all pages of the object described by params.
+// The loop of feeding ReadResult.NextPage into ReadParams.NextPage is handled internally
+// and iteration ends when the provider reports Done, a limit is reached or an error occurs.
+// Errors, including context cancellation, are yielded once, after which iteration stops.
+// To resume a previous read, set params.NextPage to the token reported by ReadProgress.
+func ReadPages(
+	ctx context.Context, conn ReadConnector, params ReadParams, opts ...PaginationOption,
+) iter.Seq2[*ReadResult, error] {
+	config := paginationParams{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return func(yield func(*ReadResult, error) bool) {
+		progress := ReadProgress{
+			NextPage: params.NextPage,
+		}
+
+		for !config.limitReached(progress) {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+
+				return
+			}
+
+			params.NextPage = progress.NextPage
+
+			result, err := conn.Read(ctx, params)
+			if err != nil {
+				yield(nil, err)
+
+				return
+			}
+
+			progress.Pages++
+			progress.Rows += int64(len(result.Data))
+			progress.NextPage = result.NextPage
+			progress.Done = result.Done || len(result.NextPage) == 0
+
+			if config.onProgress != nil {
+				config.onProgress(progress)
+			}
+
+			if !yield(result, nil) {
+				return
+			}
+
+			if progress.Done {
+				return
+			}
+		}
+	}
+}
+
+// ReadRows returns an iterator over individual rows of the object described by params.
+// It is a flattened view of ReadPages and accepts the same options.
+func ReadRows(
+	ctx context.Context, conn ReadConnector, params ReadParams, opts ...PaginationOption,
+) iter.Seq2[ReadResultRow, error] {
+	config := paginationParams{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return func(yield func(ReadResultRow, error) bool) {
+		var count int64
+
+		for page, err := range ReadPages(ctx, conn, params, opts...) {
+			if err != nil {
+				yield(ReadResultRow{}, err)
+
+				return
+			}
+
+			for _, row := range page.Data {
+				if config.maxRows > 0 && count >= config.maxRows {
+					return
+				}
+
+				count++
+
+				if !yield(row, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// StreamPages reads pages in a background goroutine and delivers them over the returned channel.
+// The channel is closed when reading is finished. An error is delivered as the last item.
+// Cancel the context to stop the goroutine early; the channel will be closed shortly after.
+func StreamPages(
+	ctx context.Context, conn ReadConnector, params ReadParams, opts ...PaginationOption,
+) <-chan PageResult {
+	pages := make(chan PageResult)
+
+	go func() {
+		defer close(pages)
+
+		for page, err := range ReadPages(ctx, conn, params, opts...) {
+			select {
+			case pages <- PageResult{Result: page, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return pages
+}
+
+func (p paginationParams) limitReached(progress ReadProgress) bool {
+	if p.maxPages > 0 && progress.Pages >= p.maxPages {
+		return true
+	}
+
+	return p.maxRows > 0 && progress.Rows >= p.maxRows
+}
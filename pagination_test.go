@@ -0,0 +1,235 @@
+package connectors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/mock"
+)
+
+var errTestRead = errors.New("read failed")
+
+// pagedConnector serves totalPages pages of rowsPerPage rows each.
+// NextPage token is the index of the following page.
+func pagedConnector(t *testing.T, totalPages, rowsPerPage int, failOnPage int) ReadConnector {
+	t.Helper()
+
+	conn, err := mock.NewConnector(
+		mock.WithClient(http.DefaultClient),
+		mock.WithRead(func(ctx context.Context, params common.ReadParams) (*common.ReadResult, error) {
+			page := 0
+			if len(params.NextPage) != 0 {
+				page, _ = strconv.Atoi(params.NextPage.String())
+			}
+
+			if page == failOnPage {
+				return nil, errTestRead
+			}
+
+			data := make([]common.ReadResultRow, rowsPerPage)
+			for i := range data {
+				data[i] = common.ReadResultRow{
+					Fields: map[string]any{"id": page*rowsPerPage + i},
+				}
+			}
+
+			result := &common.ReadResult{
+				Rows: int64(rowsPerPage),
+				Data: data,
+				Done: page == totalPages-1,
+			}
+			if !result.Done {
+				result.NextPage = common.NextPageToken(strconv.Itoa(page + 1))
+			}
+
+			return result, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create mock connector: %v", err)
+	}
+
+	return conn
+}
+
+func testReadParams() ReadParams {
+	return ReadParams{
+		ObjectName: "contacts",
+		Fields:     Fields("id"),
+	}
+}
+
+func TestReadPages(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		totalPages    int
+		failOnPage    int
+		nextPage      common.NextPageToken
+		opts          []PaginationOption
+		expectedPages int
+		expectedErr   error
+	}{
+		{
+			name:          "All pages are read until done",
+			totalPages:    4,
+			failOnPage:    -1,
+			expectedPages: 4,
+		},
+		{
+			name:          "Max pages limit stops reading",
+			totalPages:    4,
+			failOnPage:    -1,
+			opts:          []PaginationOption{WithMaxPages(2)},
+			expectedPages: 2,
+		},
+		{
+			name:          "Max rows limit stops reading at page boundary",
+			totalPages:    4,
+			failOnPage:    -1,
+			opts:          []PaginationOption{WithMaxRows(4)},
+			expectedPages: 2,
+		},
+		{
+			name:          "Reading resumes from saved token",
+			totalPages:    4,
+			failOnPage:    -1,
+			nextPage:      "2",
+			expectedPages: 2,
+		},
+		{
+			name:          "Error is yielded and stops iteration",
+			totalPages:    4,
+			failOnPage:    1,
+			expectedPages: 1,
+			expectedErr:   errTestRead,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := pagedConnector(t, tt.totalPages, 3, tt.failOnPage)
+			params := testReadParams()
+			params.NextPage = tt.nextPage
+
+			var (
+				pages   int
+				lastErr error
+			)
+
+			for _, err := range ReadPages(context.Background(), conn, params, tt.opts...) {
+				if err != nil {
+					lastErr = err
+
+					continue
+				}
+
+				pages++
+			}
+
+			if !errors.Is(lastErr, tt.expectedErr) {
+				t.Fatalf("%s: expected error (%v), got (%v)", tt.name, tt.expectedErr, lastErr)
+			}
+
+			if pages != tt.expectedPages {
+				t.Fatalf("%s: expected (%v) pages, got (%v)", tt.name, tt.expectedPages, pages)
+			}
+		})
+	}
+}
+
+func TestReadRowsMaxRows(t *testing.T) {
+	t.Parallel()
+
+	conn := pagedConnector(t, 4, 3, -1)
+
+	var ids []any
+
+	for row, err := range ReadRows(context.Background(), conn, testReadParams(), WithMaxRows(5)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		ids = append(ids, row.Fields["id"])
+	}
+
+	if len(ids) != 5 || ids[4] != 4 {
+		t.Fatalf("expected rows 0..4, got (%v)", ids)
+	}
+}
+
+func TestReadPagesProgress(t *testing.T) {
+	t.Parallel()
+
+	conn := pagedConnector(t, 3, 2, -1)
+
+	var reports []ReadProgress
+
+	for _, err := range ReadPages(context.Background(), conn, testReadParams(),
+		WithProgress(func(progress ReadProgress) {
+			reports = append(reports, progress)
+		}),
+	) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	expected := []ReadProgress{
+		{Pages: 1, Rows: 2, NextPage: "1"},
+		{Pages: 2, Rows: 4, NextPage: "2"},
+		{Pages: 3, Rows: 6, Done: true},
+	}
+
+	if len(reports) != len(expected) {
+		t.Fatalf("expected (%v) progress reports, got (%v)", len(expected), len(reports))
+	}
+
+	for i := range expected {
+		if reports[i] != expected[i] {
+			t.Fatalf("progress report %v: expected (%v), got (%v)", i, expected[i], reports[i])
+		}
+	}
+}
+
+func TestStreamPagesCancellation(t *testing.T) {
+	t.Parallel()
+
+	conn := pagedConnector(t, 100, 1, -1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		pages   int
+		lastErr error
+	)
+
+	for page := range StreamPages(ctx, conn, testReadParams()) {
+		if page.Err != nil {
+			lastErr = page.Err
+
+			continue
+		}
+
+		pages++
+		if pages == 3 {
+			cancel()
+		}
+	}
+
+	if pages >= 100 {
+		t.Fatalf("expected cancellation to stop reading, got (%v) pages", pages)
+	}
+
+	if lastErr != nil && !errors.Is(lastErr, context.Canceled) {
+		t.Fatalf("expected context cancellation error, got (%v)", lastErr)
+	}
+}
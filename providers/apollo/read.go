@@ -14,6 +14,14 @@ func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common
 		return nil, err
 	}
 
+	if err := config.ValidateNoAssociations(); err != nil {
+		return nil, err
+	}
+
+	if err := config.ValidateNoFilter(); err != nil {
+		return nil, err
+	}
+
 	url, err := c.getAPIURL(config.ObjectName, readOp)
 	if err != nil {
 		return nil, err
@@ -35,7 +43,7 @@ func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common
 	return common.ParseResult(res,
 		recordsWrapperFunc(config.ObjectName),
 		getNextRecords,
-		common.GetMarshaledData,
+		common.MakeMarshaledDataFunc(config.PreserveFieldCase),
 		config.Fields,
 	)
 }
@@ -39,7 +39,7 @@ func (c *Connector) Search(ctx context.Context, config common.ReadParams,
 		json,
 		searchRecords(responseKey[config.ObjectName]),
 		getNextRecords,
-		common.GetMarshaledData,
+		common.MakeMarshaledDataFunc(config.PreserveFieldCase),
 		config.Fields,
 	)
 }
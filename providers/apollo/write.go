@@ -15,23 +15,30 @@ func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*comm
 		return nil, err
 	}
 
-	var write common.WriteMethod
-
 	url, err := c.getAPIURL(config.ObjectName, writeOp)
 	if err != nil {
 		return nil, err
 	}
-	// sets post as default
-	write = c.Client.Post
+
+	write, err := common.WriteMethods{
+		common.WriteModeCreate: c.Client.Post,
+		common.WriteModeUpdate: c.Client.Patch,
+	}.Select(config)
+	if err != nil {
+		return nil, err
+	}
 
 	// prepares the updating data request.
 	if len(config.RecordId) > 0 {
 		url = url.AddPath(config.RecordId)
+	}
 
-		write = c.Client.Patch
+	recordData, err := config.RecordDataWithClearedFields(nil)
+	if err != nil {
+		return nil, err
 	}
 
-	json, err := write(ctx, url.String(), config.RecordData)
+	json, err := write(ctx, url.String(), recordData)
 	if err != nil {
 		return nil, err
 	}
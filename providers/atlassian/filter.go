@@ -0,0 +1,104 @@
+package atlassian
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// JQL operators keyed by filter operator.
+// https://support.atlassian.com/jira-software-cloud/docs/jql-operators/
+var jqlOperators = map[common.FilterOperator]string{ // nolint:gochecknoglobals
+	common.FilterOperatorEq:       "=",
+	common.FilterOperatorNe:       "!=",
+	common.FilterOperatorGt:       ">",
+	common.FilterOperatorLt:       "<",
+	common.FilterOperatorContains: "~",
+}
+
+// JQL accepts dates without seconds, they are interpreted in the time zone of the Jira user.
+const jqlTimeLayout = "2006/01/02 15:04"
+
+var jqlStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`) // nolint:gochecknoglobals
+
+// jqlFieldName matches system field names, custom field ids and custom field names. Ex: "cf[10010]", "Story Points".
+var jqlFieldName = regexp.MustCompile(`^(cf\[[0-9]+\]|[\p{L}\p{N}_][\p{L}\p{N}_ .-]*)$`) // nolint:gochecknoglobals
+
+// makeJQL converts filter expression into Jira Query Language.
+// Field names are checked, so that the clause cannot be altered by the field.
+func makeJQL(expr *common.FilterExpr) (string, error) {
+	if !expr.IsLogical() && !jqlFieldName.MatchString(expr.Field) {
+		return "", fmt.Errorf("%w: invalid field name %q", common.ErrInvalidFilter, expr.Field)
+	}
+
+	switch expr.Operator { // nolint:exhaustive
+	case common.FilterOperatorAnd, common.FilterOperatorOr:
+		clauses := make([]string, len(expr.Operands))
+
+		for index, operand := range expr.Operands {
+			clause, err := makeJQL(operand)
+			if err != nil {
+				return "", err
+			}
+
+			clauses[index] = clause
+		}
+
+		return "(" + strings.Join(clauses, " "+strings.ToUpper(string(expr.Operator))+" ") + ")", nil
+	case common.FilterOperatorIn:
+		values := make([]string, len(expr.Values))
+
+		for index, value := range expr.Values {
+			literal, err := makeJQLLiteral(expr, value)
+			if err != nil {
+				return "", err
+			}
+
+			values[index] = literal
+		}
+
+		return fmt.Sprintf("%v in (%v)", quoteJQLField(expr.Field), strings.Join(values, ", ")), nil
+	}
+
+	operator, ok := jqlOperators[expr.Operator]
+	if !ok {
+		return "", common.NewUnsupportedFilterError(expr, "operator is not supported")
+	}
+
+	literal, err := makeJQLLiteral(expr, expr.Value)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%v %v %v", quoteJQLField(expr.Field), operator, literal), nil
+}
+
+func makeJQLLiteral(expr *common.FilterExpr, value any) (string, error) {
+	switch val := value.(type) {
+	case string:
+		return `"` + jqlStringEscaper.Replace(val) + `"`, nil
+	case int:
+		return strconv.Itoa(val), nil
+	case int64:
+		return strconv.FormatInt(val, 10), nil
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), nil
+	case time.Time:
+		return `"` + val.Format(jqlTimeLayout) + `"`, nil
+	default:
+		return "", common.NewUnsupportedFilterError(expr, fmt.Sprintf("value of type %T is not supported", value))
+	}
+}
+
+// quoteJQLField quotes custom field names, which may contain spaces. Ex: "Story Points".
+func quoteJQLField(field string) string {
+	if strings.Contains(field, " ") {
+		return `"` + field + `"`
+	}
+
+	return field
+}
@@ -3,6 +3,7 @@ package atlassian
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/amp-labs/connectors/common"
@@ -14,11 +15,16 @@ import (
 // * ObjectName - ignored.
 // * NextPage - to get next page which may have no elements left.
 // * Since - to scope the time frame, precision is in minutes.
+// * FilterBy - translated into JQL, joined with Since.
 func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common.ReadResult, error) {
 	if err := config.ValidateParams(true); err != nil {
 		return nil, err
 	}
 
+	if err := config.ValidateNoAssociations(); err != nil {
+		return nil, err
+	}
+
 	url, err := c.buildReadURL(config)
 	if err != nil {
 		return nil, err
@@ -33,7 +39,7 @@ func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common
 		rsp,
 		getRecords,
 		getNextRecords,
-		common.GetMarshaledData,
+		common.MakeMarshaledDataFunc(config.PreserveFieldCase),
 		config.Fields,
 	)
 }
@@ -48,6 +54,8 @@ func (c *Connector) buildReadURL(config common.ReadParams) (*urlbuilder.URL, err
 		url.WithQueryParam("startAt", config.NextPage.String())
 	}
 
+	clauses := make([]string, 0)
+
 	if !config.Since.IsZero() {
 		// Read URL supports time scoping. common.ReadParams.Since is used to get relative time frame.
 		// Here is an API example on how to request issues that were updated in the last 30 minutes.
@@ -57,8 +65,21 @@ func (c *Connector) buildReadURL(config common.ReadParams) (*urlbuilder.URL, err
 
 		minutes := int64(diff.Minutes())
 		if minutes > 0 {
-			url.WithQueryParam("jql", fmt.Sprintf(`updated > "-%vm"`, minutes))
+			clauses = append(clauses, fmt.Sprintf(`updated > "-%vm"`, minutes))
+		}
+	}
+
+	if config.FilterBy != nil {
+		clause, err := makeJQL(config.FilterBy)
+		if err != nil {
+			return nil, err
 		}
+
+		clauses = append(clauses, clause)
+	}
+
+	if len(clauses) != 0 {
+		url.WithQueryParam("jql", strings.Join(clauses, " AND "))
 	}
 
 	return url, nil
@@ -172,6 +172,55 @@ func TestRead(t *testing.T) { //nolint:funlen,gocognit,cyclop,maintidx
 			},
 			ExpectedErrs: nil,
 		},
+		{
+			Name: "Filter is joined with time frame in JQL",
+			Input: common.ReadParams{
+				ObjectName: "issues",
+				Fields:     connectors.Fields("id"),
+				Since:      time.Now().Add(-5 * time.Minute),
+				FilterBy: common.FilterOr(
+					common.FilterIn("status", "Done", "In Progress"),
+					common.FilterContains("summary", `say "hi"`),
+				),
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.QueryParam("jql",
+					`updated > "-5m" AND (status in ("Done", "In Progress") OR summary ~ "say \"hi\"")`),
+				Then: mockserver.ResponseString(http.StatusOK, `
+					{
+					  "startAt": 0,
+					  "issues": [{"fields":{}, "id": "0"}]
+					}`),
+			}.Server(),
+			Comparator: func(baseURL string, actual, expected *common.ReadResult) bool {
+				return actual.Rows == expected.Rows
+			},
+			Expected: &common.ReadResult{
+				Rows: 1,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Boolean filter values are not supported by JQL",
+			Input: common.ReadParams{
+				ObjectName: "issues",
+				Fields:     connectors.Fields("id"),
+				FilterBy:   common.FilterEq("flagged", true),
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			Name: "Filter field names cannot alter the clause",
+			Input: common.ReadParams{
+				ObjectName: "issues",
+				Fields:     connectors.Fields("id"),
+				FilterBy:   common.FilterEq(`status = "Done" OR project`, "X"),
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrInvalidFilter},
+		},
 		{
 			Name: "Since rounds to minute time frame",
 			Input: common.ReadParams{
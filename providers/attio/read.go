@@ -13,6 +13,14 @@ func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common
 		return nil, err
 	}
 
+	if err := config.ValidateNoAssociations(); err != nil {
+		return nil, err
+	}
+
+	if err := config.ValidateNoFilter(); err != nil {
+		return nil, err
+	}
+
 	if !supportedObjectsByRead.Has(config.ObjectName) {
 		return nil, common.ErrOperationNotSupportedForObject
 	}
@@ -31,7 +39,7 @@ func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common
 		rsp,
 		common.GetRecordsUnderJSONPath("data"),
 		makeNextRecordsURL(url),
-		common.GetMarshaledData,
+		common.MakeMarshaledDataFunc(config.PreserveFieldCase),
 		config.Fields,
 	)
 }
@@ -28,18 +28,28 @@ func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*comm
 		return nil, err
 	}
 
-	var write common.WriteMethod
-	if len(config.RecordId) == 0 {
+	write, err := common.WriteMethods{
 		// writing to the entity without id means creating a new record.
-		write = c.Client.Post
-	} else {
-		// updating resource by patch method.
-		write = c.Client.Patch
+		common.WriteModeCreate: c.Client.Post,
+		// updating resource by patch method, multiselect values are appended.
+		common.WriteModeUpdate: c.Client.Patch,
+		// put overwrites multiselect values.
+		common.WriteModeReplace: c.Client.Put,
+	}.Select(config)
+	if err != nil {
+		return nil, err
+	}
 
+	if len(config.RecordId) != 0 {
 		url.AddPath(config.RecordId)
 	}
 
-	res, err := write(ctx, url.String(), config.RecordData)
+	recordData, err := config.RecordDataWithClearedFields(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := write(ctx, url.String(), recordData)
 	if err != nil {
 		return nil, err
 	}
@@ -11,6 +11,14 @@ func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common
 		return nil, err
 	}
 
+	if err := config.ValidateNoAssociations(); err != nil {
+		return nil, err
+	}
+
+	if err := config.ValidateNoFilter(); err != nil {
+		return nil, err
+	}
+
 	if !supportedObjectsByRead[c.Module.ID].Has(config.ObjectName) {
 		return nil, common.ErrOperationNotSupportedForObject
 	}
@@ -30,7 +38,7 @@ func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common
 	return common.ParseResult(res,
 		common.GetOptionalRecordsUnderJSONPath(responseFieldName),
 		makeNextRecordsURL(url),
-		common.GetMarshaledData,
+		common.MakeMarshaledDataFunc(config.PreserveFieldCase),
 		config.Fields,
 	)
 }
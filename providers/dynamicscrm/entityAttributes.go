@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/handy"
 	"github.com/amp-labs/connectors/common/jsonquery"
 	"github.com/amp-labs/connectors/common/naming"
 	"github.com/spyzhov/ajson"
@@ -19,11 +21,12 @@ var (
 	ErrObjectMissingAttributes = errors.New("object missing metadata attributes")
 )
 
-// Returns pairs of field names to display names.
-// Internally will make an API call to Attributes endpoint.
+// Returns field names mapped to their metadata.
+// Internally will make an API call to Attributes endpoint,
+// followed by a call per option set type to learn the options of select fields.
 func (c *Connector) getFieldsForObject(
 	ctx context.Context, objectName naming.SingularString,
-) (map[string]string, error) {
+) (map[string]common.FieldMetadata, error) {
 	url, err := c.getEntityAttributesURL(objectName)
 	if err != nil {
 		return nil, err
@@ -43,39 +46,60 @@ func (c *Connector) getFieldsForObject(
 		return nil, err
 	}
 
-	fields, err := extractFieldsFromJSON(body)
+	fields, optionSetTypes, err := extractFieldsFromJSON(body)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", err, objectName)
 	}
 
+	for _, metadataType := range optionSetTypes.List() {
+		if err = c.fillFieldOptions(ctx, objectName, metadataType, fields); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, objectName)
+		}
+	}
+
 	return fields, nil
 }
 
-// Attributes from endpoint response will be converted from JSON objects
-// to field names mapped to display names.
-func extractFieldsFromJSON(attributes *ajson.Node) (map[string]string, error) {
+// Attributes from endpoint response will be converted from JSON objects to field metadata.
+// Returned set lists attribute metadata types that carry option sets, those must be fetched separately.
+func extractFieldsFromJSON(attributes *ajson.Node) (map[string]common.FieldMetadata, handy.StringSet, error) {
 	array, err := jsonquery.New(attributes).Array("value", false)
 	if err != nil {
-		return nil, errors.Join(ErrObjectNotFound, err)
+		return nil, nil, errors.Join(ErrObjectNotFound, err)
 	}
 
 	if len(array) == 0 {
 		// nothing to read, we expected some attributes
-		return nil, ErrObjectMissingAttributes
+		return nil, nil, ErrObjectMissingAttributes
 	}
 
-	fieldsMap := make(map[string]string)
+	fields := make(map[string]common.FieldMetadata)
+	optionSetTypes := handy.NewStringSet()
 
 	for _, item := range array {
 		name, displayName, err := getAttributeNames(item)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+
+		field, err := getAttributeMetadata(item, displayName)
+		if err != nil {
+			return nil, nil, errors.Join(ErrObjectNotFound, err)
 		}
 
-		fieldsMap[name] = displayName
+		fields[name] = field
+
+		metadataType, err := getOptionSetMetadataType(item)
+		if err != nil {
+			return nil, nil, errors.Join(ErrObjectNotFound, err)
+		}
+
+		if len(metadataType) != 0 {
+			optionSetTypes.AddOne(metadataType)
+		}
 	}
 
-	return fieldsMap, nil
+	return fields, optionSetTypes, nil
 }
 
 // Single attribute payload holds logical name of a field and its display name.
@@ -0,0 +1,103 @@
+package dynamicscrm
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/handy"
+)
+
+// OData comparison operators keyed by filter operator.
+// https://learn.microsoft.com/en-us/power-apps/developer/data-platform/webapi/query/filter-rows
+var odataOperators = map[common.FilterOperator]string{ // nolint:gochecknoglobals
+	common.FilterOperatorEq: "eq",
+	common.FilterOperatorNe: "ne",
+	common.FilterOperatorGt: "gt",
+	common.FilterOperatorLt: "lt",
+}
+
+// odataName matches property names, optionally following navigation properties. Ex: "parentcustomerid_account/name".
+var odataName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(/[A-Za-z_][A-Za-z0-9_]*)*$`) // nolint:gochecknoglobals
+
+// makeODataFilter converts filter expression into OData $filter query option.
+// Field names are checked, so that the condition cannot be altered by the field.
+func makeODataFilter(expr *common.FilterExpr) (string, error) {
+	if !expr.IsLogical() && !odataName.MatchString(expr.Field) {
+		return "", fmt.Errorf("%w: invalid field name %q", common.ErrInvalidFilter, expr.Field)
+	}
+
+	switch expr.Operator { // nolint:exhaustive
+	case common.FilterOperatorAnd, common.FilterOperatorOr:
+		conditions := make([]string, len(expr.Operands))
+
+		for index, operand := range expr.Operands {
+			condition, err := makeODataFilter(operand)
+			if err != nil {
+				return "", err
+			}
+
+			conditions[index] = condition
+		}
+
+		return "(" + strings.Join(conditions, " "+string(expr.Operator)+" ") + ")", nil
+	case common.FilterOperatorIn:
+		// Expanded into a series of equality checks.
+		conditions := make([]string, len(expr.Values))
+
+		for index, value := range expr.Values {
+			literal, err := makeODataLiteral(expr, value)
+			if err != nil {
+				return "", err
+			}
+
+			conditions[index] = fmt.Sprintf("%v eq %v", expr.Field, literal)
+		}
+
+		return "(" + strings.Join(conditions, " or ") + ")", nil
+	case common.FilterOperatorContains:
+		literal, err := makeODataLiteral(expr, expr.Value)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("contains(%v,%v)", expr.Field, literal), nil
+	}
+
+	operator, ok := odataOperators[expr.Operator]
+	if !ok {
+		return "", common.NewUnsupportedFilterError(expr, "operator is not supported")
+	}
+
+	literal, err := makeODataLiteral(expr, expr.Value)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%v %v %v", expr.Field, operator, literal), nil
+}
+
+func makeODataLiteral(expr *common.FilterExpr, value any) (string, error) {
+	switch val := value.(type) {
+	case nil:
+		return "null", nil
+	case string:
+		// Single quotes are escaped by doubling them.
+		return "'" + strings.ReplaceAll(val, "'", "''") + "'", nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case int:
+		return strconv.Itoa(val), nil
+	case int64:
+		return strconv.FormatInt(val, 10), nil
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), nil
+	case time.Time:
+		return handy.Time.FormatRFC3339inUTC(val), nil
+	default:
+		return "", common.NewUnsupportedFilterError(expr, fmt.Sprintf("value of type %T is not supported", value))
+	}
+}
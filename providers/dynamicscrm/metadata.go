@@ -47,10 +47,17 @@ func (c *Connector) listObjectMetadata(
 		// Object names must be in plural.
 		// Connectors Read/Write methods for MS Dynamics use plural form. Ex: Read('contacts')
 		// The expectation is therefore to match, while schema API uses singular. Ex: `contact` schema
-		result[objectName.Plural().String()] = common.ObjectMetadata{
+		metadata := common.ObjectMetadata{
 			DisplayName: objectDisplayName,
-			FieldsMap:   fields,
+			FieldsMap:   make(map[string]string),
+			Fields:      make(map[string]common.FieldMetadata),
 		}
+
+		for name, field := range fields {
+			metadata.AddField(name, field)
+		}
+
+		result[objectName.Plural().String()] = metadata
 	}
 
 	return &common.ListObjectMetadataResult{
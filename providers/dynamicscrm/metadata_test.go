@@ -6,6 +6,7 @@ import (
 
 	"github.com/amp-labs/connectors"
 	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/handy"
 	"github.com/amp-labs/connectors/test/utils/mockutils"
 	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
 	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
@@ -19,6 +20,7 @@ func TestListObjectMetadata(t *testing.T) { // nolint:funlen,gocognit,cyclop
 	responseContactsSchema := testutils.DataFromFile(t, "contacts-schema.json")
 	// Attributes file is a shorter form of real Microsoft server response.
 	responseContactsAttributes := testutils.DataFromFile(t, "contacts-attributes.json")
+	responseContactsPicklists := testutils.DataFromFile(t, "contacts-picklist-attributes.json")
 
 	tests := []testroutines.Metadata{
 		{
@@ -74,6 +76,10 @@ func TestListObjectMetadata(t *testing.T) { // nolint:funlen,gocognit,cyclop
 				}, {
 					If:   mockcond.PathSuffix("EntityDefinitions(LogicalName='contact')/Attributes"),
 					Then: mockserver.Response(http.StatusOK, responseContactsAttributes),
+				}, {
+					If: mockcond.PathSuffix(
+						"EntityDefinitions(LogicalName='contact')/Attributes/Microsoft.Dynamics.CRM.PicklistAttributeMetadata"),
+					Then: mockserver.Response(http.StatusOK, responseContactsPicklists),
 				}},
 				Default: mockserver.Response(http.StatusOK, []byte{}),
 			}.Server(),
@@ -97,6 +103,40 @@ func TestListObjectMetadata(t *testing.T) { // nolint:funlen,gocognit,cyclop
 							"_accountid_value": "Account",
 							"_createdby_value": "Created By",
 						},
+						Fields: map[string]common.FieldMetadata{
+							"lastname": {
+								DisplayName:  "Last Name",
+								ValueType:    common.ValueTypeString,
+								ProviderType: "String",
+								Required:     handy.Pointers.Bool(true),
+								Createable:   handy.Pointers.Bool(true),
+								Updateable:   handy.Pointers.Bool(true),
+							},
+							"shippingmethodcode": {
+								DisplayName:  "Shipping Method",
+								ValueType:    common.ValueTypeSingleSelect,
+								ProviderType: "Picklist",
+								Required:     handy.Pointers.Bool(false),
+								Createable:   handy.Pointers.Bool(true),
+								Updateable:   handy.Pointers.Bool(true),
+								Values: []common.FieldValue{
+									{Value: "1", DisplayValue: "Default Value"},
+									{Value: "2", DisplayValue: "2"},
+								},
+							},
+							"_createdby_value": {
+								DisplayName:  "Created By",
+								ValueType:    common.ValueTypeReference,
+								ProviderType: "Lookup",
+								Required:     handy.Pointers.Bool(false),
+								Createable:   handy.Pointers.Bool(false),
+								Updateable:   handy.Pointers.Bool(false),
+								ReferenceTo:  []string{"systemuser"},
+							},
+							"department": {
+								DisplayName: "Department",
+							},
+						},
 					},
 				},
 				Errors: nil,
@@ -0,0 +1,218 @@
+package dynamicscrm
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/handy"
+	"github.com/amp-labs/connectors/common/jsonquery"
+	"github.com/amp-labs/connectors/common/naming"
+	"github.com/spyzhov/ajson"
+)
+
+const odataTypePrefix = "#Microsoft.Dynamics.CRM."
+
+// Attribute metadata types which describe select fields.
+// Their options are only returned when attributes are cast to one of these types.
+// https://learn.microsoft.com/en-us/power-apps/developer/data-platform/webapi/query-metadata-web-api#retrieving-attributes
+var optionSetMetadataTypes = handy.NewSet( // nolint:gochecknoglobals
+	"PicklistAttributeMetadata",
+	"MultiSelectPicklistAttributeMetadata",
+	"StateAttributeMetadata",
+	"StatusAttributeMetadata",
+)
+
+// Attribute types mapped to value types.
+// https://learn.microsoft.com/en-us/power-apps/developer/data-platform/webapi/reference/attributetypecode
+var attributeValueTypes = handy.NewDefaultMap(map[string]common.ValueType{ //nolint:gochecknoglobals
+	"String":           common.ValueTypeString,
+	"Memo":             common.ValueTypeString,
+	"Uniqueidentifier": common.ValueTypeString,
+	"Boolean":          common.ValueTypeBoolean,
+	"Integer":          common.ValueTypeInt,
+	"BigInt":           common.ValueTypeInt,
+	"Decimal":          common.ValueTypeFloat,
+	"Double":           common.ValueTypeFloat,
+	"Money":            common.ValueTypeFloat,
+	"DateTime":         common.ValueTypeDateTime,
+	"Picklist":         common.ValueTypeSingleSelect,
+	"State":            common.ValueTypeSingleSelect,
+	"Status":           common.ValueTypeSingleSelect,
+	"Lookup":           common.ValueTypeReference,
+	"Customer":         common.ValueTypeReference,
+	"Owner":            common.ValueTypeReference,
+}, func(string) common.ValueType {
+	return common.ValueTypeOther
+})
+
+// Describes attribute using its type, flags and targets.
+// Older payloads may lack these properties, in which case they are left unknown.
+func getAttributeMetadata(attribute *ajson.Node, displayName string) (common.FieldMetadata, error) {
+	field := common.FieldMetadata{
+		DisplayName: displayName,
+	}
+
+	attributeType, err := jsonquery.New(attribute).Str("AttributeType", true)
+	if err != nil {
+		return field, err
+	}
+
+	if attributeType != nil {
+		field.ProviderType = *attributeType
+		field.ValueType = attributeValueTypes.Get(*attributeType)
+
+		// Multi select fields are reported as virtual, type name tells them apart.
+		typeName, err := jsonquery.New(attribute, "AttributeTypeName").Str("Value", true)
+		if err != nil {
+			return field, err
+		}
+
+		if typeName != nil && *typeName == "MultiSelectPicklistType" {
+			field.ValueType = common.ValueTypeMultiSelect
+		}
+	}
+
+	if field.Createable, err = jsonquery.New(attribute).Bool("IsValidForCreate", true); err != nil {
+		return field, err
+	}
+
+	if field.Updateable, err = jsonquery.New(attribute).Bool("IsValidForUpdate", true); err != nil {
+		return field, err
+	}
+
+	requiredLevel, err := jsonquery.New(attribute, "RequiredLevel").Str("Value", true)
+	if err != nil {
+		return field, err
+	}
+
+	if requiredLevel != nil {
+		// ApplicationRequired is enforced by the API, unlike Recommended.
+		field.Required = handy.Pointers.Bool(
+			*requiredLevel == "SystemRequired" || *requiredLevel == "ApplicationRequired",
+		)
+	}
+
+	targets, err := jsonquery.New(attribute).Array("Targets", true)
+	if err != nil {
+		return field, err
+	}
+
+	for _, target := range targets {
+		name, err := target.GetString()
+		if err != nil {
+			return field, err
+		}
+
+		field.ReferenceTo = append(field.ReferenceTo, name)
+	}
+
+	return field, nil
+}
+
+// Returns attribute metadata type if attribute has option set, otherwise empty string.
+func getOptionSetMetadataType(attribute *ajson.Node) (string, error) {
+	odataType, err := jsonquery.New(attribute).StrWithDefault("@odata.type", "")
+	if err != nil {
+		return "", err
+	}
+
+	metadataType, _ := strings.CutPrefix(odataType, odataTypePrefix)
+	if !optionSetMetadataTypes.Has(metadataType) {
+		return "", nil
+	}
+
+	return metadataType, nil
+}
+
+// Fetches options of all attributes of given metadata type and stores them as field values.
+func (c *Connector) fillFieldOptions(
+	ctx context.Context, objectName naming.SingularString,
+	metadataType string, fields map[string]common.FieldMetadata,
+) error {
+	url, err := c.getEntityAttributesURL(objectName)
+	if err != nil {
+		return err
+	}
+
+	url.AddPath("Microsoft.Dynamics.CRM." + metadataType)
+	url.WithQueryParam("$select", "LogicalName")
+	url.WithQueryParam("$expand", "OptionSet($select=Options)")
+
+	body, err := c.performGetRequest(ctx, url)
+	if err != nil {
+		return err
+	}
+
+	attributes, err := jsonquery.New(body).Array("value", false)
+	if err != nil {
+		return err
+	}
+
+	for _, attribute := range attributes {
+		logicalName, err := jsonquery.New(attribute).Str("LogicalName", false)
+		if err != nil {
+			return err
+		}
+
+		field, ok := fields[*logicalName]
+		if !ok {
+			// Attribute is not readable, it was excluded from fields.
+			continue
+		}
+
+		field.Values, err = getOptions(attribute)
+		if err != nil {
+			return err
+		}
+
+		fields[*logicalName] = field
+	}
+
+	return nil
+}
+
+func getOptions(attribute *ajson.Node) ([]common.FieldValue, error) {
+	options, err := jsonquery.New(attribute, "OptionSet").Array("Options", true)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]common.FieldValue, len(options))
+
+	for index, option := range options {
+		value, err := jsonquery.New(option).Integer("Value", false)
+		if err != nil {
+			return nil, err
+		}
+
+		text := strconv.FormatInt(*value, 10)
+
+		label, err := getOptionLabel(option, text)
+		if err != nil {
+			return nil, err
+		}
+
+		values[index] = common.FieldValue{
+			Value:        text,
+			DisplayValue: label,
+		}
+	}
+
+	return values, nil
+}
+
+// Option label is the first localized label, value is used as fallback.
+func getOptionLabel(option *ajson.Node, value string) (string, error) {
+	localizedLabels, err := jsonquery.New(option, "Label").Array("LocalizedLabels", true)
+	if err != nil {
+		return "", err
+	}
+
+	if len(localizedLabels) == 0 {
+		return value, nil
+	}
+
+	return jsonquery.New(localizedLabels[0]).StrWithDefault("Label", value)
+}
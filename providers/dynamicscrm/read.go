@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/amp-labs/connectors/common"
@@ -12,13 +13,18 @@ import (
 )
 
 // nolint:lll
-// Microsoft API supports other capabilities like filtering, grouping, and sorting which we can potentially tap into later.
+// ReadParams.FilterBy is translated into $filter query option.
+// Microsoft API supports other capabilities like grouping, and sorting which we can potentially tap into later.
 // See https://learn.microsoft.com/en-us/power-apps/developer/data-platform/webapi/query-data-web-api#odata-query-options
 func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common.ReadResult, error) {
 	if err := config.ValidateParams(true); err != nil {
 		return nil, err
 	}
 
+	if err := config.ValidateNoAssociations(); err != nil {
+		return nil, err
+	}
+
 	url, err := c.buildReadURL(config)
 	if err != nil {
 		return nil, err
@@ -38,7 +44,7 @@ func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common
 		rsp,
 		getRecords,
 		getNextRecordsURL,
-		common.GetMarshaledData,
+		common.MakeMarshaledDataFunc(config.PreserveFieldCase),
 		config.Fields,
 	)
 }
@@ -55,14 +61,85 @@ func (c *Connector) buildReadURL(config common.ReadParams) (*urlbuilder.URL, err
 		return nil, err
 	}
 
-	fields := config.Fields.List()
-	if len(fields) != 0 {
-		url.WithQueryParam("$select", strings.Join(fields, ","))
+	selects, expands := makeSelectAndExpand(config.Fields.List())
+	if len(selects) != 0 {
+		url.WithQueryParam("$select", strings.Join(selects, ","))
+	}
+
+	if len(expands) != 0 {
+		url.WithQueryParam("$expand", strings.Join(expands, ","))
+	}
+
+	if config.FilterBy != nil {
+		filter, err := makeODataFilter(config.FilterBy)
+		if err != nil {
+			return nil, err
+		}
+
+		url.WithQueryParam("$filter", filter)
 	}
 
 	return url, nil
 }
 
+// makeSelectAndExpand converts fields into $select and $expand query options.
+// Annotations, such as "_parentcustomerid_value@OData.Community.Display.V1.FormattedValue",
+// select the annotated property. Nested fields, such as "primarycontactid.fullname",
+// expand the navigation property selecting the nested property.
+// Fields are sorted, so that the query is stable.
+func makeSelectAndExpand(fields []string) ([]string, []string) {
+	fields = slices.Sorted(slices.Values(fields))
+	selects := make([]string, 0, len(fields))
+	nested := make(map[string][]string)
+	navigations := make([]string, 0)
+
+	for _, field := range fields {
+		selector, err := common.ParseFieldSelector(field)
+		if err != nil {
+			continue
+		}
+
+		if !selector.IsNested() || strings.Contains(field, "@") {
+			if property, _, _ := strings.Cut(selector.Root(), "@"); len(property) != 0 {
+				selects = appendUnique(selects, property)
+			}
+
+			continue
+		}
+
+		navigation := selector.Root()
+		if _, found := nested[navigation]; !found {
+			navigations = append(navigations, navigation)
+		}
+
+		if property := selector.Key(1); len(property) != 0 {
+			nested[navigation] = appendUnique(nested[navigation], property)
+		}
+	}
+
+	expands := make([]string, 0, len(navigations))
+
+	for _, navigation := range navigations {
+		if len(nested[navigation]) == 0 {
+			expands = append(expands, navigation)
+		} else {
+			expands = append(expands, fmt.Sprintf("%v($select=%v)", navigation, strings.Join(nested[navigation], ",")))
+		}
+	}
+
+	return selects, expands
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, item := range list {
+		if item == value {
+			return list
+		}
+	}
+
+	return append(list, value)
+}
+
 func newPaginationHeader(pageSize int) common.Header {
 	return common.Header{
 		Key:   "Prefer",
@@ -3,11 +3,13 @@ package dynamicscrm
 import (
 	"errors"
 	"net/http"
+	"reflect"
 	"testing"
 
 	"github.com/amp-labs/connectors"
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/common/jsonquery"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
 	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
 	"github.com/amp-labs/connectors/test/utils/testroutines"
 	"github.com/amp-labs/connectors/test/utils/testutils"
@@ -83,6 +85,88 @@ func TestRead(t *testing.T) { //nolint:funlen,gocognit,cyclop
 			},
 			ExpectedErrs: nil,
 		},
+		{
+			Name: "Filter is translated into OData query option",
+			Input: common.ReadParams{
+				ObjectName: "contact",
+				Fields:     connectors.Fields("id"),
+				FilterBy: common.FilterAnd(
+					common.FilterEq("lastname", "O'Neil"),
+					common.FilterIn("statecode", 0, 1),
+					common.FilterContains("fullname", "Smith"),
+				),
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.QueryParam("$filter",
+					"(lastname eq 'O''Neil' and (statecode eq 0 or statecode eq 1) and contains(fullname,'Smith'))"),
+				Then: mockserver.ResponseString(http.StatusOK, `{
+					"value": [{"contactid": "1"}]
+				}`),
+			}.Server(),
+			Comparator: func(baseURL string, actual, expected *common.ReadResult) bool {
+				return actual.Rows == expected.Rows
+			},
+			Expected:     &common.ReadResult{Rows: 1, Done: true},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Nested fields and annotations are selected",
+			Input: common.ReadParams{
+				ObjectName: "contact",
+				Fields: connectors.Fields("fullname", "primarycontactid.fullname",
+					"_parentcustomerid_value@OData.Community.Display.V1.FormattedValue"),
+				PreserveFieldCase: true,
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.QueryParam("$select", "_parentcustomerid_value,fullname"),
+					mockcond.QueryParam("$expand", "primarycontactid($select=fullname)"),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{
+					"value": [{
+						"fullname": "Heriberto Nathan",
+						"_parentcustomerid_value": "4c8e4f3c",
+						"_parentcustomerid_value@OData.Community.Display.V1.FormattedValue": "Fourth Coffee",
+						"primarycontactid": {"fullname": "Dwayne Elijah"}
+					}]
+				}`),
+			}.Server(),
+			Comparator: func(baseURL string, actual, expected *common.ReadResult) bool {
+				return reflect.DeepEqual(actual.Data[0].Fields, expected.Data[0].Fields)
+			},
+			Expected: &common.ReadResult{
+				Data: []common.ReadResultRow{{
+					Fields: map[string]any{
+						"fullname":                  "Heriberto Nathan",
+						"primarycontactid.fullname": "Dwayne Elijah",
+						"_parentcustomerid_value@OData.Community.Display.V1.FormattedValue": "Fourth Coffee",
+					},
+				}},
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Unsupported filter value",
+			Input: common.ReadParams{
+				ObjectName: "contact",
+				Fields:     connectors.Fields("id"),
+				FilterBy:   common.FilterEq("lastname", struct{}{}),
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			Name: "Filter field names cannot alter the condition",
+			Input: common.ReadParams{
+				ObjectName: "contact",
+				Fields:     connectors.Fields("id"),
+				FilterBy:   common.FilterEq("lastname eq 'x' or fullname", "y"),
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrInvalidFilter},
+		},
 		{
 			Name:  "Successful read with chosen fields",
 			Input: common.ReadParams{ObjectName: "contact", Fields: connectors.Fields("fullname", "fax")},
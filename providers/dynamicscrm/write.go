@@ -3,28 +3,42 @@ package dynamicscrm
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/handy"
+	"github.com/amp-labs/connectors/common/jsonquery"
+	"github.com/amp-labs/connectors/common/naming"
 )
 
 // Write data will be used to Create or Update entity.
 // Return: common.WriteResult, where only the Success flag will be set.
+// Upsert is done by an alternate key, named by ExternalIdField, it must be defined for the entity.
+// https://learn.microsoft.com/en-us/power-apps/developer/data-platform/webapi/perform-conditional-operations-using-web-api#upsert-a-table-row
 func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*common.WriteResult, error) {
 	if err := config.ValidateParams(); err != nil {
 		return nil, err
 	}
 
-	var resource string
+	if config.ResolveMode() == common.WriteModeUpsert {
+		return c.upsert(ctx, config)
+	}
 
-	var write common.WriteMethod
-	if len(config.RecordId) == 0 {
+	write, err := common.WriteMethods{
 		// writing to the entity without id means
 		// that we are extending 'List' resource and creating a new record
-		write = c.Client.Post
-		resource = config.ObjectName
-	} else {
+		common.WriteModeCreate: c.Client.Post,
 		// only patch is supported for updating 'Single' resource
-		write = c.Client.Patch
+		common.WriteModeUpdate: c.Client.Patch,
+	}.Select(config)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := config.ObjectName
+	if len(config.RecordId) != 0 {
 		// resource id is passed via brackets in OData spec
 		resource = fmt.Sprintf("%s(%s)", config.ObjectName, config.RecordId)
 	}
@@ -34,9 +48,14 @@ func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*comm
 		return nil, err
 	}
 
+	recordData, err := config.RecordDataWithClearedFields(nil)
+	if err != nil {
+		return nil, err
+	}
+
 	// Neither Post nor Patch return any response data on successful completion
 	// Both complete with 204 NoContent
-	_, err = write(ctx, url.String(), config.RecordData)
+	_, err = write(ctx, url.String(), recordData)
 	if err != nil {
 		return nil, err
 	}
@@ -45,3 +64,108 @@ func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*comm
 		Success: true,
 	}, nil
 }
+
+// upsert patches the entity addressed by the alternate key.
+// The record is returned, so that 201 Created can be told apart from 200 OK of update.
+func (c *Connector) upsert(ctx context.Context, config common.WriteParams) (*common.WriteResult, error) {
+	attributeType, err := c.getAttributeType(ctx, config.ObjectName, config.ExternalIdField)
+	if err != nil {
+		return nil, err
+	}
+
+	key := formatKeyLiteral(attributeType, config.RecordId)
+
+	url, err := c.getURL(fmt.Sprintf("%s(%s=%s)", config.ObjectName, config.ExternalIdField, key))
+	if err != nil {
+		return nil, err
+	}
+
+	recordData, err := config.RecordDataWithClearedFields(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := c.Client.Patch(ctx, url.String(), recordData, common.Header{
+		Key:   "Prefer",
+		Value: "return=representation",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &common.WriteResult{
+		Success:  true,
+		RecordId: entityIdFromHeader(rsp.Headers.Get("OData-EntityId")),
+		Outcome:  common.NewWriteOutcome(rsp.Code == http.StatusCreated),
+	}
+
+	if body, ok := rsp.Body(); ok {
+		result.Data, err = jsonquery.Convertor.ObjectToMap(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+type attributeTypeResponse struct {
+	AttributeType string `json:"AttributeType"`
+}
+
+// getAttributeType returns the type of the attribute, such as "String", "Integer" or "Uniqueidentifier".
+// https://learn.microsoft.com/en-us/power-apps/developer/data-platform/webapi/query-metadata-web-api#retrieving-attributes
+func (c *Connector) getAttributeType(ctx context.Context, objectName, attribute string) (string, error) {
+	url, err := c.getEntityDefinitionURL(naming.NewSingularString(objectName))
+	if err != nil {
+		return "", err
+	}
+
+	url.AddPath(fmt.Sprintf("Attributes(LogicalName='%v')", attribute))
+	url.WithQueryParam("$select", "AttributeType")
+
+	rsp, err := c.Client.Get(ctx, url.String())
+	if err != nil {
+		return "", err
+	}
+
+	response, err := common.UnmarshalJSON[attributeTypeResponse](rsp)
+	if err != nil {
+		return "", err
+	}
+
+	if response == nil {
+		return "", common.ErrEmptyJSONHTTPResponse
+	}
+
+	return response.AttributeType, nil
+}
+
+// Attribute types whose OData literals are written without quotes.
+var unquotedAttributeTypes = handy.NewStringSet( // nolint:gochecknoglobals
+	"BigInt", "Boolean", "Customer", "DateTime", "Decimal", "Double", "Integer",
+	"Lookup", "Money", "Owner", "Picklist", "State", "Status", "Uniqueidentifier",
+)
+
+// formatKeyLiteral writes the value of the alternate key as OData literal of the attribute type.
+// Text is quoted, single quotes are escaped by doubling them.
+// The literal is percent-encoded, as it becomes a part of the URL path.
+func formatKeyLiteral(attributeType, value string) string {
+	literal := value
+	if !unquotedAttributeTypes.Has(attributeType) {
+		literal = "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	}
+
+	return strings.ReplaceAll(url.QueryEscape(literal), "+", "%20")
+}
+
+// entityIdFromHeader returns the identifier of the entity URL,
+// e.g. https://org.crm.dynamics.com/api/data/v9.2/accounts(7d577253-3ef0-4a0a-bb7f-8335c2596e70).
+func entityIdFromHeader(entityURL string) string {
+	start := strings.LastIndex(entityURL, "(")
+	if start == -1 || !strings.HasSuffix(entityURL, ")") {
+		return ""
+	}
+
+	return entityURL[start+1 : len(entityURL)-1]
+}
@@ -3,6 +3,7 @@ package dynamicscrm
 import (
 	"errors"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/amp-labs/connectors"
@@ -44,6 +45,121 @@ func TestWrite(t *testing.T) { // nolint:funlen,cyclop
 				errors.New("Resource not found for the segment 'conacs'"), // nolint:goerr113
 			},
 		},
+		{
+			Name: "Upsert by alternate key reports created record",
+			Input: common.WriteParams{
+				ObjectName:      "accounts",
+				RecordId:        "O'Neil-1",
+				RecordData:      map[string]any{"name": "Acme"},
+				Mode:            common.WriteModeUpsert,
+				ExternalIdField: "accountnumber",
+			},
+			Server: mockserver.Switch{
+				Setup: func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.Header().Set("OData-EntityId",
+						"https://org.crm.dynamics.com/api/data/v9.2/accounts(7d577253-3ef0-4a0a-bb7f-8335c2596e70)")
+				},
+				Cases: []mockserver.Case{{
+					If:   mockcond.PathSuffix("EntityDefinitions(LogicalName='account')/Attributes(LogicalName='accountnumber')"),
+					Then: mockserver.ResponseString(http.StatusOK, `{"AttributeType": "String"}`),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodPATCH(),
+						mockcond.PathSuffix("accounts(accountnumber='O''Neil-1')"),
+						mockcond.Header(http.Header{"Prefer": []string{"return=representation"}}),
+					},
+					Then: mockserver.ResponseString(http.StatusCreated, `{"name": "Acme", "accountnumber": "O'Neil-1"}`),
+				}},
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success:  true,
+				RecordId: "7d577253-3ef0-4a0a-bb7f-8335c2596e70",
+				Data:     map[string]any{"name": "Acme", "accountnumber": "O'Neil-1"},
+				Outcome:  common.WriteOutcomeCreated,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Upsert by alternate key reports updated record",
+			Input: common.WriteParams{
+				ObjectName:      "accounts",
+				RecordId:        "A-1",
+				RecordData:      map[string]any{"name": "Acme"},
+				Mode:            common.WriteModeUpsert,
+				ExternalIdField: "accountnumber",
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If:   mockcond.PathSuffix("Attributes(LogicalName='accountnumber')"),
+					Then: mockserver.ResponseString(http.StatusOK, `{"AttributeType": "String"}`),
+				}, {
+					If:   mockcond.PathSuffix("accounts(accountnumber='A-1')"),
+					Then: mockserver.ResponseString(http.StatusOK, `{"name": "Acme"}`),
+				}},
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success: true,
+				Data:    map[string]any{"name": "Acme"},
+				Outcome: common.WriteOutcomeUpdated,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Upsert by numeric alternate key is not quoted",
+			Input: common.WriteParams{
+				ObjectName:      "accounts",
+				RecordId:        "1024",
+				RecordData:      map[string]any{"name": "Acme"},
+				Mode:            common.WriteModeUpsert,
+				ExternalIdField: "employeenumber",
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If:   mockcond.PathSuffix("Attributes(LogicalName='employeenumber')"),
+					Then: mockserver.ResponseString(http.StatusOK, `{"AttributeType": "Integer"}`),
+				}, {
+					If:   mockcond.PathSuffix("accounts(employeenumber=1024)"),
+					Then: mockserver.ResponseString(http.StatusOK, `{"name": "Acme"}`),
+				}},
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success: true,
+				Data:    map[string]any{"name": "Acme"},
+				Outcome: common.WriteOutcomeUpdated,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Upsert by alternate key escapes URL characters",
+			Input: common.WriteParams{
+				ObjectName:      "accounts",
+				RecordId:        "A/1#2&3",
+				RecordData:      map[string]any{"name": "Acme"},
+				Mode:            common.WriteModeUpsert,
+				ExternalIdField: "accountnumber",
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If:   mockcond.PathSuffix("Attributes(LogicalName='accountnumber')"),
+					Then: mockserver.ResponseString(http.StatusOK, `{"AttributeType": "String"}`),
+				}, {
+					If: mockcond.Check(func(w http.ResponseWriter, r *http.Request) bool {
+						return strings.HasSuffix(r.URL.EscapedPath(), "accounts(accountnumber=%27A%2F1%232%263%27)")
+					}),
+					Then: mockserver.ResponseString(http.StatusOK, `{"name": "Acme"}`),
+				}},
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success: true,
+				Data:    map[string]any{"name": "Acme"},
+				Outcome: common.WriteOutcomeUpdated,
+			},
+			ExpectedErrs: nil,
+		},
 		{
 			Name:  "Write must act as a Create",
 			Input: common.WriteParams{ObjectName: "fax", RecordData: "dummy"},
@@ -41,4 +41,11 @@ func init() {
 			Write:     true,
 		},
 	})
+
+	// Gong allows 3 calls per second and 10,000 calls per day.
+	SetRateLimit(Gong, RateLimitOpts{
+		RequestsPerSecond: 3,
+		Burst:             3,
+		DailyQuota:        10000,
+	})
 }
@@ -0,0 +1,39 @@
+package gong
+
+import (
+	"context"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// CheckConnection validates credentials by listing the first page of users.
+// Gong doesn't report remaining quota, scopes are known for OAuth tokens issued with the "scope" field.
+// https://gong.app.gong.io/settings/api/documentation#get-/v2/users
+func (c *Connector) CheckConnection(ctx context.Context) (*common.HealthReport, error) {
+	report := &common.HealthReport{
+		CheckedAt: time.Now(),
+		Account:   c.BaseURL,
+	}
+
+	url, err := c.getURL("users")
+	if err != nil {
+		return report, err
+	}
+
+	_, err = c.Client.Get(ctx, url.String())
+	report.Latency = time.Since(report.CheckedAt)
+
+	if err != nil {
+		return report, common.ClassifyConnectionError(err)
+	}
+
+	report.Healthy = true
+
+	token, _, err := common.OAuthToken(ctx, c.Client.HTTPClient.Client)
+	if err == nil {
+		report.Scopes = common.OAuthTokenScopes(token)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,95 @@
+package gong
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+)
+
+func TestCheckConnection(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []checkConnectionTestCase{
+		{
+			Name: "Healthy connection",
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.PathSuffix("/v2/users"),
+				Then: mockserver.ResponseString(http.StatusOK, `{
+					"requestId": "4al018gzaztcr8nbukw",
+					"records": {"totalRecords": 1, "currentPageSize": 1, "currentPageNumber": 0},
+					"users": [{"id": "234599484848423"}]
+				}`),
+			}.Server(),
+			Comparator:   healthReportComparator,
+			Expected:     &common.HealthReport{Healthy: true},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Rejected credentials are an invalid access token",
+			Server: mockserver.Fixed{
+				Setup: mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusUnauthorized, `{
+					"requestId": "4al018gzaztcr8nbukw",
+					"errors": ["Unauthorized"]
+				}`),
+			}.Server(),
+			Comparator:   healthReportComparator,
+			Expected:     &common.HealthReport{},
+			ExpectedErrs: []error{common.ErrAccessToken},
+		},
+		{
+			Name: "Missing scope is forbidden",
+			Server: mockserver.Fixed{
+				Setup: mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusForbidden, `{
+					"requestId": "4al018gzaztcr8nbukw",
+					"errors": ["Insufficient scope"]
+				}`),
+			}.Server(),
+			Comparator:   healthReportComparator,
+			Expected:     &common.HealthReport{},
+			ExpectedErrs: []error{common.ErrForbidden},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+// healthReportComparator ignores timing of the check.
+func healthReportComparator(serverURL string, actual, expected *common.HealthReport) bool {
+	return actual != nil &&
+		actual.Healthy == expected.Healthy &&
+		actual.Account == serverURL &&
+		!actual.CheckedAt.IsZero() &&
+		reflect.DeepEqual(actual.Scopes, expected.Scopes) &&
+		reflect.DeepEqual(actual.Quota, expected.Quota)
+}
+
+type (
+	checkConnectionTestCaseType = testroutines.TestCase[struct{}, *common.HealthReport]
+	checkConnectionTestCase     checkConnectionTestCaseType
+)
+
+func (c checkConnectionTestCase) Run(t *testing.T, builder testroutines.ConnectorBuilder[*Connector]) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	output, err := conn.CheckConnection(context.Background())
+	checkConnectionTestCaseType(c).Validate(t, err, output)
+}
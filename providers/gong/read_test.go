@@ -33,6 +33,16 @@ func TestRead(t *testing.T) { //nolint:funlen,gocognit,cyclop
 			Server:       mockserver.Dummy(),
 			ExpectedErrs: []error{common.ErrMissingFields},
 		},
+		{
+			Name: "Filter is not supported",
+			Input: common.ReadParams{
+				ObjectName: "calls",
+				Fields:     connectors.Fields("id"),
+				FilterBy:   common.FilterEq("direction", "Inbound"),
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
 		{
 			Name:         "Unsupported object name",
 			Input:        common.ReadParams{ObjectName: "butterflies", Fields: connectors.Fields("id")},
@@ -23,7 +23,19 @@ func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*comm
 		return nil, err
 	}
 
-	res, err := c.Client.Post(ctx, url.String(), config.RecordData)
+	write, err := common.WriteMethods{
+		common.WriteModeCreate: c.Client.Post,
+	}.Select(config)
+	if err != nil {
+		return nil, err
+	}
+
+	recordData, err := config.RecordDataWithClearedFields(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := write(ctx, url.String(), recordData)
 	if err != nil {
 		return nil, err
 	}
@@ -38,4 +38,11 @@ func init() {
 			},
 		},
 	})
+
+	// Public OAuth apps are allowed 110 requests every 10 seconds per account.
+	SetRateLimit(Hubspot, RateLimitOpts{
+		RequestsPerSecond: 10,
+		Burst:             10,
+		DailyQuota:        250000,
+	})
 }
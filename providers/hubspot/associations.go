@@ -0,0 +1,254 @@
+package hubspot
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// associationsVersion is the version of the associations API, which is newer than the rest of ModuleCRM.
+// Read more @ https://developers.hubspot.com/docs/api/crm/associations
+const associationsVersion = "v4"
+
+// maxAssociationsBatchSize is the number of records which can be looked up in a single batch read.
+const maxAssociationsBatchSize = 1000
+
+// AssociationCategory tells who defined the association type.
+type AssociationCategory string
+
+const (
+	AssociationCategoryHubSpotDefined    AssociationCategory = "HUBSPOT_DEFINED"
+	AssociationCategoryUserDefined       AssociationCategory = "USER_DEFINED"
+	AssociationCategoryIntegratorDefined AssociationCategory = "INTEGRATOR_DEFINED"
+)
+
+// AssociationType identifies the kind of association, also known as association label.
+// Custom labels are created in HubSpot and have USER_DEFINED category.
+// Read more @ https://developers.hubspot.com/docs/api/crm/associations#association-type-id-values
+type AssociationType struct {
+	Category AssociationCategory `json:"associationCategory"`
+	TypeId   int                 `json:"associationTypeId"`
+}
+
+// Commonly used association types between contacts, companies and deals.
+//
+//nolint:gochecknoglobals,gomnd,mnd
+var (
+	AssociationContactToCompany        = AssociationType{AssociationCategoryHubSpotDefined, 279}
+	AssociationContactToCompanyPrimary = AssociationType{AssociationCategoryHubSpotDefined, 1}
+	AssociationCompanyToContact        = AssociationType{AssociationCategoryHubSpotDefined, 280}
+	AssociationCompanyToContactPrimary = AssociationType{AssociationCategoryHubSpotDefined, 2}
+	AssociationDealToContact           = AssociationType{AssociationCategoryHubSpotDefined, 3}
+	AssociationContactToDeal           = AssociationType{AssociationCategoryHubSpotDefined, 4}
+	AssociationDealToCompany           = AssociationType{AssociationCategoryHubSpotDefined, 341}
+	AssociationDealToCompanyPrimary    = AssociationType{AssociationCategoryHubSpotDefined, 5}
+	AssociationCompanyToDeal           = AssociationType{AssociationCategoryHubSpotDefined, 342}
+	AssociationCompanyToDealPrimary    = AssociationType{AssociationCategoryHubSpotDefined, 6}
+)
+
+// AssociationParams describes a link between two records.
+type AssociationParams struct {
+	// FromObject is the object of the source record, e.g. "contacts".
+	FromObject string // required
+	// FromId is the ID of the source record.
+	FromId string // required
+	// ToObject is the object of the target record, e.g. "companies".
+	ToObject string // required
+	// ToId is the ID of the target record.
+	ToId string // required
+	// Types are the labels of the association. When empty, the default unlabeled association is used.
+	// When removing, only the listed labels are removed, otherwise records are fully disassociated.
+	Types []AssociationType // optional
+}
+
+func (p AssociationParams) ValidateParams() error {
+	if len(p.FromObject) == 0 || len(p.FromId) == 0 || len(p.ToObject) == 0 || len(p.ToId) == 0 {
+		return ErrMissingAssociation
+	}
+
+	return nil
+}
+
+// CreateAssociation links two records. Creating an existing association is not an error.
+func (c *Connector) CreateAssociation(ctx context.Context, params AssociationParams) error {
+	if err := c.validateAssociationParams(params); err != nil {
+		return err
+	}
+
+	if len(params.Types) == 0 {
+		relativeURL := strings.Join([]string{
+			"associations", params.FromObject, params.ToObject, "batch", "associate", "default",
+		}, "/")
+
+		_, err := c.Client.Post(ctx, c.getAssociationsURL(relativeURL), map[string]any{
+			"inputs": []map[string]any{{
+				"from": map[string]string{"id": params.FromId},
+				"to":   map[string]string{"id": params.ToId},
+			}},
+		})
+
+		return err
+	}
+
+	relativeURL := strings.Join([]string{
+		"objects", params.FromObject, params.FromId, "associations", params.ToObject, params.ToId,
+	}, "/")
+
+	_, err := c.Client.Put(ctx, c.getAssociationsURL(relativeURL), params.Types)
+
+	return err
+}
+
+// RemoveAssociation removes the given labels from the association of two records.
+// Without labels, all associations between the records are removed.
+func (c *Connector) RemoveAssociation(ctx context.Context, params AssociationParams) error {
+	if err := c.validateAssociationParams(params); err != nil {
+		return err
+	}
+
+	if len(params.Types) == 0 {
+		relativeURL := strings.Join([]string{
+			"objects", params.FromObject, params.FromId, "associations", params.ToObject, params.ToId,
+		}, "/")
+
+		_, err := c.Client.Delete(ctx, c.getAssociationsURL(relativeURL))
+
+		return err
+	}
+
+	relativeURL := strings.Join([]string{
+		"associations", params.FromObject, params.ToObject, "batch", "labels", "archive",
+	}, "/")
+
+	_, err := c.Client.Post(ctx, c.getAssociationsURL(relativeURL), map[string]any{
+		"inputs": []map[string]any{{
+			"types": params.Types,
+			"from":  map[string]string{"id": params.FromId},
+			"to":    map[string]string{"id": params.ToId},
+		}},
+	})
+
+	return err
+}
+
+func (c *Connector) validateAssociationParams(params AssociationParams) error {
+	if c.Module.ID != ModuleCRM {
+		return ErrAssociationsModule
+	}
+
+	return params.ValidateParams()
+}
+
+type associationsBatchResponse struct {
+	Results []associationsBatchResult `json:"results"`
+}
+
+type associationsBatchResult struct {
+	From struct {
+		Id string `json:"id"`
+	} `json:"from"`
+	To []map[string]any `json:"to"`
+}
+
+// fillAssociations looks up records of associated objects for every row of the result.
+// Only the first page of associations is returned for each record, which holds up to 500 links.
+func (c *Connector) fillAssociations(
+	ctx context.Context, objectName string, result *common.ReadResult, associatedObjects []string,
+) error {
+	if c.Module.ID != ModuleCRM {
+		return ErrAssociationsModule
+	}
+
+	ids := make([]string, 0, len(result.Data))
+	rowsById := make(map[string]*common.ReadResultRow, len(result.Data))
+
+	for index := range result.Data {
+		row := &result.Data[index]
+
+		if id, ok := row.Raw["id"].(string); ok {
+			ids = append(ids, id)
+			rowsById[id] = row
+		}
+	}
+
+	for _, toObject := range associatedObjects {
+		associations, err := c.readAssociations(ctx, objectName, toObject, ids)
+		if err != nil {
+			return err
+		}
+
+		for id, row := range rowsById {
+			if row.Associations == nil {
+				row.Associations = make(map[string][]common.Association)
+			}
+
+			row.Associations[toObject] = associations[id]
+		}
+	}
+
+	return nil
+}
+
+// readAssociations returns associations to the object keyed by the source record ID.
+// Records without associations are omitted.
+func (c *Connector) readAssociations(
+	ctx context.Context, fromObject, toObject string, ids []string,
+) (map[string][]common.Association, error) {
+	relativeURL := strings.Join([]string{"associations", fromObject, toObject, "batch", "read"}, "/")
+	associations := make(map[string][]common.Association)
+
+	for start := 0; start < len(ids); start += maxAssociationsBatchSize {
+		batch := ids[start:min(start+maxAssociationsBatchSize, len(ids))]
+
+		inputs := make([]map[string]string, len(batch))
+		for index, id := range batch {
+			inputs[index] = map[string]string{"id": id}
+		}
+
+		rsp, err := c.Client.Post(ctx, c.getAssociationsURL(relativeURL), map[string]any{"inputs": inputs})
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := common.UnmarshalJSON[associationsBatchResponse](rsp)
+		if err != nil {
+			return nil, err
+		}
+
+		if body == nil {
+			return nil, ErrAssociationResponse
+		}
+
+		for _, result := range body.Results {
+			for _, target := range result.To {
+				associations[result.From.Id] = append(associations[result.From.Id], makeAssociation(target))
+			}
+		}
+	}
+
+	return associations, nil
+}
+
+// makeAssociation converts an item of the "to" list. Object IDs are numbers in this API.
+func makeAssociation(target map[string]any) common.Association {
+	association := common.Association{Raw: target}
+
+	switch id := target["toObjectId"].(type) {
+	case float64:
+		association.ObjectId = strconv.FormatFloat(id, 'f', -1, 64)
+	case string:
+		association.ObjectId = id
+	}
+
+	types, _ := target["associationTypes"].([]any)
+	for _, item := range types {
+		associationType, _ := item.(map[string]any)
+		if label, ok := associationType["label"].(string); ok && len(label) != 0 {
+			association.Labels = append(association.Labels, label)
+		}
+	}
+
+	return association
+}
@@ -0,0 +1,268 @@
+package hubspot
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+)
+
+func TestCreateAssociation(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	contactToCompany := AssociationParams{
+		FromObject: "contacts",
+		FromId:     "101",
+		ToObject:   "companies",
+		ToId:       "201",
+	}
+
+	labeled := contactToCompany
+	labeled.Types = []AssociationType{AssociationContactToCompanyPrimary}
+
+	tests := []associationTestCase{
+		{
+			Name:         "Both records are required",
+			Input:        AssociationParams{FromObject: "contacts", FromId: "101", ToObject: "companies"},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{ErrMissingAssociation},
+		},
+		{
+			Name:  "Default association is created in batch",
+			Input: contactToCompany,
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPOST(),
+					mockcond.PathSuffix("/crm/v4/associations/contacts/companies/batch/associate/default"),
+					mockcond.Body(`{"inputs": [{"from": {"id": "101"}, "to": {"id": "201"}}]}`),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{"status": "COMPLETE", "results": []}`),
+			}.Server(),
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Labeled association is put on the record",
+			Input: labeled,
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.Method(http.MethodPut),
+					mockcond.PathSuffix("/crm/v4/objects/contacts/101/associations/companies/201"),
+					mockcond.Body(`[{"associationCategory":"HUBSPOT_DEFINED","associationTypeId":1}]`),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{"fromObjectId": 101, "toObjectId": 201}`),
+			}.Server(),
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Failure is reported",
+			Input: contactToCompany,
+			Server: mockserver.Fixed{
+				Setup: mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusBadRequest, `{"status": "error",
+					"message": "Invalid input", "category": "VALIDATION_ERROR"}`),
+			}.Server(),
+			ExpectedErrs: []error{common.ErrBadRequest},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			}, (*Connector).CreateAssociation)
+		})
+	}
+}
+
+func TestRemoveAssociation(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	contactToCompany := AssociationParams{
+		FromObject: "contacts",
+		FromId:     "101",
+		ToObject:   "companies",
+		ToId:       "201",
+	}
+
+	labeled := contactToCompany
+	labeled.Types = []AssociationType{AssociationContactToCompanyPrimary}
+
+	tests := []associationTestCase{
+		{
+			Name:  "Records are fully disassociated without labels",
+			Input: contactToCompany,
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodDELETE(),
+					mockcond.PathSuffix("/crm/v4/objects/contacts/101/associations/companies/201"),
+				},
+				Then: mockserver.Response(http.StatusNoContent),
+			}.Server(),
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Only listed labels are archived",
+			Input: labeled,
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPOST(),
+					mockcond.PathSuffix("/crm/v4/associations/contacts/companies/batch/labels/archive"),
+					mockcond.Body(`{"inputs": [{"from": {"id": "101"}, "to": {"id": "201"},
+						"types": [{"associationCategory": "HUBSPOT_DEFINED", "associationTypeId": 1}]}]}`),
+				},
+				Then: mockserver.Response(http.StatusNoContent),
+			}.Server(),
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			}, (*Connector).RemoveAssociation)
+		})
+	}
+}
+
+func TestAssociationsRequireCRMModule(t *testing.T) {
+	t.Parallel()
+
+	tt := associationTestCase{
+		Name: "Associations are not available outside of CRM module",
+		Input: AssociationParams{
+			FromObject: "contacts",
+			FromId:     "101",
+			ToObject:   "companies",
+			ToId:       "201",
+		},
+		Server:       mockserver.Dummy(),
+		ExpectedErrs: []error{ErrAssociationsModule},
+	}
+
+	tt.Run(t, func() (*Connector, error) {
+		connector, err := NewConnector(
+			WithAuthenticatedClient(http.DefaultClient),
+			WithModule(ModuleEmpty),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		connector.setBaseURL(tt.Server.URL)
+
+		return connector, nil
+	}, (*Connector).CreateAssociation)
+}
+
+func TestReadAssociations(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []testroutines.Read{
+		{
+			Name: "Associated records are attached to rows",
+			Input: common.ReadParams{
+				ObjectName:        "contacts",
+				Fields:            connectors.Fields("email"),
+				AssociatedObjects: []string{"companies"},
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If: mockcond.PathSuffix("/crm/v3/objects/contacts/"),
+					Then: mockserver.ResponseString(http.StatusOK, `{"results": [
+						{"id": "101", "properties": {"email": "bob@acme.com"}},
+						{"id": "102", "properties": {"email": "ann@acme.com"}}]}`),
+				}, {
+					If: mockcond.And{
+						mockcond.PathSuffix("/crm/v4/associations/contacts/companies/batch/read"),
+						mockcond.Body(`{"inputs": [{"id": "101"}, {"id": "102"}]}`),
+					},
+					Then: mockserver.ResponseString(http.StatusOK, `{"status": "COMPLETE", "results": [
+						{"from": {"id": "101"}, "to": [{"toObjectId": 201, "associationTypes": [
+							{"category": "HUBSPOT_DEFINED", "typeId": 1, "label": "Primary"},
+							{"category": "HUBSPOT_DEFINED", "typeId": 279, "label": null}]}]}]}`),
+				}},
+			}.Server(),
+			Comparator: func(baseURL string, actual, expected *common.ReadResult) bool {
+				if actual.Rows != expected.Rows || len(actual.Data) != len(expected.Data) {
+					return false
+				}
+
+				for index, row := range actual.Data {
+					wanted := expected.Data[index].Associations["companies"]
+					got := row.Associations["companies"]
+
+					if len(got) != len(wanted) {
+						return false
+					}
+
+					for position := range got {
+						if got[position].ObjectId != wanted[position].ObjectId ||
+							len(got[position].Labels) != len(wanted[position].Labels) {
+							return false
+						}
+					}
+				}
+
+				return true
+			},
+			Expected: &common.ReadResult{
+				Rows: 2,
+				Data: []common.ReadResultRow{{
+					Associations: map[string][]common.Association{
+						"companies": {{ObjectId: "201", Labels: []string{"Primary"}}},
+					},
+				}, {
+					Associations: map[string][]common.Association{},
+				}},
+				Done: true,
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.ReadConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+type (
+	associationTestCaseType = testroutines.TestCase[AssociationParams, struct{}]
+	associationTestCase     associationTestCaseType
+)
+
+func (c associationTestCase) Run(
+	t *testing.T, builder testroutines.ConnectorBuilder[*Connector],
+	method func(*Connector, context.Context, AssociationParams) error,
+) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	err := method(conn, context.Background(), c.Input)
+	associationTestCaseType(c).Validate(t, err, struct{}{})
+}
@@ -0,0 +1,314 @@
+package hubspot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// maxBatchSize is the number of records accepted by a single batch request.
+const maxBatchSize = 100
+
+// BatchWriteParams describes records written by BatchCreate, BatchUpdate and BatchUpsert.
+type BatchWriteParams struct {
+	// The name of the object we are writing, e.g. "contacts"
+	ObjectName string // required
+	// Records to write. RecordId is ignored on create, it is required on update.
+	// On upsert RecordId holds the value of IdProperty, e.g. email address.
+	Records []BatchRecord // required, at most 100
+	// IdProperty is a unique property used by upsert to find existing records, e.g. "email".
+	IdProperty string // required for upsert only
+}
+
+// BatchRecord is a single record of a batch.
+type BatchRecord struct {
+	RecordId   string
+	RecordData any
+}
+
+func (p BatchWriteParams) validate(requireIds bool) error {
+	if len(p.ObjectName) == 0 {
+		return common.ErrMissingObjects
+	}
+
+	if len(p.Records) == 0 {
+		return common.ErrMissingRecordData
+	}
+
+	if len(p.Records) > maxBatchSize {
+		return fmt.Errorf("%w: got %v, limit is %v", ErrTooManyRecords, len(p.Records), maxBatchSize)
+	}
+
+	for _, record := range p.Records {
+		if record.RecordData == nil {
+			return common.ErrMissingRecordData
+		}
+
+		if requireIds && len(record.RecordId) == 0 {
+			return common.ErrMissingRecordID
+		}
+	}
+
+	return nil
+}
+
+// BatchArchiveParams describes records removed by BatchArchive.
+type BatchArchiveParams struct {
+	// The name of the object we are deleting, e.g. "contacts"
+	ObjectName string // required
+	// RecordIds of records to archive.
+	RecordIds []string // required, at most 100
+}
+
+func (p BatchArchiveParams) ValidateParams() error {
+	if len(p.ObjectName) == 0 {
+		return common.ErrMissingObjects
+	}
+
+	if len(p.RecordIds) == 0 {
+		return common.ErrMissingRecordID
+	}
+
+	if len(p.RecordIds) > maxBatchSize {
+		return fmt.Errorf("%w: got %v, limit is %v", ErrTooManyRecords, len(p.RecordIds), maxBatchSize)
+	}
+
+	return nil
+}
+
+// BatchCreate creates up to 100 records in a single call.
+// Results are returned per record in the order of Records.
+// Read more @ https://developers.hubspot.com/docs/api/crm/contacts
+func (c *Connector) BatchCreate(ctx context.Context, params BatchWriteParams) ([]common.WriteResult, error) {
+	if err := params.validate(false); err != nil {
+		return nil, err
+	}
+
+	return c.batchWrite(ctx, params, "create", func(BatchRecord) map[string]any {
+		return map[string]any{}
+	}, nil)
+}
+
+// BatchUpdate updates up to 100 records identified by RecordId in a single call.
+// Results are returned per record in the order of Records.
+func (c *Connector) BatchUpdate(ctx context.Context, params BatchWriteParams) ([]common.WriteResult, error) {
+	if err := params.validate(true); err != nil {
+		return nil, err
+	}
+
+	return c.batchWrite(ctx, params, "update", func(record BatchRecord) map[string]any {
+		return map[string]any{"id": record.RecordId}
+	}, func(rsp batchResult) string {
+		return rsp.ID
+	})
+}
+
+// BatchUpsert creates or updates up to 100 records matched by IdProperty in a single call.
+// Results are returned per record in the order of Records.
+func (c *Connector) BatchUpsert(ctx context.Context, params BatchWriteParams) ([]common.WriteResult, error) {
+	if err := params.validate(true); err != nil {
+		return nil, err
+	}
+
+	if len(params.IdProperty) == 0 {
+		return nil, ErrMissingIdProperty
+	}
+
+	return c.batchWrite(ctx, params, "upsert", func(record BatchRecord) map[string]any {
+		return map[string]any{
+			"id":         record.RecordId,
+			"idProperty": params.IdProperty,
+		}
+	}, func(rsp batchResult) string {
+		value, _ := rsp.Properties[params.IdProperty].(string)
+
+		return value
+	})
+}
+
+// BatchArchive archives up to 100 records in a single call.
+// HubSpot reports no per record outcome, therefore every record shares the result of the request.
+func (c *Connector) BatchArchive(ctx context.Context, params BatchArchiveParams) ([]common.WriteResult, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	inputs := make([]map[string]any, len(params.RecordIds))
+	for index, id := range params.RecordIds {
+		inputs[index] = map[string]any{"id": id}
+	}
+
+	relativeURL := strings.Join([]string{"objects", params.ObjectName, "batch", "archive"}, "/")
+
+	// 204 NoContent is expected
+	if _, err := c.Client.Post(ctx, c.getURL(relativeURL), map[string]any{"inputs": inputs}); err != nil {
+		return nil, err
+	}
+
+	results := make([]common.WriteResult, len(params.RecordIds))
+	for index, id := range params.RecordIds {
+		results[index] = common.WriteResult{
+			Success:  true,
+			RecordId: id,
+		}
+	}
+
+	return results, nil
+}
+
+// batchResponse is returned by batch create, update and upsert.
+// Status 207 Multi-Status means that some records failed, they are listed in errors.
+type batchResponse struct {
+	Status  string         `json:"status"`
+	Results []batchResult  `json:"results"`
+	Errors  []HubspotError `json:"errors"`
+}
+
+type batchResult struct {
+	writeResponse
+
+	ObjectWriteTraceId string `json:"objectWriteTraceId,omitempty"`
+	// New is reported by upsert, it is true when the record was created.
+	New *bool `json:"new,omitempty"`
+}
+
+// batchWrite sends records to the batch endpoint and pairs response items with the records.
+// Every input is tagged with objectWriteTraceId, which HubSpot echoes in results and errors.
+// When it is absent, results are matched by the key, or by order if there is no key.
+func (c *Connector) batchWrite(
+	ctx context.Context, params BatchWriteParams, action string,
+	makeInput func(record BatchRecord) map[string]any,
+	resultKey func(rsp batchResult) string,
+) ([]common.WriteResult, error) {
+	inputs := make([]map[string]any, len(params.Records))
+
+	for index, record := range params.Records {
+		input := makeInput(record)
+		input["properties"] = record.RecordData
+		input["objectWriteTraceId"] = strconv.Itoa(index)
+		inputs[index] = input
+	}
+
+	relativeURL := strings.Join([]string{"objects", params.ObjectName, "batch", action}, "/")
+
+	rsp, err := c.Client.Post(ctx, c.getURL(relativeURL), map[string]any{"inputs": inputs})
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := common.UnmarshalJSON[batchResponse](rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, common.ErrEmptyJSONHTTPResponse
+	}
+
+	return makeBatchResults(params.Records, body, resultKey), nil
+}
+
+// makeBatchResults returns a result for every record.
+// Errors that cannot be traced to a record are reported by every record left without a result.
+func makeBatchResults(
+	records []BatchRecord, body *batchResponse, resultKey func(rsp batchResult) string,
+) []common.WriteResult {
+	results := make([]common.WriteResult, len(records))
+	resolved := make([]bool, len(records))
+
+	indexByKey := make(map[string]int)
+	if resultKey != nil {
+		for index, record := range records {
+			indexByKey[record.RecordId] = index
+		}
+	}
+
+	findIndex := func(traceIds []string, keys []string) (int, bool) {
+		for _, traceId := range traceIds {
+			if index, err := strconv.Atoi(traceId); err == nil && index >= 0 && index < len(records) {
+				return index, true
+			}
+		}
+
+		for _, key := range keys {
+			if index, ok := indexByKey[key]; ok {
+				return index, true
+			}
+		}
+
+		return 0, false
+	}
+
+	untraced := make([]batchResult, 0)
+
+	for _, item := range body.Results {
+		var keys []string
+		if resultKey != nil {
+			keys = []string{resultKey(item)}
+		}
+
+		index, ok := findIndex([]string{item.ObjectWriteTraceId}, keys)
+		if !ok {
+			untraced = append(untraced, item)
+
+			continue
+		}
+
+		resolved[index] = true
+		results[index] = makeBatchSuccess(item)
+	}
+
+	unassignedErrors := make([]any, 0)
+
+	for _, hubspotErr := range body.Errors {
+		index, ok := findIndex(hubspotErr.Context.ObjectWriteTraceId,
+			append(append([]string{}, hubspotErr.Context.ID...), hubspotErr.Context.IDs...))
+		if !ok {
+			unassignedErrors = append(unassignedErrors, hubspotErr)
+
+			continue
+		}
+
+		resolved[index] = true
+		results[index].Errors = append(results[index].Errors, hubspotErr)
+	}
+
+	for index := range results {
+		if resolved[index] {
+			continue
+		}
+
+		// Remaining results follow the order of inputs.
+		if len(untraced) != 0 {
+			results[index] = makeBatchSuccess(untraced[0])
+			untraced = untraced[1:]
+
+			continue
+		}
+
+		results[index].Errors = unassignedErrors
+	}
+
+	for index := range results {
+		results[index].Success = len(results[index].Errors) == 0
+	}
+
+	return results
+}
+
+func makeBatchSuccess(item batchResult) common.WriteResult {
+	result := common.WriteResult{
+		Success:  true,
+		RecordId: item.ID,
+		Data:     item.Properties,
+	}
+
+	if item.New != nil {
+		result.Outcome = common.NewWriteOutcome(*item.New)
+	}
+
+	return result
+}
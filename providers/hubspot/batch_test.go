@@ -0,0 +1,219 @@
+package hubspot
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+)
+
+func TestBatchUpsert(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	upsertContacts := BatchWriteParams{
+		ObjectName: "contacts",
+		Records: []BatchRecord{
+			{RecordId: "bob@acme.com", RecordData: map[string]any{"firstname": "Bob"}},
+			{RecordId: "ann@acme.com", RecordData: map[string]any{"firstname": "Ann"}},
+		},
+		IdProperty: "email",
+	}
+
+	tests := []batchWriteTestCase{
+		{
+			Name: "Upsert requires id property",
+			Input: BatchWriteParams{
+				ObjectName: "contacts",
+				Records:    upsertContacts.Records,
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{ErrMissingIdProperty},
+		},
+		{
+			Name: "Batch size is limited",
+			Input: BatchWriteParams{
+				ObjectName: "contacts",
+				Records:    make([]BatchRecord, maxBatchSize+1),
+				IdProperty: "email",
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{ErrTooManyRecords},
+		},
+		{
+			Name:  "Results are matched by trace id",
+			Input: upsertContacts,
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.PathSuffix("/crm/v3/objects/contacts/batch/upsert"),
+					mockcond.Body(`{"inputs": [
+						{"id": "bob@acme.com", "idProperty": "email", "objectWriteTraceId": "0",
+							"properties": {"firstname": "Bob"}},
+						{"id": "ann@acme.com", "idProperty": "email", "objectWriteTraceId": "1",
+							"properties": {"firstname": "Ann"}}]}`),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{"status": "COMPLETE", "results": [
+					{"id": "502", "new": true, "objectWriteTraceId": "1", "properties": {"email": "ann@acme.com"}},
+					{"id": "501", "new": false, "objectWriteTraceId": "0", "properties": {"email": "bob@acme.com"}}]}`),
+			}.Server(),
+			Expected: []common.WriteResult{{
+				Success:  true,
+				RecordId: "501",
+				Data:     map[string]any{"email": "bob@acme.com"},
+				Outcome:  common.WriteOutcomeUpdated,
+			}, {
+				Success:  true,
+				RecordId: "502",
+				Data:     map[string]any{"email": "ann@acme.com"},
+				Outcome:  common.WriteOutcomeCreated,
+			}},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Results without trace id are matched by id property",
+			Input: upsertContacts,
+			Server: mockserver.Fixed{
+				Setup: mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusOK, `{"status": "COMPLETE", "results": [
+					{"id": "502", "properties": {"email": "ann@acme.com"}},
+					{"id": "501", "properties": {"email": "bob@acme.com"}}]}`),
+			}.Server(),
+			Expected: []common.WriteResult{{
+				Success:  true,
+				RecordId: "501",
+				Data:     map[string]any{"email": "bob@acme.com"},
+			}, {
+				Success:  true,
+				RecordId: "502",
+				Data:     map[string]any{"email": "ann@acme.com"},
+			}},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Errors are matched by trace id",
+			Input: upsertContacts,
+			Server: mockserver.Fixed{
+				Setup: mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusMultiStatus, `{"status": "COMPLETE",
+					"results": [{"id": "502", "objectWriteTraceId": "1", "properties": {"email": "ann@acme.com"}}],
+					"errors": [{"status": "error", "category": "VALIDATION_ERROR",
+						"message": "Property values were not valid", "context": {"objectWriteTraceId": ["0"]}}]}`),
+			}.Server(),
+			Expected: []common.WriteResult{{
+				Success: false,
+				Errors: []any{HubspotError{
+					Status:   "error",
+					Category: "VALIDATION_ERROR",
+					Message:  "Property values were not valid",
+					Context:  ErrContext{ObjectWriteTraceId: []string{"0"}},
+				}},
+			}, {
+				Success:  true,
+				RecordId: "502",
+				Data:     map[string]any{"email": "ann@acme.com"},
+			}},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			}, (*Connector).BatchUpsert)
+		})
+	}
+}
+
+func TestBatchCreate(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	createContacts := BatchWriteParams{
+		ObjectName: "contacts",
+		Records: []BatchRecord{
+			{RecordData: map[string]any{"firstname": "Bob"}},
+			{RecordData: map[string]any{"firstname": "Ann"}},
+		},
+	}
+
+	tests := []batchWriteTestCase{
+		{
+			Name:  "Untraced results follow the order of records",
+			Input: createContacts,
+			Server: mockserver.Fixed{
+				Setup: mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusCreated, `{"status": "COMPLETE", "results": [
+					{"id": "501", "properties": {"firstname": "Bob"}},
+					{"id": "502", "properties": {"firstname": "Ann"}}]}`),
+			}.Server(),
+			Expected: []common.WriteResult{{
+				Success:  true,
+				RecordId: "501",
+				Data:     map[string]any{"firstname": "Bob"},
+			}, {
+				Success:  true,
+				RecordId: "502",
+				Data:     map[string]any{"firstname": "Ann"},
+			}},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Untraced errors are reported by records left without result",
+			Input: createContacts,
+			Server: mockserver.Fixed{
+				Setup: mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusMultiStatus, `{"status": "COMPLETE",
+					"results": [{"id": "501", "properties": {"firstname": "Bob"}}],
+					"errors": [{"status": "error", "category": "VALIDATION_ERROR", "message": "Duplicate"}]}`),
+			}.Server(),
+			Expected: []common.WriteResult{{
+				Success:  true,
+				RecordId: "501",
+				Data:     map[string]any{"firstname": "Bob"},
+			}, {
+				Success: false,
+				Errors: []any{HubspotError{
+					Status:   "error",
+					Category: "VALIDATION_ERROR",
+					Message:  "Duplicate",
+				}},
+			}},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			}, (*Connector).BatchCreate)
+		})
+	}
+}
+
+type (
+	batchWriteTestCaseType = testroutines.TestCase[BatchWriteParams, []common.WriteResult]
+	batchWriteTestCase     batchWriteTestCaseType
+)
+
+func (c batchWriteTestCase) Run(
+	t *testing.T, builder testroutines.ConnectorBuilder[*Connector],
+	method func(*Connector, context.Context, BatchWriteParams) ([]common.WriteResult, error),
+) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	output, err := method(conn, context.Background(), c.Input)
+	batchWriteTestCaseType(c).Validate(t, err, output)
+}
@@ -0,0 +1,27 @@
+package hubspot
+
+import (
+	"context"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// Delete archives a single record. Archived records can be restored within 90 days.
+// Read more @ https://developers.hubspot.com/docs/api/crm/understanding-the-crm
+func (c *Connector) Delete(ctx context.Context, config common.DeleteParams) (*common.DeleteResult, error) {
+	if err := config.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	relativeURL := strings.Join([]string{"objects", config.ObjectName, config.RecordId}, "/")
+
+	// 204 NoContent is expected
+	if _, err := c.Client.Delete(ctx, c.getURL(relativeURL)); err != nil {
+		return nil, err
+	}
+
+	return &common.DeleteResult{
+		Success: true,
+	}, nil
+}
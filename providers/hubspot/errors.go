@@ -16,6 +16,14 @@ var (
 	ErrNotArray         = errors.New("results is not an array")
 	ErrNotObject        = errors.New("result is not an object")
 	ErrNotString        = errors.New("link is not a string")
+	ErrInvalidNextPage  = errors.New("next page token is invalid")
+
+	ErrAssociationsModule  = errors.New("associations are only available in the CRM module")
+	ErrMissingAssociation  = errors.New("association requires both objects and record ids")
+	ErrAssociationResponse = errors.New("unexpected associations response")
+
+	ErrTooManyRecords    = errors.New("too many records in a single request")
+	ErrMissingIdProperty = errors.New("upsert requires id property")
 )
 
 type HubspotError struct {
@@ -38,11 +46,14 @@ type ErrDetail struct {
 }
 
 type ErrContext struct {
-	ID             []string `json:"id,omitempty"`
-	Type           []string `json:"type,omitempty"`
-	ObjectType     []string `json:"objectType,omitempty"`
-	FromObjectType []string `json:"fromObjectType,omitempty"`
-	ToObjectType   []string `json:"toObjectType,omitempty"`
+	ID  []string `json:"id,omitempty"`
+	IDs []string `json:"ids,omitempty"`
+	// ObjectWriteTraceId echoes the value set on the input of a batch request.
+	ObjectWriteTraceId []string `json:"objectWriteTraceId,omitempty"`
+	Type               []string `json:"type,omitempty"`
+	ObjectType         []string `json:"objectType,omitempty"`
+	FromObjectType     []string `json:"fromObjectType,omitempty"`
+	ToObjectType       []string `json:"toObjectType,omitempty"`
 }
 
 type ErrLinks struct {
@@ -0,0 +1,182 @@
+package hubspot
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// Search endpoint limits.
+// https://developers.hubspot.com/docs/api/crm/search#filter-search-results
+const (
+	maxFilterGroups        = 5
+	maxFiltersPerGroup     = 6
+	maxFiltersAcrossGroups = 18
+)
+
+// containsTokenWildcard allows CONTAINS_TOKEN to match part of a word.
+const containsTokenWildcard = "*"
+
+// Search operators keyed by filter operator.
+var filterOperators = map[common.FilterOperator]FilterOperatorType{ // nolint:gochecknoglobals
+	common.FilterOperatorEq:       FilterOperatorTypeEQ,
+	common.FilterOperatorNe:       FilterOperatorTypeNEQ,
+	common.FilterOperatorGt:       FilterOperatorTypeGT,
+	common.FilterOperatorLt:       FilterOperatorTypeLT,
+	common.FilterOperatorIn:       FilterOperatorIN,
+	common.FilterOperatorContains: FilterPropertyContainsToken,
+}
+
+// makeFilterGroups converts filter expression into search filter groups.
+// Groups are OR-ed, while filters within a group are AND-ed, therefore expression is rewritten
+// in disjunctive normal form. Every group is extended with the extra filters.
+// Expressions exceeding the search limits are not supported.
+func makeFilterGroups(expr *common.FilterExpr, extra ...Filter) ([]FilterGroup, error) {
+	disjunction, err := makeFilterDisjunction(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]FilterGroup, len(disjunction))
+	total := 0
+
+	for index, conjunction := range disjunction {
+		filters := append(append([]Filter{}, extra...), conjunction...)
+		if len(filters) > maxFiltersPerGroup {
+			return nil, errFilterTooLarge(expr)
+		}
+
+		total += len(filters)
+		groups[index] = FilterGroup{Filters: filters}
+	}
+
+	if total > maxFiltersAcrossGroups {
+		return nil, errFilterTooLarge(expr)
+	}
+
+	return groups, nil
+}
+
+// makeFilterDisjunction returns a list of OR-ed groups each holding AND-ed filters.
+func makeFilterDisjunction(expr *common.FilterExpr) ([][]Filter, error) {
+	switch expr.Operator { // nolint:exhaustive
+	case common.FilterOperatorOr:
+		disjunction := make([][]Filter, 0)
+
+		for _, operand := range expr.Operands {
+			groups, err := makeFilterDisjunction(operand)
+			if err != nil {
+				return nil, err
+			}
+
+			disjunction = append(disjunction, groups...)
+			if len(disjunction) > maxFilterGroups {
+				return nil, errFilterTooLarge(expr)
+			}
+		}
+
+		return disjunction, nil
+	case common.FilterOperatorAnd:
+		// Distribute AND over OR: (a OR b) AND c = (a AND c) OR (b AND c).
+		disjunction := [][]Filter{{}}
+
+		for _, operand := range expr.Operands {
+			groups, err := makeFilterDisjunction(operand)
+			if err != nil {
+				return nil, err
+			}
+
+			product := make([][]Filter, 0, len(disjunction)*len(groups))
+
+			for _, left := range disjunction {
+				for _, right := range groups {
+					product = append(product, append(append([]Filter{}, left...), right...))
+				}
+			}
+
+			if len(product) > maxFilterGroups {
+				return nil, errFilterTooLarge(expr)
+			}
+
+			disjunction = product
+		}
+
+		return disjunction, nil
+	}
+
+	filter, err := makeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return [][]Filter{{filter}}, nil
+}
+
+func makeFilter(expr *common.FilterExpr) (Filter, error) {
+	operator, ok := filterOperators[expr.Operator]
+	if !ok {
+		return Filter{}, common.NewUnsupportedFilterError(expr, "operator is not supported")
+	}
+
+	filter := Filter{
+		FieldName: expr.Field,
+		Operator:  operator,
+	}
+
+	if expr.Operator == common.FilterOperatorIn {
+		filter.Values = make([]string, len(expr.Values))
+
+		for index, value := range expr.Values {
+			text, err := formatFilterValue(expr, value)
+			if err != nil {
+				return Filter{}, err
+			}
+
+			filter.Values[index] = text
+		}
+
+		return filter, nil
+	}
+
+	text, err := formatFilterValue(expr, expr.Value)
+	if err != nil {
+		return Filter{}, err
+	}
+
+	if expr.Operator == common.FilterOperatorContains {
+		// Tokens are matched as whole words unless wildcards are used.
+		text = containsTokenWildcard + text + containsTokenWildcard
+	}
+
+	filter.Value = text
+
+	return filter, nil
+}
+
+func formatFilterValue(expr *common.FilterExpr, value any) (string, error) {
+	switch val := value.(type) {
+	case string:
+		return val, nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case int:
+		return strconv.Itoa(val), nil
+	case int64:
+		return strconv.FormatInt(val, 10), nil
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), nil
+	case time.Time:
+		return val.Format(time.RFC3339), nil
+	default:
+		return "", common.NewUnsupportedFilterError(expr, fmt.Sprintf("value of type %T is not supported", value))
+	}
+}
+
+func errFilterTooLarge(expr *common.FilterExpr) error {
+	return common.NewUnsupportedFilterError(expr, fmt.Sprintf(
+		"exceeds search limits of %v groups, %v filters per group, %v filters in total",
+		maxFilterGroups, maxFiltersPerGroup, maxFiltersAcrossGroups,
+	))
+}
@@ -0,0 +1,106 @@
+package hubspot
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+const dailyQuotaWindow = 24 * time.Hour
+
+// Rate limit headers. Daily limits are sent to private apps only, OAuth apps get the burst limit.
+// Read more @ https://developers.hubspot.com/docs/api/usage-details#rate-limits
+const (
+	headerRateLimitDaily          = "X-HubSpot-RateLimit-Daily"
+	headerRateLimitDailyRemaining = "X-HubSpot-RateLimit-Daily-Remaining"
+	headerRateLimitMax            = "X-HubSpot-RateLimit-Max"
+	headerRateLimitRemaining      = "X-HubSpot-RateLimit-Remaining"
+	headerRateLimitInterval       = "X-HubSpot-RateLimit-Interval-Milliseconds"
+)
+
+type accountDetailsResponse struct {
+	PortalId int64 `json:"portalId"`
+}
+
+type accessTokenInfoResponse struct {
+	Scopes []string `json:"scopes"`
+}
+
+// CheckConnection validates credentials by reading details of the connected account.
+// The account is reported by its portal ID. Scopes are looked up for OAuth access tokens only.
+// Read more @ https://developers.hubspot.com/docs/api/settings/account-information-api
+func (c *Connector) CheckConnection(ctx context.Context) (*common.HealthReport, error) {
+	report := &common.HealthReport{
+		CheckedAt: time.Now(),
+	}
+
+	rsp, err := c.Client.Get(ctx, c.BaseURL+"/account-info/v3/details")
+	report.Latency = time.Since(report.CheckedAt)
+
+	if err != nil {
+		return report, common.ClassifyConnectionError(err)
+	}
+
+	details, err := common.UnmarshalJSON[accountDetailsResponse](rsp)
+	if err != nil {
+		return report, err
+	}
+
+	if details == nil {
+		return report, common.ErrEmptyJSONHTTPResponse
+	}
+
+	report.Healthy = true
+	report.Account = strconv.FormatInt(details.PortalId, 10)
+	report.Quota = parseQuota(rsp)
+
+	scopes, err := c.getTokenScopes(ctx)
+	if err == nil {
+		report.Scopes = scopes
+	}
+
+	return report, nil
+}
+
+// parseQuota prefers the daily limit, falling back to the limit of the current interval.
+func parseQuota(rsp *common.JSONHTTPResponse) *common.APIQuota {
+	quota := common.ParseAPIQuota(rsp.Headers, headerRateLimitDaily, headerRateLimitDailyRemaining, dailyQuotaWindow)
+	if quota != nil {
+		return quota
+	}
+
+	interval, err := strconv.ParseInt(rsp.Headers.Get(headerRateLimitInterval), 10, 64)
+	if err != nil {
+		interval = 0
+	}
+
+	return common.ParseAPIQuota(rsp.Headers, headerRateLimitMax, headerRateLimitRemaining,
+		time.Duration(interval)*time.Millisecond)
+}
+
+// getTokenScopes returns scopes granted to the OAuth access token.
+// Read more @ https://developers.hubspot.com/docs/api/oauth/tokens
+func (c *Connector) getTokenScopes(ctx context.Context) ([]string, error) {
+	token, ok, err := common.OAuthToken(ctx, c.Client.HTTPClient.Client)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	relativeURL := strings.Join([]string{"oauth", "v1", "access-tokens", url.PathEscape(token.AccessToken)}, "/")
+
+	rsp, err := c.Client.Get(ctx, c.BaseURL+"/"+relativeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := common.UnmarshalJSON[accessTokenInfoResponse](rsp)
+	if err != nil || info == nil {
+		return nil, err
+	}
+
+	return info.Scopes, nil
+}
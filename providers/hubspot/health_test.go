@@ -0,0 +1,129 @@
+package hubspot
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+)
+
+func TestCheckConnection(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	responseAccountDetails := `{"portalId": 62515, "timeZone": "US/Eastern", "uiDomain": "app.hubspot.com"}`
+
+	tests := []checkConnectionTestCase{
+		{
+			Name: "Daily limit is reported for private apps",
+			Server: mockserver.Conditional{
+				Setup: func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.Header().Set(headerRateLimitDaily, "250000")
+					w.Header().Set(headerRateLimitDailyRemaining, "249000")
+					w.Header().Set(headerRateLimitMax, "190")
+					w.Header().Set(headerRateLimitRemaining, "189")
+					w.Header().Set(headerRateLimitInterval, "10000")
+				},
+				If:   mockcond.PathSuffix("/account-info/v3/details"),
+				Then: mockserver.ResponseString(http.StatusOK, responseAccountDetails),
+			}.Server(),
+			Comparator: healthReportComparator,
+			Expected: &common.HealthReport{
+				Healthy: true,
+				Account: "62515",
+				Quota: &common.APIQuota{
+					Limit:     250000,
+					Remaining: 249000,
+					Window:    24 * time.Hour,
+				},
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Interval limit is reported for OAuth apps",
+			Server: mockserver.Conditional{
+				Setup: func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.Header().Set(headerRateLimitMax, "110")
+					w.Header().Set(headerRateLimitRemaining, "109")
+					w.Header().Set(headerRateLimitInterval, "10000")
+				},
+				If:   mockcond.PathSuffix("/account-info/v3/details"),
+				Then: mockserver.ResponseString(http.StatusOK, responseAccountDetails),
+			}.Server(),
+			Comparator: healthReportComparator,
+			Expected: &common.HealthReport{
+				Healthy: true,
+				Account: "62515",
+				Quota: &common.APIQuota{
+					Limit:     110,
+					Remaining: 109,
+					Window:    10 * time.Second,
+				},
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Rejected credentials are an invalid access token",
+			Server: mockserver.Fixed{
+				Setup: mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusUnauthorized, `{"status": "error",
+					"message": "Authentication credentials not found.", "category": "INVALID_AUTHENTICATION"}`),
+			}.Server(),
+			Comparator:   healthReportComparator,
+			Expected:     &common.HealthReport{},
+			ExpectedErrs: []error{common.ErrAccessToken},
+		},
+		{
+			Name: "Missing scope is forbidden",
+			Server: mockserver.Fixed{
+				Setup: mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusForbidden, `{"status": "error",
+					"message": "This app hasn't been granted all required scopes.", "category": "MISSING_SCOPES"}`),
+			}.Server(),
+			Comparator:   healthReportComparator,
+			Expected:     &common.HealthReport{},
+			ExpectedErrs: []error{common.ErrForbidden},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+// healthReportComparator ignores timing of the check.
+func healthReportComparator(serverURL string, actual, expected *common.HealthReport) bool {
+	return actual != nil &&
+		actual.Healthy == expected.Healthy &&
+		actual.Account == expected.Account &&
+		!actual.CheckedAt.IsZero() &&
+		reflect.DeepEqual(actual.Scopes, expected.Scopes) &&
+		reflect.DeepEqual(actual.Quota, expected.Quota)
+}
+
+type (
+	checkConnectionTestCaseType = testroutines.TestCase[struct{}, *common.HealthReport]
+	checkConnectionTestCase     checkConnectionTestCaseType
+)
+
+func (c checkConnectionTestCase) Run(t *testing.T, builder testroutines.ConnectorBuilder[*Connector]) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	output, err := conn.CheckConnection(context.Background())
+	checkConnectionTestCaseType(c).Validate(t, err, output)
+}
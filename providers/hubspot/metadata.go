@@ -78,9 +78,25 @@ type describeObjectResponse struct {
 	Results []describeObjectResult `json:"results"`
 }
 
+// See https://developers.hubspot.com/docs/api/crm/properties
 type describeObjectResult struct {
-	Name  string `json:"name"`
-	Label string `json:"label"`
+	Name                 string                `json:"name"`
+	Label                string                `json:"label"`
+	Type                 string                `json:"type"`
+	FieldType            string                `json:"fieldType"`
+	Options              []propertyOption      `json:"options"`
+	ModificationMetadata *modificationMetadata `json:"modificationMetadata"`
+	ReferencedObjectType string                `json:"referencedObjectType"`
+}
+
+type propertyOption struct {
+	Label  string `json:"label"`
+	Value  string `json:"value"`
+	Hidden bool   `json:"hidden"`
+}
+
+type modificationMetadata struct {
+	ReadOnlyValue bool `json:"readOnlyValue"`
 }
 
 // describeObject returns object metadata for the given object name.
@@ -109,22 +125,75 @@ func (c *Connector) describeObject(ctx context.Context, objectName string) (*com
 		return nil, fmt.Errorf("error unmarshalling object metadata response into JSON: %w", err)
 	}
 
-	return &common.ObjectMetadata{
-		DisplayName: objectName,
-		FieldsMap:   makeFieldsMap(resp),
-	}, nil
+	return makeObjectMetadata(objectName, resp), nil
 }
 
-// makeFieldsMap returns a map of field name to field label.
-func makeFieldsMap(data *describeObjectResponse) map[string]string {
-	fieldsMap := make(map[string]string)
+// makeObjectMetadata returns object metadata describing every property.
+func makeObjectMetadata(objectName string, data *describeObjectResponse) *common.ObjectMetadata {
+	metadata := &common.ObjectMetadata{
+		DisplayName: objectName,
+		FieldsMap:   make(map[string]string),
+		Fields:      make(map[string]common.FieldMetadata),
+	}
 
 	for _, field := range data.Results {
-		fieldName := strings.ToLower(field.Name)
+		metadata.AddField(strings.ToLower(field.Name), makeFieldMetadata(field))
+	}
+
+	return metadata
+}
+
+func makeFieldMetadata(field describeObjectResult) common.FieldMetadata {
+	metadata := common.FieldMetadata{
+		DisplayName:  field.Label,
+		ValueType:    propertyValueType(field),
+		ProviderType: field.Type,
+	}
+
+	if field.ModificationMetadata != nil {
+		// Properties cannot be writable on create while being read-only on update, or vice versa.
+		writable := !field.ModificationMetadata.ReadOnlyValue
+		metadata.Createable = &writable
+		metadata.Updateable = &writable
+	}
+
+	for _, option := range field.Options {
+		if !option.Hidden {
+			metadata.Values = append(metadata.Values, common.FieldValue{
+				Value:        option.Value,
+				DisplayValue: option.Label,
+			})
+		}
+	}
 
-		// Add entry to fieldsMap
-		fieldsMap[fieldName] = field.Label
+	if len(field.ReferencedObjectType) != 0 {
+		metadata.ReferenceTo = []string{field.ReferencedObjectType}
 	}
 
-	return fieldsMap
+	return metadata
+}
+
+// propertyValueType converts property type, refined by its field type, into a value type.
+// https://knowledge.hubspot.com/properties/property-field-types-in-hubspot
+func propertyValueType(field describeObjectResult) common.ValueType {
+	switch field.Type {
+	case "string", "phone_number":
+		return common.ValueTypeString
+	case "number":
+		return common.ValueTypeFloat
+	case "bool":
+		return common.ValueTypeBoolean
+	case "date":
+		return common.ValueTypeDate
+	case "datetime":
+		return common.ValueTypeDateTime
+	case "enumeration":
+		if field.FieldType == "checkbox" {
+			return common.ValueTypeMultiSelect
+		}
+
+		return common.ValueTypeSingleSelect
+	default:
+		return common.ValueTypeOther
+	}
 }
@@ -55,5 +55,5 @@ func WithModule(module common.ModuleID) Option {
 }
 
 func requiresFiltering(config common.ReadParams) bool {
-	return !config.Since.IsZero()
+	return !config.Since.IsZero() || config.FilterBy != nil
 }
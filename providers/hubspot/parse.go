@@ -1,6 +1,8 @@
 package hubspot
 
 import (
+	"strings"
+
 	"github.com/amp-labs/connectors/common"
 	"github.com/spyzhov/ajson"
 )
@@ -114,23 +116,66 @@ func getRecords(node *ajson.Node) ([]map[string]interface{}, error) {
 	return out, nil
 }
 
-// getMarshalledData accepts a list of records and returns a list of structured data ([]ReadResultRow).
-func getMarshalledData(records []map[string]interface{}, fields []string) ([]common.ReadResultRow, error) {
-	data := make([]common.ReadResultRow, len(records))
+// makeMarshalledDataFunc returns a function, which accepts a list of records
+// and returns a list of structured data ([]ReadResultRow).
+// Fields are looked up among record properties first, what is missing is selected from the whole record,
+// so that "firstname", "properties.firstname" and "$.associations.companies" are all understood.
+func makeMarshalledDataFunc(
+	preserveCase bool,
+) func(records []map[string]interface{}, fields []string) ([]common.ReadResultRow, error) {
+	return func(records []map[string]interface{}, fields []string) ([]common.ReadResultRow, error) {
+		data := make([]common.ReadResultRow, len(records))
+
+		for i, record := range records {
+			recordProperties, ok := record["properties"].(map[string]interface{})
+			if !ok {
+				return nil, ErrNotObject
+			}
+
+			values := common.ExtractFieldsFromRaw(fields, recordProperties, preserveCase)
+
+			for key, value := range common.ExtractFieldsFromRaw(fields, record, preserveCase) {
+				if _, found := values[key]; !found {
+					values[key] = value
+				}
+			}
+
+			data[i] = common.ReadResultRow{
+				Fields: values,
+				Raw:    record,
+			}
+		}
 
-	for i, record := range records {
-		recordProperties, ok := record["properties"].(map[string]interface{})
-		if !ok {
-			return nil, ErrNotObject
+		return data, nil
+	}
+}
+
+// propertyNames converts fields into HubSpot properties to be requested.
+// Selectors under "properties" are reduced to the property name,
+// other nested selectors refer to parts of the record which are returned anyway.
+func propertyNames(fields []string) []string {
+	names := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		selector, err := common.ParseFieldSelector(field)
+		if err != nil {
+			continue
 		}
 
-		data[i] = common.ReadResultRow{
-			Fields: common.ExtractLowercaseFieldsFromRaw(fields, recordProperties),
-			Raw:    record,
+		if !selector.IsNested() {
+			names = append(names, selector.Root())
+
+			continue
+		}
+
+		if strings.EqualFold(selector.Root(), "properties") {
+			if name := selector.Key(1); len(name) != 0 {
+				names = append(names, name)
+			}
 		}
 	}
 
-	return data, nil
+	return names
 }
 
 // GetLastResultId returns the last row's id from a result.
@@ -150,3 +195,14 @@ func GetLastResultId(result *common.ReadResult) string {
 
 	return lastRowId
 }
+
+// getLastRecordId returns the id of the last record, which is present regardless of the requested fields.
+func getLastRecordId(result *common.ReadResult) string {
+	if len(result.Data) == 0 {
+		return ""
+	}
+
+	id, _ := result.Data[len(result.Data)-1].Raw["id"].(string)
+
+	return id
+}
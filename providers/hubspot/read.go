@@ -8,10 +8,11 @@ import (
 	"github.com/amp-labs/connectors/common"
 )
 
-// Read reads data from Hubspot. If Since is set, it will use the
-// Search endpoint instead to filter records, but it will be
-// limited to a maximum of 10,000 records. This is a limit of the
-// search endpoint. If Since is not set, it will use the read endpoint.
+// Read reads data from Hubspot. If Since or FilterBy is set, it will use the
+// Search endpoint instead to filter records. Search is limited to 10,000 records,
+// past that limit the search is restarted after the last read record, which is
+// transparent to the caller. If Since is not set, it will use the read endpoint.
+// Records of AssociatedObjects are looked up using the v4 associations API.
 // In case Deleted objects won’t appear in any search results.
 // Deleted objects can only be read by using this endpoint.
 func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common.ReadResult, error) {
@@ -26,28 +27,36 @@ func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common
 
 	// If filtering is required, then we have to use the search endpoint.
 	// The Search endpoint has a 10K record limit. In case this limit is reached,
-	// the sorting allows the caller to continue in another call by offsetting
-	// until the ID of the last record that was successfully fetched.
+	// the sorting allows Search to continue by offsetting until the ID
+	// of the last record that was successfully fetched.
 	if requiresFiltering(config) {
+		filterGroups, err := makeReadFilterGroups(config)
+		if err != nil {
+			return nil, err
+		}
+
+		// Fail upfront rather than after reading 10K records.
+		if !canAddIdFilter(filterGroups) {
+			return nil, common.NewUnsupportedFilterError(config.FilterBy, errNoRoomForIdFilter.Error())
+		}
+
 		searchParams := SearchParams{
-			ObjectName: config.ObjectName,
-			FilterGroups: []FilterGroup{
-				{
-					Filters: []Filter{
-						BuildLastModifiedFilterGroup(&config),
-						// Add more filters to AND them together
-					},
-					// Add more filter groups to OR them together
-				},
-			},
+			ObjectName:   config.ObjectName,
+			FilterGroups: filterGroups,
 			SortBy: []SortBy{
 				BuildSort(ObjectFieldHsObjectId, SortDirectionAsc),
 			},
-			NextPage: config.NextPage,
-			Fields:   config.Fields,
+			NextPage:          config.NextPage,
+			Fields:            config.Fields,
+			PreserveFieldCase: config.PreserveFieldCase,
+		}
+
+		result, err := c.Search(ctx, searchParams)
+		if err != nil {
+			return nil, err
 		}
 
-		return c.Search(ctx, searchParams)
+		return c.withAssociations(ctx, config, result)
 	}
 
 	if len(config.NextPage) > 0 {
@@ -65,20 +74,54 @@ func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common
 		return nil, err
 	}
 
-	return common.ParseResult(
+	result, err := common.ParseResult(
 		rsp,
 		getRecords,
 		getNextRecordsURL,
-		getMarshalledData,
+		makeMarshalledDataFunc(config.PreserveFieldCase),
 		config.Fields,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.withAssociations(ctx, config, result)
+}
+
+// withAssociations attaches records of the associated objects to every row, if requested.
+func (c *Connector) withAssociations(
+	ctx context.Context, config common.ReadParams, result *common.ReadResult,
+) (*common.ReadResult, error) {
+	if len(config.AssociatedObjects) == 0 {
+		return result, nil
+	}
+
+	if err := c.fillAssociations(ctx, config.ObjectName, result, config.AssociatedObjects); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// makeReadFilterGroups combines Since and FilterBy of ReadParams into search filter groups.
+func makeReadFilterGroups(config common.ReadParams) ([]FilterGroup, error) {
+	var sinceFilters []Filter
+	if !config.Since.IsZero() {
+		sinceFilters = append(sinceFilters, BuildLastModifiedFilterGroup(&config))
+	}
+
+	if config.FilterBy == nil {
+		return []FilterGroup{{Filters: sinceFilters}}, nil
+	}
+
+	return makeFilterGroups(config.FilterBy, sinceFilters...)
 }
 
 // makeQueryValues returns the query for the desired read operation.
 func makeQueryValues(config common.ReadParams) string {
 	queryValues := url.Values{}
 
-	fields := config.Fields.List()
+	fields := propertyNames(config.Fields.List())
 	if len(fields) != 0 {
 		queryValues.Add("properties", strings.Join(fields, ","))
 	}
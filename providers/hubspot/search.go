@@ -2,15 +2,28 @@ package hubspot
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/amp-labs/connectors/common"
 )
 
+// searchResultsLimit is the number of records a single search query can page through.
+const searchResultsLimit = 10_000
+
+// rolloverTokenPrefix marks page tokens which carry searchCursor instead of a plain offset.
+const rolloverTokenPrefix = "rollover:"
+
 // Search uses the POST /search endpoint to filter object records and return the result.
 // This endpoint has a limit of 10,000 records. If the result has more than 10,000 records,
-// the caller should employ sorting to paginate through the result on the client side.
+// and records are sorted by hs_object_id in ascending order, the search is restarted
+// from the last returned record using hs_object_id GT filter. The state is kept in the NextPage token.
+// Other sort orders cannot be continued, the caller should employ sorting to paginate on the client side.
 // This endpoint paginates using paging.next.after which is to be used as an offset.
 // Archived results do not appear in search results.
 // Read more @ https://developers.hubspot.com/docs/api/crm/search
@@ -19,25 +32,154 @@ func (c *Connector) Search(ctx context.Context, config SearchParams) (*common.Re
 		return nil, err
 	}
 
-	var (
-		rsp *common.JSONHTTPResponse
-		err error
-	)
+	cursor, err := parseSearchCursor(config.NextPage)
+	if err != nil {
+		return nil, err
+	}
+
+	// The search query is sent starting from the cursor.
+	config.NextPage = common.NextPageToken(cursor.After)
+
+	if len(cursor.AfterID) != 0 {
+		config.FilterGroups, err = addIdFilter(config.FilterGroups, cursor.AfterID)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	relativeURL := strings.Join([]string{"objects", config.ObjectName, "search"}, "/")
 
-	rsp, err = c.Client.Post(ctx, c.getURL(relativeURL), makeFilterBody(config))
+	rsp, err := c.Client.Post(ctx, c.getURL(relativeURL), makeFilterBody(config))
 	if err != nil {
 		return nil, err
 	}
 
-	return common.ParseResult(
+	result, err := common.ParseResult(
 		rsp,
 		getRecords,
 		getNextRecordsAfter,
-		getMarshalledData,
+		makeMarshalledDataFunc(config.PreserveFieldCase),
 		config.Fields,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !result.Done {
+		result.NextPage = nextSearchCursor(config, cursor, result).token()
+	}
+
+	return result, nil
+}
+
+// searchCursor is the state of paging through search results.
+type searchCursor struct {
+	// After is the offset within the current search query.
+	After string `json:"after,omitempty"`
+	// AfterID is set once the search limit was reached,
+	// the query is then restricted to records with greater hs_object_id.
+	AfterID string `json:"afterId,omitempty"`
+}
+
+func parseSearchCursor(token common.NextPageToken) (searchCursor, error) {
+	encoded, rolledOver := strings.CutPrefix(token.String(), rolloverTokenPrefix)
+	if !rolledOver {
+		// Plain offset, the search limit was not reached yet.
+		return searchCursor{After: token.String()}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return searchCursor{}, errors.Join(ErrInvalidNextPage, err)
+	}
+
+	var cursor searchCursor
+	if err = json.Unmarshal(data, &cursor); err != nil {
+		return searchCursor{}, errors.Join(ErrInvalidNextPage, err)
+	}
+
+	return cursor, nil
+}
+
+func (c searchCursor) token() common.NextPageToken {
+	if len(c.AfterID) == 0 {
+		return common.NextPageToken(c.After)
+	}
+
+	data, _ := json.Marshal(c) // nolint:errchkjson
+
+	return common.NextPageToken(rolloverTokenPrefix + base64.RawURLEncoding.EncodeToString(data))
+}
+
+// nextSearchCursor returns the cursor of the following page.
+// Once the offset reaches the search limit, the query is restarted after the last record.
+func nextSearchCursor(config SearchParams, current searchCursor, result *common.ReadResult) searchCursor {
+	next := searchCursor{
+		After:   result.NextPage.String(),
+		AfterID: current.AfterID,
+	}
+
+	offset, err := strconv.Atoi(next.After)
+	if err != nil || offset < searchResultsLimit || !isSortedById(config.SortBy) {
+		return next
+	}
+
+	lastID := getLastRecordId(result)
+	if len(lastID) == 0 {
+		return next
+	}
+
+	return searchCursor{AfterID: lastID}
+}
+
+// isSortedById reports whether records are ordered the way rollover requires.
+func isSortedById(sorts []SortBy) bool {
+	return len(sorts) != 0 &&
+		sorts[0].PropertyName == string(ObjectFieldHsObjectId) &&
+		sorts[0].Direction == SortDirectionAsc
+}
+
+// addIdFilter restricts every filter group to records after the given id.
+// Filter groups are copied, input is not modified.
+func addIdFilter(groups []FilterGroup, id string) ([]FilterGroup, error) {
+	if !canAddIdFilter(groups) {
+		return nil, fmt.Errorf("%w: %v", common.ErrOperationNotSupportedForObject, errNoRoomForIdFilter)
+	}
+
+	idFilter := BuildIdFilterGroup(id)
+
+	if len(groups) == 0 {
+		return []FilterGroup{{Filters: []Filter{idFilter}}}, nil
+	}
+
+	output := make([]FilterGroup, len(groups))
+	for index, group := range groups {
+		output[index] = FilterGroup{
+			Filters: append(append([]Filter{}, group.Filters...), idFilter),
+		}
+	}
+
+	return output, nil
+}
+
+// errNoRoomForIdFilter explains why search cannot continue past the search limit.
+var errNoRoomForIdFilter = fmt.Errorf( // nolint:gochecknoglobals,goerr113
+	"search filters leave no room for hs_object_id filter, which is needed to read past %v records",
+	searchResultsLimit)
+
+// canAddIdFilter reports whether search limits allow one more filter in every group.
+func canAddIdFilter(groups []FilterGroup) bool {
+	total := 0
+
+	for _, group := range groups {
+		if len(group.Filters) >= maxFiltersPerGroup {
+			return false
+		}
+
+		total += len(group.Filters) + 1
+	}
+
+	return total <= maxFiltersAcrossGroups
 }
 
 // BuildLastModifiedFilterGroup filters records modified since the given time.
@@ -99,7 +241,7 @@ func makeFilterBody(config SearchParams) map[string]any {
 	}
 
 	if config.Fields != nil {
-		filterBody["properties"] = config.Fields.List()
+		filterBody["properties"] = propertyNames(config.Fields.List())
 	}
 
 	return filterBody
@@ -0,0 +1,254 @@
+package hubspot
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/handy"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestSearch(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	sortedById := []SortBy{BuildSort(ObjectFieldHsObjectId, SortDirectionAsc)}
+	rolloverToken := searchCursor{AfterID: "9999"}.token()
+
+	tests := []searchTestCase{
+		{
+			Name: "Offset is returned as next page",
+			Input: SearchParams{
+				ObjectName: "contacts",
+				Fields:     handy.NewSet("email"),
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.PathSuffix("/crm/v3/objects/contacts/search"),
+					mockcond.Body(`{"limit": "100", "properties": ["email"]}`),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{"total": 20000,
+					"results": [{"id": "1", "properties": {"email": "bob@acme.com"}}],
+					"paging": {"next": {"after": "100"}}}`),
+			}.Server(),
+			Comparator: searchResultComparator,
+			Expected:   &common.ReadResult{Rows: 1, NextPage: "100"},
+		},
+		{
+			Name: "Search limit rolls over to records after the last id",
+			Input: SearchParams{
+				ObjectName: "contacts",
+				NextPage:   "9900",
+				SortBy:     sortedById,
+				Fields:     handy.NewSet("email"),
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.Body(`{"limit": "100", "after": "9900", "properties": ["email"],
+					"sorts": [{"propertyName": "hs_object_id", "direction": "ASCENDING"}]}`),
+				Then: mockserver.ResponseString(http.StatusOK, `{"total": 20000,
+					"results": [{"id": "9998", "properties": {}}, {"id": "9999", "properties": {}}],
+					"paging": {"next": {"after": "10000"}}}`),
+			}.Server(),
+			Comparator: searchResultComparator,
+			Expected:   &common.ReadResult{Rows: 2, NextPage: rolloverToken},
+		},
+		{
+			Name: "Rolled over search filters by id and restarts offset",
+			Input: SearchParams{
+				ObjectName: "contacts",
+				NextPage:   rolloverToken,
+				SortBy:     sortedById,
+				Fields:     handy.NewSet("email"),
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.Body(`{"limit": "100", "properties": ["email"],
+					"sorts": [{"propertyName": "hs_object_id", "direction": "ASCENDING"}],
+					"filterGroups": [{"filters": [{"propertyName": "hs_object_id", "operator": "GT", "value": "9999"}]}]}`),
+				Then: mockserver.ResponseString(http.StatusOK, `{"total": 10000,
+					"results": [{"id": "10000", "properties": {}}],
+					"paging": {"next": {"after": "1"}}}`),
+			}.Server(),
+			Comparator: searchResultComparator,
+			Expected: &common.ReadResult{
+				Rows:     1,
+				NextPage: searchCursor{After: "1", AfterID: "9999"}.token(),
+			},
+		},
+		{
+			Name: "Search limit is not rolled over for other sort orders",
+			Input: SearchParams{
+				ObjectName: "contacts",
+				NextPage:   "9900",
+				SortBy:     []SortBy{BuildSort(ObjectFieldHsLastModifiedDate, SortDirectionAsc)},
+				Fields:     handy.NewSet("email"),
+			},
+			Server: mockserver.Fixed{
+				Setup: mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusOK, `{"total": 20000,
+					"results": [{"id": "9999", "properties": {}}],
+					"paging": {"next": {"after": "10000"}}}`),
+			}.Server(),
+			Comparator: searchResultComparator,
+			Expected:   &common.ReadResult{Rows: 1, NextPage: "10000"},
+		},
+		{
+			Name: "Malformed rollover token is rejected",
+			Input: SearchParams{
+				ObjectName: "contacts",
+				NextPage:   rolloverTokenPrefix + "!!!",
+				Fields:     handy.NewSet("email"),
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{ErrInvalidNextPage},
+		},
+		{
+			Name: "Rollover fails when filters have no room for id filter",
+			Input: SearchParams{
+				ObjectName: "contacts",
+				NextPage:   rolloverToken,
+				FilterGroups: []FilterGroup{{Filters: []Filter{
+					{FieldName: "a"}, {FieldName: "b"}, {FieldName: "c"},
+					{FieldName: "d"}, {FieldName: "e"}, {FieldName: "f"},
+				}}},
+				Fields: handy.NewSet("email"),
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestAddIdFilter(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	idFilter := BuildIdFilterGroup("42")
+	nameFilter := Filter{FieldName: "name", Operator: FilterOperatorTypeEQ, Value: "Acme"}
+	fullGroup := FilterGroup{Filters: []Filter{nameFilter, nameFilter, nameFilter, nameFilter, nameFilter, nameFilter}}
+
+	tests := []struct {
+		name         string
+		input        []FilterGroup
+		expected     []FilterGroup
+		expectedErrs []error
+	}{
+		{
+			name:     "No filters",
+			input:    nil,
+			expected: []FilterGroup{{Filters: []Filter{idFilter}}},
+		},
+		{
+			name: "Every group is restricted",
+			input: []FilterGroup{
+				{Filters: []Filter{nameFilter}},
+				{Filters: []Filter{nameFilter, nameFilter}},
+			},
+			expected: []FilterGroup{
+				{Filters: []Filter{nameFilter, idFilter}},
+				{Filters: []Filter{nameFilter, nameFilter, idFilter}},
+			},
+		},
+		{
+			name:         "Full group has no room",
+			input:        []FilterGroup{fullGroup},
+			expectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			name: "Total number of filters is limited",
+			input: []FilterGroup{
+				{Filters: fullGroup.Filters[:4]},
+				{Filters: fullGroup.Filters[:4]},
+				{Filters: fullGroup.Filters[:4]},
+				{Filters: fullGroup.Filters[:4]},
+			},
+			expectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			original := cloneFilterGroups(tt.input)
+
+			output, err := addIdFilter(tt.input, "42")
+			testutils.CheckErrors(t, tt.name, tt.expectedErrs, err)
+
+			if !reflect.DeepEqual(output, tt.expected) {
+				t.Fatalf("%s: expected: (%v), got: (%v)", tt.name, tt.expected, output)
+			}
+
+			if !reflect.DeepEqual(tt.input, original) {
+				t.Fatalf("%s: input was modified: (%v)", tt.name, tt.input)
+			}
+		})
+	}
+}
+
+func cloneFilterGroups(groups []FilterGroup) []FilterGroup {
+	if groups == nil {
+		return nil
+	}
+
+	output := make([]FilterGroup, len(groups))
+	for index, group := range groups {
+		output[index] = FilterGroup{Filters: append([]Filter{}, group.Filters...)}
+	}
+
+	return output
+}
+
+// searchResultComparator checks paging, records are covered by read tests.
+func searchResultComparator(serverURL string, actual, expected *common.ReadResult) bool {
+	return actual.Rows == expected.Rows &&
+		actual.NextPage == expected.NextPage &&
+		actual.Done == expected.Done
+}
+
+type (
+	searchTestCaseType = testroutines.TestCase[SearchParams, *common.ReadResult]
+	searchTestCase     searchTestCaseType
+)
+
+func (c searchTestCase) Run(t *testing.T, builder testroutines.ConnectorBuilder[*Connector]) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	output, err := conn.Search(context.Background(), c.Input)
+	searchTestCaseType(c).Validate(t, err, output)
+}
+
+func constructTestConnector(serverURL string) (*Connector, error) {
+	connector, err := NewConnector(
+		WithAuthenticatedClient(http.DefaultClient),
+		WithModule(ModuleCRM),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// for testing we want to redirect calls to our mock server
+	connector.setBaseURL(serverURL)
+
+	return connector, nil
+}
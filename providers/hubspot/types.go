@@ -15,7 +15,10 @@ type SearchParams struct {
 	// FilterBy is the filter to apply to the search
 	FilterGroups []FilterGroup // optional
 	// Fields is the list of fields to return in the result.
+	// Dotted or JSONPath fields select nested values of the record, e.g. "properties.firstname".
 	Fields handy.Set[string] // optional
+	// PreserveFieldCase keeps keys of ReadResultRow.Fields as they were requested, rather than lowercased.
+	PreserveFieldCase bool // optional
 }
 
 func (p SearchParams) ValidateParams() error {
@@ -45,6 +48,8 @@ type Filter struct {
 	FieldName string             `json:"propertyName,omitempty"`
 	Operator  FilterOperatorType `json:"operator,omitempty"`
 	Value     string             `json:"value,omitempty"`
+	// Values are used by IN and NIN operators.
+	Values []string `json:"values,omitempty"`
 }
 
 type (
@@ -2,9 +2,18 @@ package hubspot
 
 import (
 	"strings"
+
+	"github.com/amp-labs/connectors/common"
 )
 
 // getURL is a helper to return the full URL considering the base URL & module.
 func (c *Connector) getURL(arg string) string {
 	return strings.Join([]string{c.BaseURL, c.Module.Path(), arg}, "/")
 }
+
+// getAssociationsURL returns the URL of the associations API, which has its own version within the CRM module.
+func (c *Connector) getAssociationsURL(arg string) string {
+	module := common.Module{Label: c.Module.Label, Version: associationsVersion}
+
+	return strings.Join([]string{c.BaseURL, module.Path(), arg}, "/")
+}
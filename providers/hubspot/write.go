@@ -18,28 +18,44 @@ type writeResponse struct {
 	UpdatedAt             string         `json:"updatedAt"`
 }
 
+// Write creates or updates a record.
+// Upsert matches the record by a unique property named by ExternalIdField, e.g. "email".
+// It is done via batch upsert, as the single record API has no such operation.
 func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*common.WriteResult, error) {
 	if err := config.ValidateParams(); err != nil {
 		return nil, err
 	}
 
-	var write common.WriteMethod
+	if config.ResolveMode() == common.WriteModeUpsert {
+		return c.upsert(ctx, config)
+	}
+
+	write, err := common.WriteMethods{
+		common.WriteModeCreate: c.Client.Post,
+		common.WriteModeUpdate: c.Client.Patch,
+	}.Select(config)
+	if err != nil {
+		return nil, err
+	}
 
 	relativeURL := strings.Join([]string{"objects", config.ObjectName}, "/")
 	url := c.getURL(relativeURL)
 
 	if config.RecordId != "" {
-		write = c.Client.Patch
 		url = fmt.Sprintf("%s/%s", url, config.RecordId)
-	} else {
-		write = c.Client.Post
+	}
+
+	// HubSpot clears a property when it is set to an empty string.
+	properties, err := config.RecordDataWithClearedFields("")
+	if err != nil {
+		return nil, err
 	}
 
 	// Hubspot requires everything to be wrapped in a "properties" object.
 	// We do this automatically in the write method so that the user doesn't
 	// have to worry about it.
 	data := make(map[string]interface{})
-	data["properties"] = config.RecordData
+	data["properties"] = properties
 
 	json, err := write(ctx, url, data)
 	if err != nil {
@@ -57,3 +73,24 @@ func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*comm
 		Data:     rsp.Properties,
 	}, nil
 }
+
+func (c *Connector) upsert(ctx context.Context, config common.WriteParams) (*common.WriteResult, error) {
+	properties, err := config.RecordDataWithClearedFields("")
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := c.BatchUpsert(ctx, BatchWriteParams{
+		ObjectName: config.ObjectName,
+		Records: []BatchRecord{{
+			RecordId:   config.RecordId,
+			RecordData: properties,
+		}},
+		IdProperty: config.ExternalIdField,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &results[0], nil
+}
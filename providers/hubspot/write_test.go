@@ -0,0 +1,111 @@
+package hubspot
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+)
+
+func TestWrite(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	upsertContact := common.WriteParams{
+		ObjectName:      "contacts",
+		RecordId:        "bob@acme.com",
+		RecordData:      map[string]any{"firstname": "Bob"},
+		Mode:            common.WriteModeUpsert,
+		ExternalIdField: "email",
+	}
+
+	tests := []testroutines.Write{
+		{
+			Name: "Upsert requires external id field",
+			Input: common.WriteParams{
+				ObjectName: "contacts",
+				RecordId:   "1",
+				RecordData: map[string]any{},
+				Mode:       common.WriteModeUpsert,
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingExternalIdField},
+		},
+		{
+			Name:  "Upsert reports created record",
+			Input: upsertContact,
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPOST(),
+					mockcond.PathSuffix("/crm/v3/objects/contacts/batch/upsert"),
+					mockcond.Body(`{"inputs": [{"id": "bob@acme.com", "idProperty": "email",
+						"objectWriteTraceId": "0", "properties": {"firstname": "Bob"}}]}`),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{"status": "COMPLETE", "results": [
+					{"id": "501", "new": true, "objectWriteTraceId": "0",
+						"properties": {"email": "bob@acme.com", "firstname": "Bob"}}]}`),
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success:  true,
+				RecordId: "501",
+				Data:     map[string]any{"email": "bob@acme.com", "firstname": "Bob"},
+				Outcome:  common.WriteOutcomeCreated,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Upsert reports updated record",
+			Input: upsertContact,
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.PathSuffix("/crm/v3/objects/contacts/batch/upsert"),
+				Then: mockserver.ResponseString(http.StatusOK, `{"status": "COMPLETE", "results": [
+					{"id": "501", "new": false, "properties": {"email": "bob@acme.com"}}]}`),
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success:  true,
+				RecordId: "501",
+				Data:     map[string]any{"email": "bob@acme.com"},
+				Outcome:  common.WriteOutcomeUpdated,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Upsert of invalid record is not successful",
+			Input: upsertContact,
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.PathSuffix("/crm/v3/objects/contacts/batch/upsert"),
+				Then: mockserver.ResponseString(http.StatusMultiStatus, `{"status": "COMPLETE", "results": [],
+					"errors": [{"status": "error", "category": "VALIDATION_ERROR",
+						"message": "Property values were not valid", "context": {"objectWriteTraceId": ["0"]}}]}`),
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success: false,
+				Errors: []any{HubspotError{
+					Status:   "error",
+					Category: "VALIDATION_ERROR",
+					Message:  "Property values were not valid",
+					Context:  ErrContext{ObjectWriteTraceId: []string{"0"}},
+				}},
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.WriteConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
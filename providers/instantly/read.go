@@ -13,6 +13,14 @@ func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common
 		return nil, err
 	}
 
+	if err := config.ValidateNoAssociations(); err != nil {
+		return nil, err
+	}
+
+	if err := config.ValidateNoFilter(); err != nil {
+		return nil, err
+	}
+
 	if !supportedObjectsByRead.Has(config.ObjectName) {
 		return nil, common.ErrOperationNotSupportedForObject
 	}
@@ -36,7 +44,7 @@ func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common
 		rsp,
 		common.GetRecordsUnderJSONPath(nodePath),
 		makeNextRecordsURL(url),
-		common.GetMarshaledData,
+		common.MakeMarshaledDataFunc(config.PreserveFieldCase),
 		config.Fields,
 	)
 }
@@ -32,18 +32,26 @@ func (c *Connector) Write(
 		return nil, err
 	}
 
-	var write common.WriteMethod
-	if len(config.RecordId) == 0 {
-		write = c.Client.Post
+	write, err := common.WriteMethods{
+		common.WriteModeCreate: c.Client.Post,
+		common.WriteModeUpdate: c.Client.Patch,
+	}.Select(config)
+	if err != nil {
+		return nil, err
+	}
 
-		constructURLPathCreate(config, url) // nolint:wsl
+	if len(config.RecordId) == 0 {
+		constructURLPathCreate(config, url)
 	} else {
-		write = c.Client.Patch
-
 		constructURLPathUpdate(config, url)
 	}
 
-	res, err := write(ctx, url.String(), config.RecordData)
+	recordData, err := config.RecordDataWithClearedFields(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := write(ctx, url.String(), recordData)
 	if err != nil {
 		return nil, err
 	}
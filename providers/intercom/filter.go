@@ -0,0 +1,165 @@
+package intercom
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// Search query limits.
+// https://developers.intercom.com/docs/references/rest-api/api.intercom.io/contacts/searchcontacts
+const (
+	maxSearchQueryDepth      = 3
+	maxSearchFiltersPerGroup = 15
+)
+
+// Search operators keyed by filter operator.
+var searchOperators = map[common.FilterOperator]string{ // nolint:gochecknoglobals
+	common.FilterOperatorEq:       "=",
+	common.FilterOperatorNe:       "!=",
+	common.FilterOperatorGt:       ">",
+	common.FilterOperatorLt:       "<",
+	common.FilterOperatorIn:       "IN",
+	common.FilterOperatorContains: "~",
+}
+
+// makeSearchQuery returns a query matching records updated since the given time and satisfying the filter.
+// Conditions are AND-ed together at the top level.
+func makeSearchQuery(params common.ReadParams) (*searchQuery, error) {
+	conditions := make([]any, 0)
+
+	if !params.Since.IsZero() {
+		// Unix time format is used.
+		conditions = append(conditions, searchQueryValue{
+			Field:    "updated_at",
+			Operator: ">",
+			Value:    strconv.FormatInt(params.Since.Unix(), 10),
+		})
+	}
+
+	if params.FilterBy != nil {
+		condition, err := makeSearchCondition(params.FilterBy)
+		if err != nil {
+			return nil, err
+		}
+
+		if nested, ok := condition.(searchQuery); ok && nested.Operator == "AND" {
+			// Flatten to save on nesting levels.
+			conditions = append(conditions, nested.Value...)
+		} else {
+			conditions = append(conditions, condition)
+		}
+	}
+
+	query := &searchQuery{
+		Operator: "AND",
+		Value:    conditions,
+	}
+
+	if depth := searchQueryDepth(*query); depth > maxSearchQueryDepth {
+		return nil, common.NewUnsupportedFilterError(params.FilterBy,
+			fmt.Sprintf("query is nested %v levels deep, limit is %v", depth, maxSearchQueryDepth))
+	}
+
+	if len(query.Value) > maxSearchFiltersPerGroup {
+		return nil, errTooManySearchFilters(params.FilterBy)
+	}
+
+	return query, nil
+}
+
+// makeSearchCondition converts filter expression into either searchQuery or searchQueryValue.
+func makeSearchCondition(expr *common.FilterExpr) (any, error) {
+	if expr.IsLogical() {
+		if len(expr.Operands) > maxSearchFiltersPerGroup {
+			return nil, errTooManySearchFilters(expr)
+		}
+
+		values := make([]any, len(expr.Operands))
+
+		for index, operand := range expr.Operands {
+			value, err := makeSearchCondition(operand)
+			if err != nil {
+				return nil, err
+			}
+
+			values[index] = value
+		}
+
+		operator := "AND"
+		if expr.Operator == common.FilterOperatorOr {
+			operator = "OR"
+		}
+
+		return searchQuery{
+			Operator: operator,
+			Value:    values,
+		}, nil
+	}
+
+	operator, ok := searchOperators[expr.Operator]
+	if !ok {
+		return nil, common.NewUnsupportedFilterError(expr, "operator is not supported")
+	}
+
+	if expr.Operator == common.FilterOperatorIn {
+		values := make([]any, len(expr.Values))
+
+		for index, value := range expr.Values {
+			formatted, err := formatSearchValue(expr, value)
+			if err != nil {
+				return nil, err
+			}
+
+			values[index] = formatted
+		}
+
+		return searchQueryValue{
+			Field:    expr.Field,
+			Operator: operator,
+			Value:    values,
+		}, nil
+	}
+
+	value, err := formatSearchValue(expr, expr.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return searchQueryValue{
+		Field:    expr.Field,
+		Operator: operator,
+		Value:    value,
+	}, nil
+}
+
+func formatSearchValue(expr *common.FilterExpr, value any) (any, error) {
+	switch val := value.(type) {
+	case nil, string, bool, int, int64, float64:
+		return val, nil
+	case time.Time:
+		// Timestamps are in Unix time format.
+		return strconv.FormatInt(val.Unix(), 10), nil
+	default:
+		return nil, common.NewUnsupportedFilterError(expr, fmt.Sprintf("value of type %T is not supported", value))
+	}
+}
+
+func searchQueryDepth(query searchQuery) int {
+	depth := 0
+
+	for _, value := range query.Value {
+		if nested, ok := value.(searchQuery); ok {
+			depth = max(depth, searchQueryDepth(nested))
+		}
+	}
+
+	return depth + 1
+}
+
+func errTooManySearchFilters(expr *common.FilterExpr) error {
+	return common.NewUnsupportedFilterError(expr,
+		fmt.Sprintf("search group allows at most %v filters", maxSearchFiltersPerGroup))
+}
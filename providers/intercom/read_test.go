@@ -32,6 +32,7 @@ func TestRead(t *testing.T) { //nolint:funlen,gocognit,cyclop,maintidx
 	responseContactsThirdPage := testutils.DataFromFile(t, "read-contacts-3-last-page.json")
 	responseReadConversations := testutils.DataFromFile(t, "read-conversations.json")
 	requestSearchConversations := testutils.DataFromFile(t, "read-search-conversations-request.json")
+	requestFilterConversations := testutils.DataFromFile(t, "read-search-conversations-filter-request.json")
 	responseSearchConversations := testutils.DataFromFile(t, "read-search-conversations.json")
 	responseNotesFirstPage := testutils.DataFromFile(t, "read-notes-1-first-page.json")
 	responseNotesSecondPage := testutils.DataFromFile(t, "read-notes-2-last-page.json")
@@ -295,6 +296,43 @@ func TestRead(t *testing.T) { //nolint:funlen,gocognit,cyclop,maintidx
 			},
 			ExpectedErrs: nil,
 		},
+		{
+			Name: "Filter is merged into conversations search query",
+			Input: common.ReadParams{
+				ObjectName: "conversations",
+				Fields:     connectors.Fields("id"),
+				Since:      time.Unix(1726674883, 0),
+				FilterBy: common.FilterAnd(
+					common.FilterIn("state", "open", "snoozed"),
+					common.FilterOr(
+						common.FilterContains("title", "return"),
+						common.FilterEq("priority", "priority"),
+					),
+				),
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.BodyBytes(requestFilterConversations),
+				Then:  mockserver.Response(http.StatusOK, responseSearchConversations),
+			}.Server(),
+			Comparator: func(baseURL string, actual, expected *common.ReadResult) bool {
+				return actual.Rows == expected.Rows
+			},
+			Expected: &common.ReadResult{
+				Rows: 1,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Objects without search endpoint cannot be filtered",
+			Input: common.ReadParams{
+				ObjectName: "admins",
+				Fields:     connectors.Fields("id"),
+				FilterBy:   common.FilterEq("name", "Jane"),
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
 	}
 
 	for _, tt := range tests {
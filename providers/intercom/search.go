@@ -2,13 +2,12 @@ package intercom
 
 import (
 	"context"
-	"strconv"
 
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/common/urlbuilder"
 )
 
-// Search is used when `Since` parameter is provided for the select few objects.
+// Search is used when `Since` or `FilterBy` parameter is provided for the select few objects.
 // Documentation below explains how search is done for "conversations" object. Others, use the same query language.
 // https://developers.intercom.com/docs/references/rest-api/api.intercom.io/conversations/searchconversations
 // https://developers.intercom.com/docs/references/rest-api/api.intercom.io/contacts/searchcontacts
@@ -16,10 +15,15 @@ func (c *Connector) readViaSearch(
 	ctx context.Context, config common.ReadParams,
 ) (*common.JSONHTTPResponse, *urlbuilder.URL, error, bool) {
 	if !incrementalSearchObjectPagination.Has(config.ObjectName) {
+		if config.FilterBy != nil {
+			// Only search endpoints understand filters.
+			return nil, nil, common.NewUnsupportedFilterError(config.FilterBy, "object cannot be searched"), true
+		}
+
 		return nil, nil, nil, false
 	}
 
-	if config.Since.IsZero() && config.ObjectName != ticketsObjectName {
+	if config.Since.IsZero() && config.FilterBy == nil && config.ObjectName != ticketsObjectName {
 		// Search is only relevant when we do incremental reading.
 		// Tickets is an exception. We can do full read only via POST.
 		return nil, nil, nil, false
@@ -44,17 +48,17 @@ func (c *Connector) readViaSearch(
 }
 
 func (c *Connector) createSearchPayload(params common.ReadParams) (*searchReqPayload, error) {
-	if params.Since.IsZero() && params.ObjectName == ticketsObjectName {
+	if params.Since.IsZero() && params.FilterBy == nil && params.ObjectName == ticketsObjectName {
 		// Perform full read for tickets using POST query.
 		// This is a hack, query is designed to return all objects.
 		return &searchReqPayload{
 			Query: searchQuery{
 				Operator: "OR",
-				Value: []searchQueryValue{{
+				Value: []any{searchQueryValue{
 					Field:    "open",
 					Operator: "=",
 					Value:    "true",
-				}, {
+				}, searchQueryValue{
 					Field:    "open",
 					Operator: "=",
 					Value:    "false",
@@ -71,20 +75,16 @@ func (c *Connector) createSearchPayload(params common.ReadParams) (*searchReqPay
 		return nil, err
 	}
 
-	// Unix time format is used.
-	updatedAfter := strconv.FormatInt(params.Since.Unix(), 10)
+	query, err := makeSearchQuery(params)
+	if err != nil {
+		return nil, err
+	}
+
 	// We no longer request by GET, so query parameter must be moved to the POST payload.
 	startingAfter, _ := url.GetFirstQueryParam("starting_after")
 
 	conversation := searchReqPayload{
-		Query: searchQuery{
-			Operator: "AND",
-			Value: []searchQueryValue{{
-				Field:    "updated_at",
-				Operator: ">",
-				Value:    updatedAfter,
-			}},
-		},
+		Query: *query,
 		Pagination: searchPagination{
 			PerPage:       incrementalSearchObjectPagination.Get(params.ObjectName),
 			StartingAfter: startingAfter,
@@ -99,15 +99,16 @@ type searchReqPayload struct {
 	Pagination searchPagination `json:"pagination"`
 }
 
+// searchQuery combines its values, which are either searchQueryValue or nested searchQuery.
 type searchQuery struct {
-	Operator string             `json:"operator"`
-	Value    []searchQueryValue `json:"value"`
+	Operator string `json:"operator"`
+	Value    []any  `json:"value"`
 }
 
 type searchQueryValue struct {
 	Field    string `json:"field"`
 	Operator string `json:"operator"`
-	Value    string `json:"value"`
+	Value    any    `json:"value"`
 }
 
 type searchPagination struct {
@@ -41,4 +41,12 @@ func init() {
 			Write:     true,
 		},
 	})
+
+	// Marketo allows 100 calls per 20 seconds, 10 concurrent calls and 50,000 calls per day.
+	SetRateLimit(Marketo, RateLimitOpts{
+		RequestsPerSecond:  5,
+		Burst:              10,
+		DailyQuota:         50000,
+		ConcurrentRequests: 10,
+	})
 }
@@ -19,7 +19,21 @@ func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*comm
 		return nil, err
 	}
 
-	json, err := c.Client.Post(ctx, url.String(), config.RecordData)
+	// Leads are created and updated by the same request.
+	write, err := common.WriteMethods{
+		common.WriteModeCreate: c.Client.Post,
+		common.WriteModeUpdate: c.Client.Post,
+	}.Select(config)
+	if err != nil {
+		return nil, err
+	}
+
+	recordData, err := config.RecordDataWithClearedFields(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	json, err := write(ctx, url.String(), recordData)
 	if err != nil {
 		return nil, err
 	}
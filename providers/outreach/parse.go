@@ -36,6 +36,9 @@ func getRecords(node *ajson.Node) ([]map[string]any, error) {
 	return records, nil
 }
 
+// constructRecords flattens attributes next to the record id.
+// Attributes and relationships are also kept nested, for fields such as "attributes.email"
+// or "relationships.account.data.id".
 func constructRecords(d Data) []map[string]any {
 	records := make([]map[string]any, len(d.Data))
 
@@ -47,6 +50,11 @@ func constructRecords(d Data) []map[string]any {
 			recordItems[k] = v
 		}
 
+		recordItems[attributesKey] = record.Attributes
+		if record.Relationships != nil {
+			recordItems[relationshipsKey] = record.Relationships
+		}
+
 		records[i] = recordItems
 	}
 
@@ -31,21 +31,28 @@ func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*comm
 		return nil, err
 	}
 
-	var write common.WriteMethod
-
 	url, err := c.getApiURL(config.ObjectName)
 	if err != nil {
 		return nil, err
 	}
 
+	write, err := common.WriteMethods{
+		common.WriteModeCreate: c.Client.Post,
+		common.WriteModeUpdate: c.Client.Patch,
+	}.Select(config)
+	if err != nil {
+		return nil, err
+	}
+
 	// prepares the updating data request.
 	if len(config.RecordId) > 0 {
 		url.AddPath(config.RecordId)
+	}
 
-		write = c.Client.Patch
-	} else {
-		// prepares the creating data request.
-		write = c.Client.Post
+	// Cleared fields are nulled among attributes.
+	config.RecordData, err = config.RecordDataWithClearedFields(nil)
+	if err != nil {
+		return nil, err
 	}
 
 	req, err := constructWriteRequest(config)
@@ -24,7 +24,7 @@ func init() {
 			},
 			Proxy:     true,
 			Read:      false,
-			Subscribe: false,
+			Subscribe: true,
 			Write:     false,
 		},
 		Media: &Media{
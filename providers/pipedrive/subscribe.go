@@ -0,0 +1,187 @@
+package pipedrive
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/naming"
+)
+
+const webhooksObjectName = "webhooks"
+
+// eventActions maps change types to actions of Pipedrive webhooks.
+var eventActions = map[common.ChangeType]string{ // nolint:gochecknoglobals
+	common.ChangeTypeCreated: "added",
+	common.ChangeTypeUpdated: "updated",
+	common.ChangeTypeDeleted: "deleted",
+}
+
+type webhookPayload struct {
+	SubscriptionURL  string `json:"subscription_url"`
+	EventAction      string `json:"event_action"`
+	EventObject      string `json:"event_object"`
+	HTTPAuthUser     string `json:"http_auth_user,omitempty"`
+	HTTPAuthPassword string `json:"http_auth_password,omitempty"`
+	Version          string `json:"version"`
+}
+
+type webhookResponse struct {
+	Data    map[string]any `json:"data"`
+	Success bool           `json:"success"`
+}
+
+type webhooksResponse struct {
+	Data    []map[string]any `json:"data"`
+	Success bool             `json:"success"`
+}
+
+// Subscribe creates webhooks for changes of the object. A webhook has a single action,
+// so one webhook is created per change type, unless all of them are requested.
+// Username and password of the params protect the webhook, see webhook.NewPipedriveHandler.
+// https://developers.pipedrive.com/docs/api/v1/Webhooks#addWebhook
+func (c *Connector) Subscribe(ctx context.Context, params common.SubscribeParams) ([]common.Subscription, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	actions := []string{"*"}
+
+	if len(params.Changes) != 0 && len(params.Changes) != len(common.AllChangeTypes) {
+		actions = make([]string, len(params.Changes))
+		for i, change := range params.Changes {
+			actions[i] = eventActions[change]
+		}
+	}
+
+	url, err := c.getAPIURL(webhooksObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]common.Subscription, 0, len(actions))
+
+	for _, action := range actions {
+		res, err := c.Client.Post(ctx, url.String(), webhookPayload{
+			SubscriptionURL:  params.TargetURL,
+			EventAction:      action,
+			EventObject:      naming.NewSingularString(params.ObjectName).String(),
+			HTTPAuthUser:     params.Username,
+			HTTPAuthPassword: params.Password,
+			Version:          "1.0",
+		})
+		if err != nil {
+			// Webhooks created so far are returned, so that the caller can delete them.
+			return subscriptions, err
+		}
+
+		response, err := common.UnmarshalJSON[webhookResponse](res)
+		if err != nil {
+			return subscriptions, err
+		}
+
+		subscriptions = append(subscriptions, toSubscription(response.Data))
+	}
+
+	return subscriptions, nil
+}
+
+// ListSubscriptions returns all webhooks of the company.
+// https://developers.pipedrive.com/docs/api/v1/Webhooks#getWebhooks
+func (c *Connector) ListSubscriptions(ctx context.Context) ([]common.Subscription, error) {
+	url, err := c.getAPIURL(webhooksObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.Client.Get(ctx, url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := common.UnmarshalJSON[webhooksResponse](res)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]common.Subscription, len(response.Data))
+	for i, raw := range response.Data {
+		subscriptions[i] = toSubscription(raw)
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteSubscription deletes the webhook.
+// https://developers.pipedrive.com/docs/api/v1/Webhooks#deleteWebhook
+func (c *Connector) DeleteSubscription(ctx context.Context, id string) error {
+	if len(id) == 0 {
+		return common.ErrMissingSubscriptionID
+	}
+
+	url, err := c.getAPIURL(webhooksObjectName)
+	if err != nil {
+		return err
+	}
+
+	url.AddPath(id)
+
+	_, err = c.Client.Delete(ctx, url.String())
+
+	return err
+}
+
+func toSubscription(raw map[string]any) common.Subscription {
+	subscription := common.Subscription{
+		ID:        formatID(raw["id"]),
+		TargetURL: fmt.Sprint(raw["subscription_url"]),
+		Active:    isActive(raw["is_active"]),
+		Raw:       raw,
+	}
+
+	if object := fmt.Sprint(raw["event_object"]); object != "*" {
+		subscription.ObjectName = objectName(object)
+	}
+
+	switch action := fmt.Sprint(raw["event_action"]); action {
+	case "*":
+		subscription.Changes = slices.Clone(common.AllChangeTypes)
+	case "merged":
+		subscription.Changes = []common.ChangeType{common.ChangeTypeUpdated}
+	default:
+		for change, name := range eventActions {
+			if name == action {
+				subscription.Changes = []common.ChangeType{change}
+			}
+		}
+	}
+
+	return subscription
+}
+
+// objectName converts the object of a webhook into the name used by the API, "person" is read from "persons".
+func objectName(object string) string {
+	if object == "person" {
+		return "persons"
+	}
+
+	return naming.NewPluralString(object).String()
+}
+
+// isActive understands both 1 and true, as older webhooks report activity as a number.
+func isActive(value any) bool {
+	text := fmt.Sprint(value)
+
+	return text == "1" || text == "true"
+}
+
+// formatID prints numeric ids without exponent.
+func formatID(value any) string {
+	if number, ok := value.(float64); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+
+	return fmt.Sprint(value)
+}
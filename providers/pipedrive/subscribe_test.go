@@ -0,0 +1,141 @@
+package pipedrive
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+)
+
+func TestSubscribe(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []subscribeTestCase{
+		{
+			Name:         "Object must be included",
+			Input:        common.SubscribeParams{TargetURL: "https://example.com/hooks"},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingObjects},
+		},
+		{
+			Name: "Unknown change type",
+			Input: common.SubscribeParams{
+				ObjectName: "deals", TargetURL: "https://example.com/hooks",
+				Changes: []common.ChangeType{"merged"},
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrUnknownChangeType},
+		},
+		{
+			Name:  "All changes share one webhook",
+			Input: common.SubscribeParams{ObjectName: "persons", TargetURL: "https://example.com/hooks"},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPOST(),
+					mockcond.PathSuffix("/v1/webhooks"),
+					mockcond.Body(`{"subscription_url":"https://example.com/hooks",
+						"event_action":"*","event_object":"person","version":"1.0"}`),
+				},
+				Then: mockserver.ResponseString(http.StatusCreated, `{"status": "ok", "success": true, "data": {
+					"id": 1234567, "event_action": "*", "event_object": "person",
+					"subscription_url": "https://example.com/hooks", "is_active": 1
+				}}`),
+			}.Server(),
+			Comparator: subscriptionsComparator,
+			Expected: []common.Subscription{{
+				ID:         "1234567",
+				ObjectName: "persons",
+				Changes:    []common.ChangeType{common.ChangeTypeCreated, common.ChangeTypeUpdated, common.ChangeTypeDeleted},
+				TargetURL:  "https://example.com/hooks",
+				Active:     true,
+			}},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Webhook per change with basic auth",
+			Input: common.SubscribeParams{
+				ObjectName: "deals",
+				Changes:    []common.ChangeType{common.ChangeTypeCreated, common.ChangeTypeDeleted},
+				TargetURL:  "https://example.com/hooks",
+				Username:   "amp",
+				Password:   "s3cr3t",
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If: mockcond.Body(`{"subscription_url":"https://example.com/hooks","event_action":"added",
+						"event_object":"deal","http_auth_user":"amp","http_auth_password":"s3cr3t","version":"1.0"}`),
+					Then: mockserver.ResponseString(http.StatusCreated, `{"success": true, "data": {
+						"id": 1, "event_action": "added", "event_object": "deal",
+						"subscription_url": "https://example.com/hooks", "is_active": true
+					}}`),
+				}, {
+					If: mockcond.Body(`{"subscription_url":"https://example.com/hooks","event_action":"deleted",
+						"event_object":"deal","http_auth_user":"amp","http_auth_password":"s3cr3t","version":"1.0"}`),
+					Then: mockserver.ResponseString(http.StatusCreated, `{"success": true, "data": {
+						"id": 2, "event_action": "deleted", "event_object": "deal",
+						"subscription_url": "https://example.com/hooks", "is_active": true
+					}}`),
+				}},
+			}.Server(),
+			Comparator: subscriptionsComparator,
+			Expected: []common.Subscription{{
+				ID:         "1",
+				ObjectName: "deals",
+				Changes:    []common.ChangeType{common.ChangeTypeCreated},
+				TargetURL:  "https://example.com/hooks",
+				Active:     true,
+			}, {
+				ID:         "2",
+				ObjectName: "deals",
+				Changes:    []common.ChangeType{common.ChangeTypeDeleted},
+				TargetURL:  "https://example.com/hooks",
+				Active:     true,
+			}},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+// subscriptionsComparator ignores raw responses of the provider.
+func subscriptionsComparator(_ string, actual, expected []common.Subscription) bool {
+	if len(actual) != len(expected) {
+		return false
+	}
+
+	for i := range actual {
+		actual[i].Raw = nil
+	}
+
+	return reflect.DeepEqual(actual, expected)
+}
+
+type (
+	subscribeTestCaseType = testroutines.TestCase[common.SubscribeParams, []common.Subscription]
+	subscribeTestCase     subscribeTestCaseType
+)
+
+func (c subscribeTestCase) Run(t *testing.T, builder testroutines.ConnectorBuilder[*Connector]) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	output, err := conn.Subscribe(context.Background(), c.Input)
+	subscribeTestCaseType(c).Validate(t, err, output)
+}
@@ -20,22 +20,30 @@ func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*comm
 		return nil, err
 	}
 
-	var write common.WriteMethod
-
 	url, err := c.getAPIURL(config.ObjectName)
 	if err != nil {
 		return nil, err
 	}
 
+	// Pipedrive PUT changes only the given fields.
+	write, err := common.WriteMethods{
+		common.WriteModeCreate: c.Client.Post,
+		common.WriteModeUpdate: c.Client.Put,
+	}.Select(config)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(config.RecordId) != 0 {
 		url.AddPath(config.RecordId)
+	}
 
-		write = c.Client.Put
-	} else {
-		write = c.Client.Post
+	recordData, err := config.RecordDataWithClearedFields(nil)
+	if err != nil {
+		return nil, err
 	}
 
-	resp, err := write(ctx, url.String(), config.RecordData)
+	resp, err := write(ctx, url.String(), recordData)
 	if err != nil {
 		return nil, err
 	}
@@ -18,19 +18,27 @@ func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*comm
 		return nil, err
 	}
 
-	var write common.WriteMethod
-	if len(config.RecordId) == 0 {
+	write, err := common.WriteMethods{
 		// writing to the entity without id means
 		// that we are extending 'List' resource and creating a new record
-		write = c.Client.Post
-	} else {
-		// only put is supported for updating 'Single' resource
-		write = c.Client.Patch
+		common.WriteModeCreate: c.Client.Post,
+		// patch changes the given fields of 'Single' resource
+		common.WriteModeUpdate: c.Client.Patch,
+	}.Select(config)
+	if err != nil {
+		return nil, err
+	}
 
+	if len(config.RecordId) != 0 {
 		url.AddPath(config.RecordId)
 	}
 
-	res, err := write(ctx, url.String(), config.RecordData)
+	recordData, err := config.RecordDataWithClearedFields(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := write(ctx, url.String(), recordData)
 	if err != nil {
 		return nil, err
 	}
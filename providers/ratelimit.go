@@ -0,0 +1,45 @@
+package providers
+
+import "sync"
+
+// RateLimitOpts Client side rate limits that keep requests within the provider's quotas.
+// They are kept beside the catalog, which is generated from the OpenAPI spec and has no place for them.
+type RateLimitOpts struct {
+	// Burst Number of requests that can be sent at once before the sustained rate applies. Defaults to 1.
+	Burst int `json:"burst,omitempty"`
+
+	// ConcurrentRequests Maximum number of requests in flight at the same time. Zero means no limit.
+	ConcurrentRequests int `json:"concurrentRequests,omitempty"`
+
+	// DailyQuota Maximum number of requests per day. Zero means no limit.
+	DailyQuota int `json:"dailyQuota,omitempty"`
+
+	// RequestsPerSecond Sustained rate of requests. Zero means no limit.
+	RequestsPerSecond float64 `json:"requestsPerSecond,omitempty"`
+}
+
+var (
+	rateLimits      = make(map[Provider]RateLimitOpts) // nolint:gochecknoglobals
+	rateLimitsMutex sync.RWMutex                       // nolint:gochecknoglobals
+)
+
+// SetRateLimit sets the rate limits of the provider. It is meant to be called from init, next to SetInfo.
+func SetRateLimit(provider Provider, opts RateLimitOpts) {
+	rateLimitsMutex.Lock()
+	defer rateLimitsMutex.Unlock()
+
+	rateLimits[provider] = opts
+}
+
+// ReadRateLimit returns the rate limits of the provider, or nil if it has none.
+func ReadRateLimit(provider Provider) *RateLimitOpts {
+	rateLimitsMutex.RLock()
+	defer rateLimitsMutex.RUnlock()
+
+	opts, ok := rateLimits[provider]
+	if !ok {
+		return nil
+	}
+
+	return &opts
+}
@@ -22,8 +22,8 @@ func init() {
 		},
 		Support: Support{
 			BulkWrite: BulkWriteSupport{
-				Insert: false,
-				Update: false,
+				Insert: true,
+				Update: true,
 				Upsert: true,
 				Delete: true,
 			},
@@ -10,6 +10,7 @@ import (
 // https://developer.salesforce.com/docs/atlas.en-us.api_asynch.meta/api_asynch/create_job.htm
 //
 // After creation inspect newly launched Bulk Job via:
+// * WaitForJob
 // * GetJobInfo
 // * GetJobResults
 // * GetSuccessfulJobResults.
@@ -111,6 +111,7 @@ func (c *Connector) GetBulkQueryInfo(ctx context.Context, jobId string) (*GetJob
 }
 
 // GetBulkQueryResults returns completed data from bulk query.
+// The response is raw CSV, use ReadBulkQueryResults to get parsed rows page by page.
 // https://developer.salesforce.com/docs/atlas.en-us.api_asynch.meta/api_asynch/query_get_job_results.htm
 func (c *Connector) GetBulkQueryResults(ctx context.Context, jobId string) (*http.Response, error) {
 	location, err := c.getRestApiURL("jobs/query/", jobId, "/results")
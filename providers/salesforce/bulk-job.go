@@ -0,0 +1,191 @@
+package salesforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+const sfCreatedFieldName = "sf__Created"
+
+// Ingest job operations keyed by provider-neutral bulk mode.
+// https://developer.salesforce.com/docs/atlas.en-us.api_asynch.meta/api_asynch/create_job.htm
+var bulkWriteOperations = map[common.BulkWriteMode]string{ // nolint:gochecknoglobals
+	common.BulkWriteModeInsert: "insert",
+	common.BulkWriteModeUpdate: "update",
+	common.BulkWriteModeUpsert: string(UpsertMode),
+	common.BulkWriteModeDelete: string(DeleteMode),
+}
+
+// bulkNullValue is how Ingest Job CSV clears a field, an empty value leaves the field unchanged.
+// https://developer.salesforce.com/docs/atlas.en-us.api_asynch.meta/api_asynch/datafiles_csv_valid_record_rows.htm
+const bulkNullValue = "#N/A"
+
+// StartBulkWrite launches an Ingest Job for any write mode. JSON lines are converted to CSV before the upload,
+// nested objects become relationship columns, e.g. "Account.external_id__c".
+// Null values clear the field, while missing values leave it unchanged.
+// Update and delete records must have the "Id" column.
+// Poll the job via GetBulkWriteStatus, then read records via GetBulkWriteResults.
+func (c *Connector) StartBulkWrite(ctx context.Context, params common.BulkWriteParams) (*common.BulkJob, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	csvData := params.Data
+
+	if params.Format == common.BulkDataFormatJSONLines {
+		data, err := common.JSONLinesToCSV(params.Data, bulkNullValue)
+		if err != nil {
+			return nil, err
+		}
+
+		csvData = bytes.NewReader(data)
+	}
+
+	body := map[string]any{
+		"object":      params.ObjectName,
+		"operation":   bulkWriteOperations[params.Mode],
+		"contentType": "CSV",
+		"lineEnding":  "LF",
+	}
+
+	if params.Mode == common.BulkWriteModeUpsert {
+		body["externalIdFieldName"] = params.ExternalIdField
+	}
+
+	result, err := c.bulkOperation(ctx, BulkOperationParams{
+		ObjectName: params.ObjectName,
+		CSVData:    csvData,
+	}, body)
+	if err != nil {
+		return nil, fmt.Errorf("bulk write failed: %w", err)
+	}
+
+	return &common.BulkJob{
+		JobId: result.JobId,
+		State: bulkJobState(result.State),
+	}, nil
+}
+
+// GetBulkWriteStatus reports the progress of an Ingest Job.
+func (c *Connector) GetBulkWriteStatus(ctx context.Context, jobId string) (*common.BulkJobStatus, error) {
+	info, err := c.GetJobInfo(ctx, jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.BulkJobStatus{
+		JobId:            info.Id,
+		State:            bulkJobState(info.State),
+		RecordsProcessed: int64(info.NumberRecordsProcessed),
+		RecordsFailed:    int64(info.NumberRecordsFailed),
+		Message:          info.ErrorMessage,
+		ProviderState:    info.State,
+	}, nil
+}
+
+// GetBulkWriteResults returns successful records followed by failed ones.
+// Record fields are returned as strings, the way they were uploaded.
+// https://developer.salesforce.com/docs/atlas.en-us.api_asynch.meta/api_asynch/get_job_successful_results.htm
+// https://developer.salesforce.com/docs/atlas.en-us.api_asynch.meta/api_asynch/get_job_failed_results.htm
+func (c *Connector) GetBulkWriteResults(ctx context.Context, jobId string) (*common.BulkWriteResults, error) {
+	succeeded, err := c.GetSuccessfulJobResults(ctx, jobId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job results: %w", err)
+	}
+
+	records, err := parseBulkRecordResults(succeeded, true)
+	if err != nil {
+		return nil, err
+	}
+
+	failed, err := c.getFailedJobResults(ctx, jobId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job results: %w", err)
+	}
+
+	failures, err := parseBulkRecordResults(failed, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.BulkWriteResults{
+		JobId:   jobId,
+		Records: append(records, failures...),
+	}, nil
+}
+
+func bulkJobState(state string) common.BulkJobState {
+	switch state {
+	case JobStateComplete:
+		return common.BulkJobStateComplete
+	case JobStateFailed:
+		return common.BulkJobStateFailed
+	case JobStateAborted:
+		return common.BulkJobStateAborted
+	default:
+		return common.BulkJobStateInProgress
+	}
+}
+
+// parseBulkRecordResults converts CSV of successful or failed results into write results.
+// Besides record fields, each row has sf__Id and either sf__Created or sf__Error column.
+func parseBulkRecordResults(res *http.Response, success bool) ([]common.WriteResult, error) {
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Join(ErrReadToByteFailed, err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, common.InterpretError(res, body)
+	}
+
+	rows, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	if err != nil {
+		return nil, errors.Join(common.ErrParseError, err)
+	}
+
+	if len(rows) == 0 {
+		return []common.WriteResult{}, nil
+	}
+
+	header := rows[0]
+	results := make([]common.WriteResult, 0, len(rows)-1)
+
+	for _, row := range rows[1:] {
+		result := common.WriteResult{
+			Success: success,
+			Data:    make(map[string]any),
+		}
+
+		for index, column := range header {
+			if index >= len(row) {
+				break
+			}
+
+			switch column {
+			case sfIdFieldName:
+				result.RecordId = row[index]
+			case sfErrorFieldName:
+				result.Errors = []any{row[index]}
+			case sfCreatedFieldName:
+				// Tells whether upsert has inserted the record, not a record field.
+				result.Outcome = common.NewWriteOutcome(row[index] == "true")
+			default:
+				result.Data[column] = row[index]
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,195 @@
+package salesforce
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestStartBulkWrite(t *testing.T) { //nolint:funlen
+	t.Parallel()
+
+	responseCreateJob := testutils.DataFromFile(t, "bulk/write/launch-job-opportunity.json")
+	responseUpdateJob := testutils.DataFromFile(t, "bulk/write/update-job-opportunity.json")
+
+	tests := []startBulkWriteTestCase{
+		{
+			Name: "Upsert requires External ID",
+			Input: common.BulkWriteParams{
+				ObjectName: "Opportunity",
+				Mode:       common.BulkWriteModeUpsert,
+				Data:       strings.NewReader(""),
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingExternalIdField},
+		},
+		{
+			Name: "Unknown mode is rejected",
+			Input: common.BulkWriteParams{
+				ObjectName: "Opportunity",
+				Mode:       "merge",
+				Data:       strings.NewReader(""),
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrUnsupportedBulkMode},
+		},
+		{
+			Name: "JSON lines are uploaded as CSV for insert",
+			Input: common.BulkWriteParams{
+				ObjectName: "Opportunity",
+				Mode:       common.BulkWriteModeInsert,
+				Format:     common.BulkDataFormatJSONLines,
+				Data: strings.NewReader(`{"Name":"Acme deal","Amount":1200,"Account":{"external_id__c":"A-1"}}
+{"Name":"Globex deal","StageName":"Closed Won","Amount":null}`),
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If: mockcond.And{
+						mockcond.PathSuffix("/services/data/v59.0/jobs/ingest"),
+						mockcond.Body(`{"contentType":"CSV","lineEnding":"LF","object":"Opportunity","operation":"insert"}`),
+					},
+					Then: mockserver.Response(http.StatusOK, responseCreateJob),
+				}, {
+					If: mockcond.And{
+						mockcond.PathSuffix("/services/data/v59.0/jobs/ingest/750ak000009BWKLAA4/batches"),
+						mockcond.Body("Account.external_id__c,Amount,Name,StageName\n" +
+							"A-1,1200,Acme deal,\n" +
+							",#N/A,Globex deal,Closed Won\n"),
+					},
+					Then: mockserver.Response(http.StatusCreated, []byte{}),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodPATCH(),
+						mockcond.PathSuffix("/services/data/v59.0/jobs/ingest/750ak000009BWKLAA4"),
+					},
+					Then: mockserver.Response(http.StatusOK, responseUpdateJob),
+				}},
+			}.Server(),
+			Expected: &common.BulkJob{
+				JobId: "750ak000009BWKLAA4",
+				State: common.BulkJobStateInProgress,
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests { // nolint:dupl
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestGetBulkWriteResults(t *testing.T) {
+	t.Parallel()
+
+	responseSuccess := testutils.DataFromFile(t, "bulk/write/successful-results.csv")
+	responseFailure := testutils.DataFromFile(t, "bulk/info/partial-failure.csv")
+
+	tests := []bulkWriteResultsTestCase{
+		{
+			Name:  "Successful and failed records are combined",
+			Input: "750ak000009BWKLAA4",
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If:   mockcond.PathSuffix("/jobs/ingest/750ak000009BWKLAA4/successfulResults"),
+					Then: mockserver.Response(http.StatusOK, responseSuccess),
+				}, {
+					If:   mockcond.PathSuffix("/jobs/ingest/750ak000009BWKLAA4/failedResults"),
+					Then: mockserver.Response(http.StatusOK, responseFailure),
+				}},
+			}.Server(),
+			Expected: &common.BulkWriteResults{
+				JobId: "750ak000009BWKLAA4",
+				Records: []common.WriteResult{{
+					Success:  true,
+					RecordId: "006ak000004hTFkAAM",
+					Data: map[string]any{
+						"Name":           "Acme deal",
+						"external_id__c": "external-id-1",
+					},
+					Outcome: common.WriteOutcomeCreated,
+				}, {
+					Success:  true,
+					RecordId: "006ak000004hTFlAAM",
+					Data: map[string]any{
+						"Name":           "Globex deal",
+						"external_id__c": "external-id-2",
+					},
+					Outcome: common.WriteOutcomeUpdated,
+				}, {
+					Success: false,
+					Errors: []any{"INVALID_FIELD:Failed to deserialize field at col 3. " +
+						"Due to, '2003-04-987654321987654321' is not a valid value for the type xsd:date:CloseDate --"},
+					Data: map[string]any{
+						"Name":           "Noemi Miller",
+						"StageName":      "GENERATED",
+						"external_id__c": "external-id-3",
+						"CloseDate":      "2003-04-987654321987654321",
+					},
+				}},
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Unknown job",
+			Input: "750ak000009BWKLAA4",
+			Server: mockserver.Fixed{
+				Setup:  mockserver.ContentJSON(),
+				Always: mockserver.Response(http.StatusNotFound, []byte(`[{"errorCode":"NOT_FOUND"}]`)),
+			}.Server(),
+			ExpectedErrs: []error{common.ErrRetryable},
+		},
+	}
+
+	for _, tt := range tests { // nolint:dupl
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+type (
+	startBulkWriteTestCaseType = testroutines.TestCase[common.BulkWriteParams, *common.BulkJob]
+	startBulkWriteTestCase     startBulkWriteTestCaseType
+)
+
+func (c startBulkWriteTestCase) Run(t *testing.T, builder testroutines.ConnectorBuilder[*Connector]) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	output, err := conn.StartBulkWrite(context.Background(), c.Input)
+	startBulkWriteTestCaseType(c).Validate(t, err, output)
+}
+
+type (
+	bulkWriteResultsTestCaseType = testroutines.TestCase[string, *common.BulkWriteResults]
+	bulkWriteResultsTestCase     bulkWriteResultsTestCaseType
+)
+
+func (c bulkWriteResultsTestCase) Run(t *testing.T, builder testroutines.ConnectorBuilder[*Connector]) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	output, err := conn.GetBulkWriteResults(context.Background(), c.Input)
+	bulkWriteResultsTestCaseType(c).Validate(t, err, output)
+}
@@ -16,7 +16,9 @@ import (
 // https://developer.salesforce.com/docs/atlas.en-us.250.0.soql_sosl.meta/soql_sosl/sforce_api_calls_soql_select.htm
 //
 // After creation inspect newly launched Bulk Job via:
+// * WaitForQuery
 // * GetBulkQueryInfo
+// * ReadBulkQueryResults
 // * GetBulkQueryResults.
 func (c *Connector) BulkQuery(
 	ctx context.Context,
@@ -21,10 +21,17 @@ func (c *Connector) BulkRead(ctx context.Context, params common.ReadParams) (*Ge
 		return nil, err
 	}
 
-	soql := makeSOQL(params)
+	soql, err := makeSOQL(params)
+	if err != nil {
+		return nil, err
+	}
+
 	// Note: if params.Deleted is set to true query will return only removed items.
 
-	query := soql.String()
+	query, err := soql.Build()
+	if err != nil {
+		return nil, err
+	}
 
 	return c.BulkQuery(ctx, query, params.Deleted)
 }
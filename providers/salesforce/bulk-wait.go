@@ -0,0 +1,233 @@
+package salesforce
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+const (
+	defaultWaitInitialInterval = time.Second
+	defaultWaitMaxInterval     = 30 * time.Second
+	defaultWaitMultiplier      = 1.5
+
+	// Salesforce sets locator header to this value when there are no more results.
+	bulkQueryLastLocator = "null"
+)
+
+var ErrJobWaitTimeout = errors.New("timed out waiting for bulk job to complete")
+
+// WaitParams controls how WaitForJob and WaitForQuery poll the job state.
+// Zero values are replaced with defaults.
+type WaitParams struct {
+	// InitialInterval is the delay before the first status check.
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between status checks.
+	MaxInterval time.Duration
+	// Multiplier is applied to the delay after every status check.
+	Multiplier float64
+	// Timeout limits the total time spent waiting. Zero means wait until the context is done.
+	Timeout time.Duration
+	// OnProgress is called after every status check. Optional.
+	OnProgress func(JobProgress)
+}
+
+// JobProgress is a snapshot of a Bulk job reported while waiting for it to complete.
+type JobProgress struct {
+	JobId            string
+	State            string
+	RecordsProcessed int64
+	RecordsFailed    int64
+}
+
+func (p WaitParams) withDefaults() WaitParams {
+	if p.InitialInterval <= 0 {
+		p.InitialInterval = defaultWaitInitialInterval
+	}
+
+	if p.MaxInterval <= 0 {
+		p.MaxInterval = defaultWaitMaxInterval
+	}
+
+	if p.Multiplier < 1 {
+		p.Multiplier = defaultWaitMultiplier
+	}
+
+	return p
+}
+
+// WaitForJob polls an Ingest Job, created via BulkWrite or BulkDelete, until it reaches a terminal state.
+// Use GetJobResults afterward to learn which records have failed.
+func (c *Connector) WaitForJob(ctx context.Context, jobId string, params WaitParams) (*GetJobInfoResult, error) {
+	return waitForJob(ctx, jobId, params, c.GetJobInfo)
+}
+
+// WaitForQuery polls a Query Job, created via BulkQuery or BulkRead, until it reaches a terminal state.
+// Use ReadBulkQueryResults afterward to read the records.
+func (c *Connector) WaitForQuery(ctx context.Context, jobId string, params WaitParams) (*GetJobInfoResult, error) {
+	return waitForJob(ctx, jobId, params, c.GetBulkQueryInfo)
+}
+
+func waitForJob(
+	ctx context.Context, jobId string, params WaitParams,
+	getInfo func(ctx context.Context, jobId string) (*GetJobInfoResult, error),
+) (*GetJobInfoResult, error) {
+	params = params.withDefaults()
+
+	if params.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeoutCause(ctx, params.Timeout, ErrJobWaitTimeout)
+		defer cancel()
+	}
+
+	interval := params.InitialInterval
+	timer := time.NewTimer(interval)
+
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		case <-timer.C:
+		}
+
+		info, err := getInfo(ctx, jobId)
+		if err != nil {
+			return nil, err
+		}
+
+		if params.OnProgress != nil {
+			params.OnProgress(JobProgress{
+				JobId:            info.Id,
+				State:            info.State,
+				RecordsProcessed: int64(info.NumberRecordsProcessed),
+				RecordsFailed:    int64(info.NumberRecordsFailed),
+			})
+		}
+
+		if info.IsStatusDone() {
+			return info, nil
+		}
+
+		interval = min(time.Duration(float64(interval)*params.Multiplier), params.MaxInterval)
+		timer.Reset(interval)
+	}
+}
+
+// ReadBulkQueryResults returns one page of records produced by a completed Query Job.
+// Start with empty NextPage, then pass ReadResult.NextPage to get the following page until Done.
+// Page size is decided by Salesforce unless maxRecords is positive.
+// Values in rows are strings, as they are read from CSV.
+// https://developer.salesforce.com/docs/atlas.en-us.api_asynch.meta/api_asynch/query_get_job_results.htm
+func (c *Connector) ReadBulkQueryResults(
+	ctx context.Context, jobId string, nextPage common.NextPageToken, maxRecords int,
+) (*common.ReadResult, error) {
+	location, err := c.getRestApiURL("jobs/query", jobId, "results")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nextPage) != 0 {
+		location.WithQueryParam("locator", nextPage.String())
+	}
+
+	if maxRecords > 0 {
+		location.WithQueryParam("maxRecords", strconv.Itoa(maxRecords))
+	}
+
+	rsp, body, err := c.Client.HTTPClient.Get(ctx, location.String(), common.Header{ // nolint:bodyclose
+		Key:   "Accept",
+		Value: "text/csv",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get results for bulk query %s: %w", jobId, err)
+	}
+
+	data, err := parseCSVRecords(body)
+	if err != nil {
+		return nil, errors.Join(common.ErrParseError, err)
+	}
+
+	locator := rsp.Header.Get("Sforce-Locator")
+	if locator == bulkQueryLastLocator {
+		locator = ""
+	}
+
+	return &common.ReadResult{
+		Rows:     int64(len(data)),
+		Data:     data,
+		NextPage: common.NextPageToken(locator),
+		Done:     len(locator) == 0,
+	}, nil
+}
+
+// StreamBulkQueryResults iterates over all pages of records produced by a completed Query Job.
+// Iteration stops after the first error.
+func (c *Connector) StreamBulkQueryResults(
+	ctx context.Context, jobId string, maxRecords int,
+) iter.Seq2[*common.ReadResult, error] {
+	return func(yield func(*common.ReadResult, error) bool) {
+		var nextPage common.NextPageToken
+
+		for {
+			result, err := c.ReadBulkQueryResults(ctx, jobId, nextPage, maxRecords)
+			if err != nil {
+				yield(nil, err)
+
+				return
+			}
+
+			if !yield(result, nil) || result.Done {
+				return
+			}
+
+			nextPage = result.NextPage
+		}
+	}
+}
+
+// parseCSVRecords converts CSV, where the first line is a header, into rows.
+func parseCSVRecords(body []byte) ([]common.ReadResultRow, error) {
+	reader := csv.NewReader(strings.NewReader(string(body)))
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return []common.ReadResultRow{}, nil
+		}
+
+		return nil, err
+	}
+
+	records := make([]map[string]any, 0)
+
+	for {
+		line, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, err
+		}
+
+		record := make(map[string]any, len(header))
+		for index, column := range header {
+			record[column] = line[index]
+		}
+
+		records = append(records, record)
+	}
+
+	return common.GetMarshaledData(records, header)
+}
@@ -0,0 +1,155 @@
+package salesforce
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestWaitForJob(t *testing.T) {
+	t.Parallel()
+
+	responseInProgress := testutils.DataFromFile(t, "bulk/info/in-progress.json")
+	responseSuccess := testutils.DataFromFile(t, "bulk/info/success.json")
+
+	var checks atomic.Int32
+
+	// Job is reported in progress twice before completing.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if checks.Add(1) <= 2 {
+			_, _ = w.Write(responseInProgress)
+
+			return
+		}
+
+		_, _ = w.Write(responseSuccess)
+	}))
+	defer server.Close()
+
+	conn, err := constructTestConnector(server.URL)
+	if err != nil {
+		t.Fatalf("failed to construct connector: %v", err)
+	}
+
+	var states []string
+
+	info, err := conn.WaitForJob(context.Background(), "750ak000009Bq9OAAS", WaitParams{
+		InitialInterval: time.Millisecond,
+		OnProgress: func(progress JobProgress) {
+			states = append(states, progress.State)
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !info.IsStatusDone() {
+		t.Fatalf("expected job to be done, got state (%v)", info.State)
+	}
+
+	expectedStates := []string{JobStateInProgress, JobStateInProgress, JobStateComplete}
+	if !reflect.DeepEqual(states, expectedStates) {
+		t.Fatalf("expected progress (%v), got (%v)", expectedStates, states)
+	}
+}
+
+func TestWaitForQueryTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := mockserver.Fixed{
+		Setup:  mockserver.ContentJSON(),
+		Always: mockserver.Response(http.StatusOK, testutils.DataFromFile(t, "bulk/info/in-progress.json")),
+	}.Server()
+	defer server.Close()
+
+	conn, err := constructTestConnector(server.URL)
+	if err != nil {
+		t.Fatalf("failed to construct connector: %v", err)
+	}
+
+	_, err = conn.WaitForQuery(context.Background(), "750ak000009Bq9OAAS", WaitParams{
+		InitialInterval: time.Millisecond,
+		Timeout:         20 * time.Millisecond,
+	})
+	if !errors.Is(err, ErrJobWaitTimeout) {
+		t.Fatalf("expected timeout error, got (%v)", err)
+	}
+}
+
+func TestStreamBulkQueryResults(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	respondCSV := func(locator, data string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Sforce-Locator", locator)
+			mockserver.ResponseString(http.StatusOK, data)(w, r)
+		}
+	}
+
+	server := mockserver.Switch{
+		Cases: []mockserver.Case{{
+			If: mockcond.And{
+				mockcond.PathSuffix("/services/data/v59.0/jobs/query/750ak000009AVi5AAG/results"),
+				mockcond.QueryParam("locator", "MTAwMDA"),
+			},
+			Then: respondCSV("null", "Id,Name\n001ak00000OKNPHAA5,\"Acme, Inc\"\n"),
+		}, {
+			If: mockcond.And{
+				mockcond.PathSuffix("/services/data/v59.0/jobs/query/750ak000009AVi5AAG/results"),
+				mockcond.QueryParam("maxRecords", "1"),
+				mockcond.QueryParamsMissing("locator"),
+			},
+			Then: respondCSV("MTAwMDA", "Id,Name\n001ak00000OKNPGAA5,Edge\n"),
+		}},
+	}.Server()
+	defer server.Close()
+
+	conn, err := constructTestConnector(server.URL)
+	if err != nil {
+		t.Fatalf("failed to construct connector: %v", err)
+	}
+
+	var pages []*common.ReadResult
+
+	for page, err := range conn.StreamBulkQueryResults(context.Background(), "750ak000009AVi5AAG", 1) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		pages = append(pages, page)
+	}
+
+	expected := []*common.ReadResult{{
+		Rows: 1,
+		Data: []common.ReadResultRow{{
+			Fields: map[string]any{"id": "001ak00000OKNPGAA5", "name": "Edge"},
+			Raw:    map[string]any{"Id": "001ak00000OKNPGAA5", "Name": "Edge"},
+		}},
+		NextPage: "MTAwMDA",
+		Done:     false,
+	}, {
+		Rows: 1,
+		Data: []common.ReadResultRow{{
+			Fields: map[string]any{"id": "001ak00000OKNPHAA5", "name": "Acme, Inc"},
+			Raw:    map[string]any{"Id": "001ak00000OKNPHAA5", "Name": "Acme, Inc"},
+		}},
+		Done: true,
+	}}
+
+	if !reflect.DeepEqual(pages, expected) {
+		t.Fatalf("expected pages (%v), got (%v)", expected, pages)
+	}
+}
@@ -14,6 +14,7 @@ var ErrExternalIdEmpty = errors.New("external id is required")
 // https://developer.salesforce.com/docs/atlas.en-us.api_asynch.meta/api_asynch/create_job.htm
 //
 // After creation inspect newly launched Bulk Job via:
+// * WaitForJob
 // * GetJobInfo
 // * GetJobResults
 // * GetSuccessfulJobResults.
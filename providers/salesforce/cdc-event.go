@@ -0,0 +1,165 @@
+package salesforce
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// ErrNotChangeEvent is returned when the payload has no ChangeEventHeader.
+var ErrNotChangeEvent = errors.New("payload is not a change event")
+
+// ChangeEventType is the operation which caused the change event.
+type ChangeEventType string
+
+// nolint: lll
+// https://developer.salesforce.com/docs/atlas.en-us.change_data_capture.meta/change_data_capture/cdc_event_fields_header.htm
+const (
+	ChangeEventCreate   ChangeEventType = "CREATE"
+	ChangeEventUpdate   ChangeEventType = "UPDATE"
+	ChangeEventDelete   ChangeEventType = "DELETE"
+	ChangeEventUndelete ChangeEventType = "UNDELETE"
+	// Gap events tell that changes happened but couldn't be captured, records must be read to catch up.
+	ChangeEventGapCreate   ChangeEventType = "GAP_CREATE"
+	ChangeEventGapUpdate   ChangeEventType = "GAP_UPDATE"
+	ChangeEventGapDelete   ChangeEventType = "GAP_DELETE"
+	ChangeEventGapUndelete ChangeEventType = "GAP_UNDELETE"
+	// ChangeEventGapOverflow is sent instead of events of a transaction which changed too many records.
+	ChangeEventGapOverflow ChangeEventType = "GAP_OVERFLOW"
+)
+
+// ChangeEventHeader describes the change, it is part of every change event.
+type ChangeEventHeader struct {
+	// EntityName is the sObject, such as "Account".
+	EntityName string `json:"entityName"`
+	// RecordIds lists records which were changed the same way, events of a transaction may be merged.
+	RecordIds  []string        `json:"recordIds"`
+	ChangeType ChangeEventType `json:"changeType"`
+	// ChangeOrigin is the API or client which made the change.
+	ChangeOrigin   string `json:"changeOrigin"`
+	TransactionKey string `json:"transactionKey"`
+	// SequenceNumber orders events within the transaction.
+	SequenceNumber int `json:"sequenceNumber"`
+	// CommitTimestamp is in milliseconds since epoch, see CommitTime.
+	CommitTimestamp int64  `json:"commitTimestamp"`
+	CommitNumber    int64  `json:"commitNumber"`
+	CommitUser      string `json:"commitUser"`
+	// ChangedFields lists updated fields, fields of compound fields are written as "Name.LastName".
+	ChangedFields []string `json:"changedFields"`
+	// NulledFields lists fields which were set to null.
+	NulledFields []string `json:"nulledFields"`
+	// DiffFields lists large text fields whose values are sent as a diff.
+	DiffFields []string `json:"diffFields"`
+}
+
+// ChangeEvent is a Change Data Capture event, as decoded by ParseChangeEvent.
+type ChangeEvent struct {
+	Header ChangeEventHeader `json:"header"`
+	// Fields are the values of the record which were set, the header excluded.
+	Fields map[string]any `json:"fields"`
+	// ReplayID allows resuming the subscription after this event. Zero when the payload has no envelope.
+	ReplayID int64 `json:"replayId,omitempty"`
+}
+
+// CommitTime returns when the change was committed.
+func (h ChangeEventHeader) CommitTime() time.Time {
+	return time.UnixMilli(h.CommitTimestamp).UTC()
+}
+
+// IsGap tells that the event carries no field values, the records must be read to learn their state.
+func (h ChangeEventHeader) IsGap() bool {
+	return strings.HasPrefix(string(h.ChangeType), "GAP_")
+}
+
+// Change converts the change type into the one shared by all connectors.
+// Undeleted records are reported as created.
+func (h ChangeEventHeader) Change() common.ChangeType {
+	switch h.ChangeType {
+	case ChangeEventCreate, ChangeEventUndelete, ChangeEventGapCreate, ChangeEventGapUndelete:
+		return common.ChangeTypeCreated
+	case ChangeEventDelete, ChangeEventGapDelete:
+		return common.ChangeTypeDeleted
+	case ChangeEventUpdate, ChangeEventGapUpdate, ChangeEventGapOverflow:
+		return common.ChangeTypeUpdated
+	default:
+		return common.ChangeTypeUpdated
+	}
+}
+
+// cometDEnvelope wraps the payload of events delivered by the Streaming API.
+type cometDEnvelope struct {
+	Data *struct {
+		Payload map[string]json.RawMessage `json:"payload"`
+		Event   struct {
+			ReplayID int64 `json:"replayId"`
+		} `json:"event"`
+	} `json:"data"`
+}
+
+// ParseChangeEvent decodes the payload of a change event. Both the payload alone, as decoded from Pub/Sub API,
+// and the envelope of the Streaming API, having "data.payload" and "data.event.replayId", are understood.
+// https://developer.salesforce.com/docs/atlas.en-us.change_data_capture.meta/change_data_capture/cdc_message_structure.htm
+func ParseChangeEvent(data []byte) (*ChangeEvent, error) {
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+
+	event := &ChangeEvent{}
+
+	if _, ok := payload["data"]; ok {
+		var envelope cometDEnvelope
+		if err := json.Unmarshal(data, &envelope); err != nil {
+			return nil, err
+		}
+
+		if envelope.Data != nil && envelope.Data.Payload != nil {
+			payload = envelope.Data.Payload
+			event.ReplayID = envelope.Data.Event.ReplayID
+		}
+	}
+
+	header, ok := payload["ChangeEventHeader"]
+	if !ok {
+		return nil, ErrNotChangeEvent
+	}
+
+	if err := json.Unmarshal(header, &event.Header); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotChangeEvent, err)
+	}
+
+	event.Fields = make(map[string]any, len(payload)-1)
+
+	for field, raw := range payload {
+		if field == "ChangeEventHeader" {
+			continue
+		}
+
+		value, err := decodeFieldValue(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		event.Fields[field] = value
+	}
+
+	return event, nil
+}
+
+// decodeFieldValue keeps numbers as written, so that large values are not rounded.
+func decodeFieldValue(raw json.RawMessage) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
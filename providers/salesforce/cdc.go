@@ -0,0 +1,255 @@
+package salesforce
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// StandardChangeEventChannel is the channel of Change Data Capture events, which exists in every org.
+// Custom channels are created by CreateEventChannel with the "data" channel type.
+const StandardChangeEventChannel = "ChangeEvents"
+
+const (
+	changeEventSuffix            = "ChangeEvent"
+	customObjectSuffix           = "__c"
+	customChannelSuffix          = "__chn"
+	uriToolingChannelMember      = "tooling/sobjects/PlatformEventChannelMember"
+	uriToolingQuery              = "tooling/query"
+	channelMemberQueryFields     = "Id,DeveloperName,EventChannel,SelectedEntity"
+	channelMemberQueryObjectName = "PlatformEventChannelMember"
+)
+
+type toolingQueryResponse[R any] struct {
+	Records        []R    `json:"records"`
+	Done           bool   `json:"done"`
+	NextRecordsURL string `json:"nextRecordsUrl"`
+}
+
+// nolint:tagliatelle
+type channelMemberRecord struct {
+	Id             string `json:"Id"`
+	DeveloperName  string `json:"DeveloperName"`
+	EventChannel   string `json:"EventChannel"`
+	SelectedEntity string `json:"SelectedEntity"`
+}
+
+// nolint:tagliatelle
+type channelRecord struct {
+	Id string `json:"Id"`
+}
+
+// ChangeEventName returns the change event of the sObject, "Account" is reported by "AccountChangeEvent",
+// custom object "Order__c" is reported by "Order__ChangeEvent".
+func ChangeEventName(objectName string) string {
+	if strings.HasSuffix(objectName, customObjectSuffix) {
+		return strings.TrimSuffix(objectName, "c") + changeEventSuffix
+	}
+
+	return objectName + changeEventSuffix
+}
+
+// ChangeEventObjectName is the reverse of ChangeEventName, it returns the sObject of the change event.
+func ChangeEventObjectName(eventName string) string {
+	objectName := strings.TrimSuffix(eventName, changeEventSuffix)
+	if strings.HasSuffix(objectName, "__") {
+		return objectName + "c"
+	}
+
+	return objectName
+}
+
+// EnableChangeEvents selects sObjects for Change Data Capture on the channel,
+// which is usually StandardChangeEventChannel. Objects that are already selected are left as they are.
+// Members of the channel are returned for every object.
+// nolint: lll
+// https://developer.salesforce.com/docs/atlas.en-us.change_data_capture.meta/change_data_capture/cdc_select_objects_tooling_api.htm
+func (c *Connector) EnableChangeEvents(
+	ctx context.Context, channel string, objectNames ...string,
+) ([]*EventChannelMember, error) {
+	existing, err := c.ListChangeEvents(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*EventChannelMember, 0, len(objectNames))
+
+	for _, objectName := range objectNames {
+		entity := ChangeEventName(objectName)
+
+		index := slices.IndexFunc(existing, func(member *EventChannelMember) bool {
+			return strings.EqualFold(member.Metadata.SelectedEntity, entity)
+		})
+		if index != -1 {
+			members = append(members, existing[index])
+
+			continue
+		}
+
+		member, err := c.CreateEventChannelMember(ctx, &EventChannelMember{
+			FullName: channelMemberFullName(channel, entity),
+			Metadata: &EventChannelMemberMetadata{
+				EventChannel:   channel,
+				SelectedEntity: entity,
+			},
+		})
+		if err != nil {
+			return members, err
+		}
+
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+// DisableChangeEvents removes sObjects from Change Data Capture on the channel.
+// Without object names every object of the channel is removed. Objects which are not selected are ignored,
+// so that the call can be repeated until it succeeds.
+func (c *Connector) DisableChangeEvents(ctx context.Context, channel string, objectNames ...string) error {
+	existing, err := c.ListChangeEvents(ctx, channel)
+	if err != nil {
+		return err
+	}
+
+	entities := make([]string, len(objectNames))
+	for i, objectName := range objectNames {
+		entities[i] = strings.ToLower(ChangeEventName(objectName))
+	}
+
+	for _, member := range existing {
+		if len(entities) != 0 && !slices.Contains(entities, strings.ToLower(member.Metadata.SelectedEntity)) {
+			continue
+		}
+
+		if err = c.DeleteEventChannelMember(ctx, member.Id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListChangeEvents returns members of the channel, one per change event it delivers.
+// Use ChangeEventObjectName to get the sObject of the SelectedEntity.
+// nolint: lll
+// https://developer.salesforce.com/docs/atlas.en-us.api_tooling.meta/api_tooling/tooling_api_objects_platformeventchannelmember.htm
+func (c *Connector) ListChangeEvents(ctx context.Context, channel string) ([]*EventChannelMember, error) {
+	// Members refer to custom channels by id, the standard channel is referred to by name.
+	channelRefs := []string{channel}
+
+	if strings.HasSuffix(channel, customChannelSuffix) {
+		query, err := NewSOQL("PlatformEventChannel").Select("Id").
+			Where("DeveloperName = ?", strings.TrimSuffix(channel, customChannelSuffix)).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+
+		channels, err := toolingQuery[channelRecord](ctx, c, query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range channels {
+			channelRefs = append(channelRefs, record.Id)
+		}
+	}
+
+	records, err := toolingQuery[channelMemberRecord](ctx, c,
+		"SELECT "+channelMemberQueryFields+" FROM "+channelMemberQueryObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*EventChannelMember, 0, len(records))
+
+	for _, record := range records {
+		if !slices.Contains(channelRefs, record.EventChannel) ||
+			!strings.HasSuffix(record.SelectedEntity, changeEventSuffix) {
+			continue
+		}
+
+		members = append(members, &EventChannelMember{
+			Id:       record.Id,
+			FullName: record.DeveloperName,
+			Metadata: &EventChannelMemberMetadata{
+				EventChannel:   channel,
+				SelectedEntity: record.SelectedEntity,
+			},
+		})
+	}
+
+	return members, nil
+}
+
+// DeleteEventChannelMember removes the member from its channel. Missing member is not an error.
+// nolint: lll
+// https://developer.salesforce.com/docs/atlas.en-us.api_tooling.meta/api_tooling/tooling_api_objects_platformeventchannelmember.htm
+func (c *Connector) DeleteEventChannelMember(ctx context.Context, id string) error {
+	location, err := c.getRestApiURL(uriToolingChannelMember, id)
+	if err != nil {
+		return err
+	}
+
+	// 204 NoContent is expected
+	_, err = c.Client.Delete(ctx, location.String())
+	if isNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// toolingQuery runs the query against the Tooling API and returns records of every page.
+func toolingQuery[R any](ctx context.Context, c *Connector, query string) ([]R, error) {
+	location, err := c.getRestApiURL(uriToolingQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	location.WithQueryParam("q", query)
+
+	var (
+		records []R
+		next    = location.String()
+	)
+
+	for len(next) != 0 {
+		resp, err := c.Client.Get(ctx, next)
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := common.UnmarshalJSON[toolingQueryResponse[R]](resp)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, page.Records...)
+
+		next = ""
+		if !page.Done && len(page.NextRecordsURL) != 0 {
+			// Next page is a path relative to the instance.
+			next = c.BaseURL + page.NextRecordsURL
+		}
+	}
+
+	return records, nil
+}
+
+// channelMemberFullName follows the naming of Salesforce,
+// "ChangeEvents_AccountChangeEvent" or "Sales_chn_AccountChangeEvent" for custom channel "Sales__chn".
+func channelMemberFullName(channel, entity string) string {
+	return strings.ReplaceAll(channel, "__", "_") + "_" + entity
+}
+
+func isNotFound(err error) bool {
+	var statusErr *common.HTTPStatusError
+
+	return errors.As(err, &statusErr) && statusErr.HTTPStatus == http.StatusNotFound
+}
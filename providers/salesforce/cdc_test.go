@@ -0,0 +1,292 @@
+package salesforce
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+)
+
+const channelMembersQuery = "SELECT Id,DeveloperName,EventChannel,SelectedEntity FROM PlatformEventChannelMember"
+
+// responseChannelMembers has Account selected on the standard channel,
+// Lead on a custom channel and a platform event which is not a change event.
+var responseChannelMembers = `{"size": 3, "totalSize": 3, "done": true, "records": [
+	{"Id": "0v8ak00000001", "DeveloperName": "ChangeEvents_AccountChangeEvent",
+		"EventChannel": "ChangeEvents", "SelectedEntity": "AccountChangeEvent"},
+	{"Id": "0v8ak00000002", "DeveloperName": "Sales_chn_LeadChangeEvent",
+		"EventChannel": "0YLak0000000001", "SelectedEntity": "LeadChangeEvent"},
+	{"Id": "0v8ak00000003", "DeveloperName": "ChangeEvents_Order_e",
+		"EventChannel": "ChangeEvents", "SelectedEntity": "Order__e"}
+]}` // nolint:gochecknoglobals
+
+func TestEnableChangeEvents(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []enableChangeEventsTestCase{
+		{
+			Name:  "Selected objects are kept, missing ones are created",
+			Input: []string{"Account", "Invoice__c"},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If: mockcond.And{
+						mockcond.PathSuffix("/services/data/v59.0/tooling/query"),
+						mockcond.QueryParam("q", channelMembersQuery),
+					},
+					Then: mockserver.ResponseString(http.StatusOK, responseChannelMembers),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodPOST(),
+						mockcond.PathSuffix("/services/data/v59.0/tooling/sobjects/PlatformEventChannelMember"),
+						mockcond.Body(`{"FullName":"ChangeEvents_Invoice__ChangeEvent",
+							"Metadata":{"eventChannel":"ChangeEvents","selectedEntity":"Invoice__ChangeEvent"}}`),
+					},
+					Then: mockserver.ResponseString(http.StatusCreated,
+						`{"id": "0v8ak00000004", "success": true, "errors": []}`),
+				}},
+			}.Server(),
+			Expected: []*EventChannelMember{{
+				Id:       "0v8ak00000001",
+				FullName: "ChangeEvents_AccountChangeEvent",
+				Metadata: &EventChannelMemberMetadata{EventChannel: "ChangeEvents", SelectedEntity: "AccountChangeEvent"},
+			}, {
+				Id:       "0v8ak00000004",
+				FullName: "ChangeEvents_Invoice__ChangeEvent",
+				Metadata: &EventChannelMemberMetadata{EventChannel: "ChangeEvents", SelectedEntity: "Invoice__ChangeEvent"},
+			}},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestDisableChangeEvents(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []disableChangeEventsTestCase{
+		{
+			Name: "Custom channel members are removed, already deleted ones are ignored",
+			Input: disableChangeEventsInput{
+				channel: "Sales__chn",
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If: mockcond.QueryParam("q", "SELECT Id FROM PlatformEventChannel WHERE DeveloperName = 'Sales'"),
+					Then: mockserver.ResponseString(http.StatusOK,
+						`{"size": 1, "done": true, "records": [{"Id": "0YLak0000000001"}]}`),
+				}, {
+					If:   mockcond.QueryParam("q", channelMembersQuery),
+					Then: mockserver.ResponseString(http.StatusOK, responseChannelMembers),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodDELETE(),
+						mockcond.PathSuffix("/tooling/sobjects/PlatformEventChannelMember/0v8ak00000002"),
+					},
+					Then: mockserver.ResponseString(http.StatusNotFound,
+						`[{"errorCode": "NOT_FOUND", "message": "The requested resource does not exist"}]`),
+				}},
+			}.Server(),
+			Expected:     struct{}{},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Only requested objects are removed",
+			Input: disableChangeEventsInput{
+				channel:     StandardChangeEventChannel,
+				objectNames: []string{"Contact", "Account"},
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If:   mockcond.QueryParam("q", channelMembersQuery),
+					Then: mockserver.ResponseString(http.StatusOK, responseChannelMembers),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodDELETE(),
+						mockcond.PathSuffix("/tooling/sobjects/PlatformEventChannelMember/0v8ak00000001"),
+					},
+					Then: mockserver.Response(http.StatusNoContent),
+				}},
+			}.Server(),
+			Expected:     struct{}{},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestParseChangeEvent(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        string
+		expected     *ChangeEvent
+		expectedErrs []error
+	}{
+		{
+			name: "Streaming API envelope",
+			input: `{"data": {"schema": "IeRuaY6cbI_HsV8Rv1Mc5g", "event": {"replayId": 6},
+				"payload": {"ChangeEventHeader": {"entityName": "Account", "recordIds": ["001ak00000A1"],
+				"changeType": "UPDATE", "changeOrigin": "com/salesforce/api/rest/59.0",
+				"transactionKey": "0002343d-9d90-e395-ed20-cf416ba652ad", "sequenceNumber": 1,
+				"commitTimestamp": 1729152000000, "commitNumber": 10585193272713,
+				"commitUser": "005ak000001", "nulledFields": ["Fax"], "diffFields": [],
+				"changedFields": ["Name", "Fax", "LastModifiedDate"]},
+				"Name": "Acme", "Fax": null, "LastModifiedDate": "2024-10-17T08:00:00.000Z"}},
+				"channel": "/data/AccountChangeEvent"}`,
+			expected: &ChangeEvent{
+				Header: ChangeEventHeader{
+					EntityName:      "Account",
+					RecordIds:       []string{"001ak00000A1"},
+					ChangeType:      ChangeEventUpdate,
+					ChangeOrigin:    "com/salesforce/api/rest/59.0",
+					TransactionKey:  "0002343d-9d90-e395-ed20-cf416ba652ad",
+					SequenceNumber:  1,
+					CommitTimestamp: 1729152000000,
+					CommitNumber:    10585193272713,
+					CommitUser:      "005ak000001",
+					ChangedFields:   []string{"Name", "Fax", "LastModifiedDate"},
+					NulledFields:    []string{"Fax"},
+					DiffFields:      []string{},
+				},
+				Fields:   map[string]any{"Name": "Acme", "Fax": nil, "LastModifiedDate": "2024-10-17T08:00:00.000Z"},
+				ReplayID: 6,
+			},
+		},
+		{
+			name: "Large numbers are not rounded",
+			input: `{"ChangeEventHeader": {"entityName": "Opportunity", "recordIds": ["006ak1"],
+				"changeType": "UPDATE", "commitTimestamp": 1729152000000},
+				"Amount": 9007199254740993}`,
+			expected: &ChangeEvent{
+				Header: ChangeEventHeader{
+					EntityName:      "Opportunity",
+					RecordIds:       []string{"006ak1"},
+					ChangeType:      ChangeEventUpdate,
+					CommitTimestamp: 1729152000000,
+				},
+				Fields: map[string]any{"Amount": json.Number("9007199254740993")},
+			},
+		},
+		{
+			name: "Payload alone",
+			input: `{"ChangeEventHeader": {"entityName": "Invoice__c", "recordIds": ["a01ak1", "a01ak2"],
+				"changeType": "GAP_DELETE", "commitTimestamp": 1729152000000}}`,
+			expected: &ChangeEvent{
+				Header: ChangeEventHeader{
+					EntityName:      "Invoice__c",
+					RecordIds:       []string{"a01ak1", "a01ak2"},
+					ChangeType:      ChangeEventGapDelete,
+					CommitTimestamp: 1729152000000,
+				},
+				Fields: map[string]any{},
+			},
+		},
+		{
+			name:         "Platform event is not a change event",
+			input:        `{"data": {"payload": {"Order_Number__c": "42"}, "event": {"replayId": 7}}}`,
+			expectedErrs: []error{ErrNotChangeEvent},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := ParseChangeEvent([]byte(tt.input))
+
+			for _, expectedErr := range tt.expectedErrs {
+				if !errors.Is(err, expectedErr) {
+					t.Fatalf("%s: expected error: (%v), got: (%v)", tt.name, expectedErr, err)
+				}
+			}
+
+			if len(tt.expectedErrs) == 0 && err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+
+			if !reflect.DeepEqual(output, tt.expected) {
+				t.Fatalf("%s: expected: (%+v), got: (%+v)", tt.name, tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestChangeEventHeader(t *testing.T) {
+	t.Parallel()
+
+	header := ChangeEventHeader{ChangeType: ChangeEventGapUndelete, CommitTimestamp: 1729152000000}
+
+	if !header.IsGap() || header.Change() != common.ChangeTypeCreated {
+		t.Fatalf("gap undelete must be a gap creation, got gap %v and %v", header.IsGap(), header.Change())
+	}
+
+	if !header.CommitTime().Equal(time.Date(2024, 10, 17, 8, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected commit time %v", header.CommitTime())
+	}
+
+	if name := ChangeEventName("Invoice__c"); name != "Invoice__ChangeEvent" || ChangeEventObjectName(name) != "Invoice__c" {
+		t.Fatalf("unexpected change event name %v", name)
+	}
+}
+
+type (
+	enableChangeEventsTestCaseType = testroutines.TestCase[[]string, []*EventChannelMember]
+	enableChangeEventsTestCase     enableChangeEventsTestCaseType
+
+	disableChangeEventsInput struct {
+		channel     string
+		objectNames []string
+	}
+	disableChangeEventsTestCaseType = testroutines.TestCase[disableChangeEventsInput, struct{}]
+	disableChangeEventsTestCase     disableChangeEventsTestCaseType
+)
+
+func (c enableChangeEventsTestCase) Run(t *testing.T, builder testroutines.ConnectorBuilder[*Connector]) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	output, err := conn.EnableChangeEvents(context.Background(), StandardChangeEventChannel, c.Input...)
+	enableChangeEventsTestCaseType(c).Validate(t, err, output)
+}
+
+func (c disableChangeEventsTestCase) Run(t *testing.T, builder testroutines.ConnectorBuilder[*Connector]) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	err := conn.DisableChangeEvents(context.Background(), c.Input.channel, c.Input.objectNames...)
+	disableChangeEventsTestCaseType(c).Validate(t, err, struct{}{})
+}
@@ -0,0 +1,340 @@
+package salesforce
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/jsonquery"
+	"github.com/spyzhov/ajson"
+)
+
+// maxCompositeSubrequests is the limit of subrequests accepted by Composite API.
+const maxCompositeSubrequests = 25
+
+var (
+	ErrMixedCollection      = errors.New("collection cannot mix records being created with records being updated")
+	ErrMissingReferenceId   = errors.New("composite subrequest is missing reference id")
+	ErrDuplicateReferenceId = errors.New("composite subrequest reference id is not unique")
+)
+
+// WriteCollectionParams describes records written by WriteCollection.
+type WriteCollectionParams struct {
+	// Records to write. Each record may belong to a different object.
+	// Either all records have RecordId set, in which case they are updated,
+	// or none of them do, and they are created.
+	Records []common.WriteParams // required, at most 200
+	// AllOrNone rolls back the whole request when any record fails to be written.
+	AllOrNone bool // optional
+}
+
+func (p WriteCollectionParams) ValidateParams() error {
+	if len(p.Records) == 0 {
+		return common.ErrMissingRecordData
+	}
+
+	if len(p.Records) > maxCollectionSize {
+		return fmt.Errorf("%w: got %v, limit is %v", ErrTooManyRecords, len(p.Records), maxCollectionSize)
+	}
+
+	isUpdate := p.isUpdate()
+
+	for _, record := range p.Records {
+		if err := record.ValidateParams(); err != nil {
+			return err
+		}
+
+		if (len(record.RecordId) != 0) != isUpdate {
+			return ErrMixedCollection
+		}
+	}
+
+	return nil
+}
+
+func (p WriteCollectionParams) isUpdate() bool {
+	return len(p.Records[0].RecordId) != 0
+}
+
+// WriteCollection creates or updates up to 200 records in a single call using sObject Collections.
+// This saves API calls compared to Write, which sends one request per record,
+// while staying synchronous unlike BulkWrite. Results are returned per record in the order of Records.
+// https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_composite_sobjects_collections_create.htm
+// https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_composite_sobjects_collections_update.htm
+func (c *Connector) WriteCollection(
+	ctx context.Context, params WriteCollectionParams,
+) ([]common.WriteResult, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	url, err := c.getRestApiURL("composite", "sobjects")
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]map[string]any, len(params.Records))
+
+	for index, record := range params.Records {
+		records[index], err = makeCollectionRecord(record)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	body := map[string]any{
+		"allOrNone": params.AllOrNone,
+		"records":   records,
+	}
+
+	var write common.WriteMethod = c.Client.Post
+	if params.isUpdate() {
+		write = c.Client.Patch
+	}
+
+	rsp, err := write(ctx, url.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCollectionResult(rsp)
+}
+
+// makeCollectionRecord converts record data to the format of sObject Collections.
+// Object name is given via attributes, while record identifier is one of the fields.
+func makeCollectionRecord(params common.WriteParams) (map[string]any, error) {
+	recordData, err := makeBatchRecordData(params)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := recordDataToMap(recordData)
+	if err != nil {
+		return nil, err
+	}
+
+	record["attributes"] = map[string]any{
+		"type": params.ObjectName,
+	}
+
+	if len(params.RecordId) != 0 {
+		record["Id"] = params.RecordId
+	}
+
+	return record, nil
+}
+
+// makeBatchRecordData returns record data with ClearFields set to null.
+// Records are created or updated depending on RecordId, other write modes are not supported in batches.
+func makeBatchRecordData(params common.WriteParams) (any, error) {
+	if params.Mode != common.WriteModeDefault || len(params.ExternalIdField) != 0 {
+		return nil, fmt.Errorf("%w: %v mode of %v in a batch",
+			common.ErrOperationNotSupportedForObject, params.ResolveMode(), params.ObjectName)
+	}
+
+	return params.RecordDataWithClearedFields(nil)
+}
+
+// recordDataToMap returns a shallow copy of record data as a map.
+// Numbers are decoded as json.Number, so that large ones are not rounded.
+func recordDataToMap(data any) (map[string]any, error) {
+	if record, ok := data.(map[string]any); ok {
+		clone := make(map[string]any, len(record))
+		for key, value := range record {
+			clone[key] = value
+		}
+
+		return clone, nil
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Join(common.ErrRecordDataNotJSON, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+
+	var record map[string]any
+	if err = decoder.Decode(&record); err != nil {
+		return nil, errors.Join(common.ErrRecordDataNotJSON, err)
+	}
+
+	return record, nil
+}
+
+// CompositeParams describes a chain of writes executed by Composite.
+type CompositeParams struct {
+	// Subrequests are executed in order. Up to 25 subrequests are allowed.
+	Subrequests []CompositeSubrequest // required
+	// AllOrNone rolls back the whole request when any subrequest fails.
+	AllOrNone bool // optional
+}
+
+// CompositeSubrequest is a single write within Composite request.
+// Later subrequests can refer to the outcome of earlier ones using "@{ReferenceId.id}" notation,
+// both in RecordId and inside RecordData. Ex: create Account, then create Contact with AccountId="@{newAccount.id}".
+type CompositeSubrequest struct {
+	// ReferenceId names this subrequest, must be unique within the request.
+	ReferenceId string // required
+	// Write describes the record. Record is updated if RecordId is set, otherwise it is created.
+	Write common.WriteParams // required
+}
+
+func (p CompositeParams) ValidateParams() error {
+	if len(p.Subrequests) == 0 {
+		return common.ErrMissingRecordData
+	}
+
+	if len(p.Subrequests) > maxCompositeSubrequests {
+		return fmt.Errorf("%w: got %v, limit is %v", ErrTooManyRecords, len(p.Subrequests), maxCompositeSubrequests)
+	}
+
+	references := make(map[string]bool)
+
+	for _, subrequest := range p.Subrequests {
+		if len(subrequest.ReferenceId) == 0 {
+			return ErrMissingReferenceId
+		}
+
+		if references[subrequest.ReferenceId] {
+			return fmt.Errorf("%w: %v", ErrDuplicateReferenceId, subrequest.ReferenceId)
+		}
+
+		references[subrequest.ReferenceId] = true
+
+		if err := subrequest.Write.ValidateParams(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Composite executes a series of dependent writes in a single call.
+// Results are returned per subrequest in the order of Subrequests.
+// https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_composite_composite.htm
+func (c *Connector) Composite(ctx context.Context, params CompositeParams) ([]common.WriteResult, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	url, err := c.getRestApiURL("composite")
+	if err != nil {
+		return nil, err
+	}
+
+	subrequests := make([]map[string]any, len(params.Subrequests))
+
+	for index, subrequest := range params.Subrequests {
+		subrequests[index], err = makeCompositeSubrequest(subrequest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rsp, err := c.Client.Post(ctx, url.String(), map[string]any{
+		"allOrNone":        params.AllOrNone,
+		"compositeRequest": subrequests,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCompositeResult(rsp, params)
+}
+
+func makeCompositeSubrequest(subrequest CompositeSubrequest) (map[string]any, error) {
+	recordData, err := makeBatchRecordData(subrequest.Write)
+	if err != nil {
+		return nil, err
+	}
+
+	path := strings.Join([]string{restAPISuffix, "sobjects", subrequest.Write.ObjectName}, "/")
+	method := http.MethodPost
+
+	if len(subrequest.Write.RecordId) != 0 {
+		path += "/" + subrequest.Write.RecordId
+		method = http.MethodPatch
+	}
+
+	return map[string]any{
+		"method":      method,
+		"url":         path,
+		"referenceId": subrequest.ReferenceId,
+		"body":        recordData,
+	}, nil
+}
+
+// parseCompositeResult converts Composite response into per subrequest results.
+// Successful creation returns the same body as Write, successful update has no body,
+// while failures are described by a list of errors.
+func parseCompositeResult(rsp *common.JSONHTTPResponse, params CompositeParams) ([]common.WriteResult, error) {
+	body, ok := rsp.Body()
+	if !ok {
+		return nil, common.ErrEmptyJSONHTTPResponse
+	}
+
+	items, err := jsonquery.New(body).Array("compositeResponse", false)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]common.WriteResult, len(items))
+
+	for index, item := range items {
+		result, err := parseCompositeSubresult(item)
+		if err != nil {
+			return nil, err
+		}
+
+		// Updated records are not echoed back, therefore the identifier comes from the request.
+		if len(result.RecordId) == 0 && index < len(params.Subrequests) {
+			result.RecordId = params.Subrequests[index].Write.RecordId
+		}
+
+		results[index] = *result
+	}
+
+	return results, nil
+}
+
+func parseCompositeSubresult(node *ajson.Node) (*common.WriteResult, error) {
+	status, err := jsonquery.New(node).Integer("httpStatusCode", false)
+	if err != nil {
+		return nil, err
+	}
+
+	success := *status >= 200 && *status < 300
+
+	subresult, err := jsonquery.New(node).Object("body", true)
+	if err != nil {
+		if !errors.Is(err, jsonquery.ErrNotObject) {
+			return nil, err
+		}
+
+		// Body is a list of errors.
+		errs, err := getErrors(node, "body")
+		if err != nil {
+			return nil, err
+		}
+
+		return &common.WriteResult{
+			Success: success,
+			Errors:  errs,
+		}, nil
+	}
+
+	if subresult == nil {
+		return &common.WriteResult{
+			Success: success,
+		}, nil
+	}
+
+	return parseRecordResult(subresult)
+}
@@ -0,0 +1,299 @@
+package salesforce
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+	"github.com/go-test/deep"
+)
+
+func TestWriteCollection(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	responseCollection := `[{
+		"id": "001RM000003oLnnYAE",
+		"success": true,
+		"errors": []
+	}, {
+		"id": "003RM0000068xVCYAY",
+		"success": true,
+		"errors": []
+	}]`
+	expectedResults := []common.WriteResult{
+		{Success: true, RecordId: "001RM000003oLnnYAE", Errors: []any{}},
+		{Success: true, RecordId: "003RM0000068xVCYAY", Errors: []any{}},
+	}
+
+	tests := []struct {
+		name         string
+		input        WriteCollectionParams
+		server       mockserver.Conditional
+		expected     []common.WriteResult
+		expectedErrs []error
+	}{
+		{
+			name:         "Records are required",
+			input:        WriteCollectionParams{},
+			expectedErrs: []error{common.ErrMissingRecordData},
+		},
+		{
+			name: "Created and updated records cannot be mixed",
+			input: WriteCollectionParams{Records: []common.WriteParams{
+				{ObjectName: "Account", RecordData: map[string]any{"Name": "Acme"}},
+				{ObjectName: "Account", RecordId: "001RM000003oLnnYAE", RecordData: map[string]any{"Name": "Acme"}},
+			}},
+			expectedErrs: []error{ErrMixedCollection},
+		},
+		{
+			name: "Records are created",
+			input: WriteCollectionParams{
+				AllOrNone: true,
+				Records: []common.WriteParams{
+					{ObjectName: "Account", RecordData: map[string]any{"Name": "Acme"}},
+					{ObjectName: "Contact", RecordData: map[string]any{"LastName": "Smith"}},
+				},
+			},
+			server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPOST(),
+					mockcond.PathSuffix("/services/data/v59.0/composite/sobjects"),
+					mockcond.Body(`{"allOrNone":true,"records":[
+						{"attributes":{"type":"Account"},"Name":"Acme"},
+						{"attributes":{"type":"Contact"},"LastName":"Smith"}
+					]}`),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, responseCollection),
+			},
+			expected: expectedResults,
+		},
+		{
+			name: "Write modes other than create and update are not supported",
+			input: WriteCollectionParams{Records: []common.WriteParams{{
+				ObjectName:      "Account",
+				RecordId:        "A-1",
+				RecordData:      map[string]any{"Name": "Acme"},
+				Mode:            common.WriteModeUpsert,
+				ExternalIdField: "External_Id__c",
+			}}},
+			expectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			name: "Cleared fields are sent as null",
+			input: WriteCollectionParams{
+				Records: []common.WriteParams{{
+					ObjectName:  "Account",
+					RecordId:    "001RM000003oLnnYAE",
+					RecordData:  map[string]any{"Name": "Acme"},
+					ClearFields: []string{"Phone"},
+				}},
+			},
+			server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.Body(`{"allOrNone":false,"records":[
+					{"attributes":{"type":"Account"},"Id":"001RM000003oLnnYAE","Name":"Acme","Phone":null}
+				]}`),
+				Then: mockserver.ResponseString(http.StatusOK, `[{"id": "001RM000003oLnnYAE", "success": true, "errors": []}]`),
+			},
+			expected: []common.WriteResult{{Success: true, RecordId: "001RM000003oLnnYAE", Errors: []any{}}},
+		},
+		{
+			name: "Large numbers are not rounded",
+			input: WriteCollectionParams{
+				Records: []common.WriteParams{{
+					ObjectName: "Opportunity",
+					RecordId:   "006RM000002bEfiYAE",
+					RecordData: struct {
+						Amount int64 `json:"Amount"`
+					}{Amount: 9007199254740993},
+				}},
+			},
+			server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				// Body is compared as text, since JSON comparison would round the number as well.
+				If: mockcond.Check(func(w http.ResponseWriter, r *http.Request) bool {
+					body, err := io.ReadAll(r.Body)
+
+					return err == nil && string(body) == `{"allOrNone":false,"records":[`+
+						`{"Amount":9007199254740993,"Id":"006RM000002bEfiYAE","attributes":{"type":"Opportunity"}}]}`
+				}),
+				Then: mockserver.ResponseString(http.StatusOK, `[{"id": "006RM000002bEfiYAE", "success": true, "errors": []}]`),
+			},
+			expected: []common.WriteResult{{Success: true, RecordId: "006RM000002bEfiYAE", Errors: []any{}}},
+		},
+		{
+			name: "Records are updated",
+			input: WriteCollectionParams{
+				Records: []common.WriteParams{
+					{ObjectName: "Account", RecordId: "001RM000003oLnnYAE", RecordData: map[string]any{"Name": "Acme"}},
+					{ObjectName: "Contact", RecordId: "003RM0000068xVCYAY", RecordData: map[string]any{"LastName": "Smith"}},
+				},
+			},
+			server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPATCH(),
+					mockcond.PathSuffix("/services/data/v59.0/composite/sobjects"),
+					mockcond.Body(`{"allOrNone":false,"records":[
+						{"attributes":{"type":"Account"},"Id":"001RM000003oLnnYAE","Name":"Acme"},
+						{"attributes":{"type":"Contact"},"Id":"003RM0000068xVCYAY","LastName":"Smith"}
+					]}`),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, responseCollection),
+			},
+			expected: expectedResults,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := tt.server.Server()
+			defer server.Close()
+
+			conn, err := constructTestConnector(server.URL)
+			if err != nil {
+				t.Fatalf("%s: failed to construct connector: %v", tt.name, err)
+			}
+
+			output, err := conn.WriteCollection(context.Background(), tt.input)
+			testutils.CheckErrors(t, tt.name, tt.expectedErrs, err)
+
+			if !reflect.DeepEqual(output, tt.expected) {
+				t.Fatalf("%s:, \nexpected: (%v), \ngot: (%v), \ndiff: (%v)",
+					tt.name, tt.expected, output, deep.Equal(output, tt.expected))
+			}
+		})
+	}
+}
+
+func TestComposite(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        CompositeParams
+		server       mockserver.Conditional
+		expected     []common.WriteResult
+		expectedErrs []error
+	}{
+		{
+			name: "Reference identifiers must be unique",
+			input: CompositeParams{Subrequests: []CompositeSubrequest{
+				{ReferenceId: "ref", Write: common.WriteParams{ObjectName: "Account", RecordData: map[string]any{}}},
+				{ReferenceId: "ref", Write: common.WriteParams{ObjectName: "Account", RecordData: map[string]any{}}},
+			}},
+			expectedErrs: []error{ErrDuplicateReferenceId},
+		},
+		{
+			name: "Chained subrequests are reported in order",
+			input: CompositeParams{
+				AllOrNone: true,
+				Subrequests: []CompositeSubrequest{{
+					ReferenceId: "newAccount",
+					Write: common.WriteParams{
+						ObjectName: "Account",
+						RecordData: map[string]any{"Name": "Acme"},
+					},
+				}, {
+					ReferenceId: "newContact",
+					Write: common.WriteParams{
+						ObjectName: "Contact",
+						RecordData: map[string]any{"LastName": "Smith", "AccountId": "@{newAccount.id}"},
+					},
+				}, {
+					ReferenceId: "renameAccount",
+					Write: common.WriteParams{
+						ObjectName: "Account",
+						RecordId:   "@{newAccount.id}",
+						RecordData: map[string]any{"Name": "Acme Inc"},
+					},
+				}},
+			},
+			server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPOST(),
+					mockcond.PathSuffix("/services/data/v59.0/composite"),
+					mockcond.Body(`{"allOrNone":true,"compositeRequest":[{
+						"method":"POST","referenceId":"newAccount",
+						"url":"/services/data/v59.0/sobjects/Account",
+						"body":{"Name":"Acme"}
+					},{
+						"method":"POST","referenceId":"newContact",
+						"url":"/services/data/v59.0/sobjects/Contact",
+						"body":{"AccountId":"@{newAccount.id}","LastName":"Smith"}
+					},{
+						"method":"PATCH","referenceId":"renameAccount",
+						"url":"/services/data/v59.0/sobjects/Account/@{newAccount.id}",
+						"body":{"Name":"Acme Inc"}
+					}]}`),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{"compositeResponse":[{
+					"body": {"id": "001RM000003oLnnYAE", "success": true, "errors": []},
+					"httpHeaders": {},
+					"httpStatusCode": 201,
+					"referenceId": "newAccount"
+				},{
+					"body": [{"errorCode": "REQUIRED_FIELD_MISSING", "message": "Required fields are missing"}],
+					"httpHeaders": {},
+					"httpStatusCode": 400,
+					"referenceId": "newContact"
+				},{
+					"body": null,
+					"httpHeaders": {},
+					"httpStatusCode": 204,
+					"referenceId": "renameAccount"
+				}]}`),
+			},
+			expected: []common.WriteResult{{
+				Success:  true,
+				RecordId: "001RM000003oLnnYAE",
+				Errors:   []any{},
+			}, {
+				Success: false,
+				Errors: []any{map[string]any{
+					"errorCode": "REQUIRED_FIELD_MISSING",
+					"message":   "Required fields are missing",
+				}},
+			}, {
+				Success:  true,
+				RecordId: "@{newAccount.id}",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := tt.server.Server()
+			defer server.Close()
+
+			conn, err := constructTestConnector(server.URL)
+			if err != nil {
+				t.Fatalf("%s: failed to construct connector: %v", tt.name, err)
+			}
+
+			output, err := conn.Composite(context.Background(), tt.input)
+			testutils.CheckErrors(t, tt.name, tt.expectedErrs, err)
+
+			if !reflect.DeepEqual(output, tt.expected) {
+				t.Fatalf("%s:, \nexpected: (%v), \ngot: (%v), \ndiff: (%v)",
+					tt.name, tt.expected, output, deep.Equal(output, tt.expected))
+			}
+		})
+	}
+}
@@ -60,6 +60,7 @@ func NewConnector(opts ...Option) (conn *Connector, outErr error) {
 		JSON: &interpreter.DirectFaultyResponder{Callback: conn.interpretJSONError},
 		XML:  &interpreter.DirectFaultyResponder{Callback: conn.interpretXMLError},
 	}.Handle
+	conn.Client.HTTPClient.IsRetryable = isRetryableError
 
 	return conn, nil
 }
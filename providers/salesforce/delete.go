@@ -0,0 +1,105 @@
+package salesforce
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/jsonquery"
+)
+
+// Delete removes a single record.
+// https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/dome_delete_record.htm
+func (c *Connector) Delete(ctx context.Context, config common.DeleteParams) (*common.DeleteResult, error) {
+	if err := config.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	url, err := c.getRestApiURL("sobjects", config.ObjectName, config.RecordId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 204 NoContent is expected
+	_, err = c.Client.Delete(ctx, url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.DeleteResult{
+		Success: true,
+	}, nil
+}
+
+// DeleteCollectionParams describes records removed by DeleteCollection.
+type DeleteCollectionParams struct {
+	// RecordIds of records to delete. Records may belong to different objects.
+	RecordIds []string // required, at most 200
+	// AllOrNone rolls back the whole request when any record fails to be deleted.
+	AllOrNone bool // optional
+}
+
+func (p DeleteCollectionParams) ValidateParams() error {
+	if len(p.RecordIds) == 0 {
+		return common.ErrMissingRecordID
+	}
+
+	if len(p.RecordIds) > maxCollectionSize {
+		return fmt.Errorf("%w: got %v, limit is %v", ErrTooManyRecords, len(p.RecordIds), maxCollectionSize)
+	}
+
+	return nil
+}
+
+// DeleteCollection removes up to 200 records in a single call using sObject Collections.
+// Unlike BulkDelete it is synchronous, results are returned per record in the order of RecordIds.
+// https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_composite_sobjects_collections_delete.htm
+func (c *Connector) DeleteCollection(
+	ctx context.Context, params DeleteCollectionParams,
+) ([]common.WriteResult, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	url, err := c.getRestApiURL("composite", "sobjects")
+	if err != nil {
+		return nil, err
+	}
+
+	url.WithQueryParam("ids", strings.Join(params.RecordIds, ","))
+	url.WithQueryParam("allOrNone", fmt.Sprintf("%v", params.AllOrNone))
+
+	rsp, err := c.Client.Delete(ctx, url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCollectionResult(rsp)
+}
+
+// parseCollectionResult parses sObject Collections response, which is a list of per record results.
+func parseCollectionResult(rsp *common.JSONHTTPResponse) ([]common.WriteResult, error) {
+	body, ok := rsp.Body()
+	if !ok {
+		return nil, common.ErrEmptyJSONHTTPResponse
+	}
+
+	items, err := jsonquery.New(body).Array("", false)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]common.WriteResult, len(items))
+
+	for index, item := range items {
+		result, err := parseRecordResult(item)
+		if err != nil {
+			return nil, err
+		}
+
+		results[index] = *result
+	}
+
+	return results, nil
+}
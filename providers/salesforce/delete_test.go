@@ -0,0 +1,162 @@
+package salesforce
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+	"github.com/go-test/deep"
+)
+
+func TestDelete(t *testing.T) { // nolint:funlen,cyclop
+	t.Parallel()
+
+	tests := []testroutines.Delete{
+		{
+			Name:         "Delete object must be included",
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingObjects},
+		},
+		{
+			Name:         "Delete object and its ID must be included",
+			Input:        common.DeleteParams{ObjectName: "Account"},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingRecordID},
+		},
+		{
+			Name:  "Error response is understood",
+			Input: common.DeleteParams{ObjectName: "Account", RecordId: "001ak00000OQTieAAH"},
+			Server: mockserver.Fixed{
+				Setup: mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusBadRequest, `[{
+					"message": "unable to obtain exclusive access to this record",
+					"errorCode": "UNABLE_TO_LOCK_ROW"
+				}]`),
+			}.Server(),
+			ExpectedErrs: []error{
+				common.ErrUnableToLockRow,
+				errors.New("unable to obtain exclusive access to this record"), // nolint:goerr113
+			},
+		},
+		{
+			Name:  "Successful delete",
+			Input: common.DeleteParams{ObjectName: "Account", RecordId: "001ak00000OQTieAAH"},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodDELETE(),
+					mockcond.PathSuffix("/services/data/v59.0/sobjects/Account/001ak00000OQTieAAH"),
+				},
+				Then: mockserver.Response(http.StatusNoContent),
+			}.Server(),
+			Expected:     &common.DeleteResult{Success: true},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests { // nolint:dupl
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.DeleteConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestDeleteCollection(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tooManyIds := make([]string, maxCollectionSize+1)
+
+	tests := []struct {
+		name         string
+		input        DeleteCollectionParams
+		server       mockserver.Conditional
+		expected     []common.WriteResult
+		expectedErrs []error
+	}{
+		{
+			name:         "Record identifiers are required",
+			input:        DeleteCollectionParams{},
+			expectedErrs: []error{common.ErrMissingRecordID},
+		},
+		{
+			name:         "Collection size is limited",
+			input:        DeleteCollectionParams{RecordIds: tooManyIds},
+			expectedErrs: []error{ErrTooManyRecords},
+		},
+		{
+			name:  "Results are reported per record",
+			input: DeleteCollectionParams{RecordIds: []string{"001RM000003oLnnYAE", "001RM000003oLrB000"}},
+			server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodDELETE(),
+					mockcond.PathSuffix("/services/data/v59.0/composite/sobjects"),
+					mockcond.QueryParam("ids", "001RM000003oLnnYAE,001RM000003oLrB000"),
+					mockcond.QueryParam("allOrNone", "false"),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `[{
+					"id": "001RM000003oLnnYAE",
+					"success": true,
+					"errors": []
+				}, {
+					"success": false,
+					"errors": [{
+						"statusCode": "MALFORMED_ID",
+						"message": "malformed id 001RM000003oLrB000",
+						"fields": []
+					}]
+				}]`),
+			},
+			expected: []common.WriteResult{{
+				Success:  true,
+				RecordId: "001RM000003oLnnYAE",
+				Errors:   []any{},
+			}, {
+				Success: false,
+				Errors: []any{map[string]any{
+					"statusCode": "MALFORMED_ID",
+					"message":    "malformed id 001RM000003oLrB000",
+					"fields":     []any{},
+				}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := tt.server.Server()
+			defer server.Close()
+
+			conn, err := constructTestConnector(server.URL)
+			if err != nil {
+				t.Fatalf("%s: failed to construct connector: %v", tt.name, err)
+			}
+
+			output, err := conn.DeleteCollection(context.Background(), tt.input)
+			testutils.CheckErrors(t, tt.name, tt.expectedErrs, err)
+
+			if !reflect.DeepEqual(output, tt.expected) {
+				t.Fatalf("%s:, \nexpected: (%v), \ngot: (%v), \ndiff: (%v)",
+					tt.name, tt.expected, output, deep.Equal(output, tt.expected))
+			}
+		})
+	}
+}
@@ -5,12 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/common/xquery"
 )
 
-var ErrCannotReadMetadata = errors.New("cannot read object metadata, it is possible you don't have the correct permissions set") // nolint:lll
+var (
+	ErrCannotReadMetadata = errors.New("cannot read object metadata, it is possible you don't have the correct permissions set") // nolint:lll
+	ErrTooManyRecords     = errors.New("too many records in a single request")
+)
+
+// maxCollectionSize is the limit of records accepted by sObject Collections and Composite APIs.
+const maxCollectionSize = 200
 
 type jsonError struct {
 	Message   string `json:"message"`
@@ -64,6 +71,46 @@ func (c *Connector) interpretJSONError(res *http.Response, body []byte) error {
 	return common.InterpretError(res, body)
 }
 
+// isRetryableError extends the default classification with Salesforce specific errors.
+// Row lock contention is temporary, the same request is likely to succeed once the lock is released.
+func isRetryableError(err error) bool {
+	return common.IsRetryableError(err) || errors.Is(err, common.ErrUnableToLockRow)
+}
+
+// SOAPFault is the error reported by SOAP APIs, such as the Metadata API.
+// It is joined to errors of the XML client, so that callers can inspect it with errors.As.
+type SOAPFault struct {
+	// Code is the fault code without its namespace, such as "INVALID_SESSION_ID" or "Client".
+	Code string
+	// ExceptionCode is the code of the fault details, such as "INVALID_TYPE". Empty when there are no details.
+	ExceptionCode string
+	Message       string
+}
+
+func (f *SOAPFault) Error() string {
+	return f.Code + ": " + f.Message
+}
+
+// parseSOAPFault returns nil when the document is not a fault.
+// Details are qualified by the "sf" namespace, they are found by local names.
+func parseSOAPFault(xml *xquery.XML) *SOAPFault {
+	code := xml.FindOne("//faultcode")
+	if code.IsEmpty() {
+		return nil
+	}
+
+	_, faultCode, found := strings.Cut(code.Text(), ":")
+	if !found {
+		faultCode = code.Text()
+	}
+
+	return &SOAPFault{
+		Code:          faultCode,
+		ExceptionCode: xml.FindOne("//detail//*[local-name()='exceptionCode']").Text(),
+		Message:       strings.TrimSpace(xml.FindOne("//faultstring").Text()),
+	}
+}
+
 func (c *Connector) interpretXMLError(res *http.Response, body []byte) error {
 	xml, err := xquery.NewXML(body)
 	if err != nil {
@@ -72,24 +119,27 @@ func (c *Connector) interpretXMLError(res *http.Response, body []byte) error {
 		return common.InterpretError(res, body)
 	}
 
-	code := xml.FindOne("//faultcode").Text()
-	message := xml.FindOne("//faultstring").Text()
+	fault := parseSOAPFault(xml)
+	if fault == nil {
+		return common.InterpretError(res, body)
+	}
 
 	var matchingErr error
 
-	switch code {
-	case "soapenv:Client":
+	switch fault.Code {
+	case "Client", "INVALID_TYPE", "INVALID_FIELD", "INVALID_CROSS_REFERENCE_KEY", "FIELD_INTEGRITY_EXCEPTION":
 		matchingErr = common.ErrBadRequest
-	case "sf:INVALID_SESSION_ID":
+	case "INVALID_SESSION_ID":
 		matchingErr = common.ErrAccessToken
+	case "INSUFFICIENT_ACCESS", "INSUFFICIENT_ACCESS_OR_READONLY":
+		matchingErr = common.ErrForbidden
+	case "API_DISABLED_FOR_ORG":
+		matchingErr = common.ErrApiDisabled
+	case "REQUEST_LIMIT_EXCEEDED":
+		matchingErr = common.ErrLimitExceeded
+	default:
+		return fmt.Errorf("%w: %w", common.InterpretError(res, body), fault)
 	}
 
-	if matchingErr == nil {
-		return common.InterpretError(res, body)
-	}
-
-	return createError(matchingErr, jsonError{
-		Message:   message,
-		ErrorCode: code,
-	})
+	return fmt.Errorf("%w: %w", matchingErr, fault)
 }
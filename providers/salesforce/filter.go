@@ -0,0 +1,124 @@
+package salesforce
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/handy"
+)
+
+// SOQL comparison operators keyed by filter operator.
+var soqlOperators = map[common.FilterOperator]string{ // nolint:gochecknoglobals
+	common.FilterOperatorEq: "=",
+	common.FilterOperatorNe: "!=",
+	common.FilterOperatorGt: ">",
+	common.FilterOperatorLt: "<",
+}
+
+// Characters that must be escaped inside quoted SOQL string literals.
+// https://developer.salesforce.com/docs/atlas.en-us.soql_sosl.meta/soql_sosl/sforce_api_calls_soql_select_quotedstringescapes.htm
+var soqlStringEscaper = strings.NewReplacer( // nolint:gochecknoglobals
+	`\`, `\\`,
+	`'`, `\'`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+	"\b", `\b`,
+	"\f", `\f`,
+)
+
+// LIKE operator treats these as wildcards, they must be matched literally.
+var soqlLikeEscaper = strings.NewReplacer(`%`, `\%`, `_`, `\_`) // nolint:gochecknoglobals
+
+// makeSOQLCondition converts filter expression into condition of SOQL WHERE clause.
+// Field names are checked, so that the condition cannot be altered by the field.
+func makeSOQLCondition(expr *common.FilterExpr) (string, error) {
+	if !expr.IsLogical() && !soqlName.MatchString(expr.Field) {
+		return "", fmt.Errorf("%w: invalid field name %q", common.ErrInvalidFilter, expr.Field)
+	}
+
+	switch expr.Operator { // nolint:exhaustive
+	case common.FilterOperatorAnd, common.FilterOperatorOr:
+		conditions := make([]string, len(expr.Operands))
+
+		for index, operand := range expr.Operands {
+			condition, err := makeSOQLCondition(operand)
+			if err != nil {
+				return "", err
+			}
+
+			conditions[index] = condition
+		}
+
+		separator := " AND "
+		if expr.Operator == common.FilterOperatorOr {
+			separator = " OR "
+		}
+
+		return "(" + strings.Join(conditions, separator) + ")", nil
+	case common.FilterOperatorIn:
+		values := make([]string, len(expr.Values))
+
+		for index, value := range expr.Values {
+			literal, err := makeSOQLLiteral(expr, value)
+			if err != nil {
+				return "", err
+			}
+
+			values[index] = literal
+		}
+
+		return fmt.Sprintf("%v IN (%v)", expr.Field, strings.Join(values, ",")), nil
+	case common.FilterOperatorContains:
+		text, _ := expr.Value.(string)
+
+		return fmt.Sprintf("%v LIKE '%%%v%%'", expr.Field, soqlLikeEscaper.Replace(soqlStringEscaper.Replace(text))), nil
+	}
+
+	operator, ok := soqlOperators[expr.Operator]
+	if !ok {
+		return "", common.NewUnsupportedFilterError(expr, "operator is not supported")
+	}
+
+	literal, err := makeSOQLLiteral(expr, expr.Value)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%v %v %v", expr.Field, operator, literal), nil
+}
+
+func makeSOQLLiteral(expr *common.FilterExpr, value any) (string, error) {
+	literal, ok := soqlLiteral(value)
+	if !ok {
+		return "", common.NewUnsupportedFilterError(expr, fmt.Sprintf("value of type %T is not supported", value))
+	}
+
+	return literal, nil
+}
+
+// soqlLiteral formats the value as SOQL literal, strings are quoted and escaped.
+func soqlLiteral(value any) (string, bool) {
+	switch val := value.(type) {
+	case nil:
+		return "null", true
+	case string:
+		return "'" + soqlStringEscaper.Replace(val) + "'", true
+	case bool:
+		return strconv.FormatBool(val), true
+	case int:
+		return strconv.Itoa(val), true
+	case int64:
+		return strconv.FormatInt(val, 10), true
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), true
+	case time.Time:
+		return handy.Time.FormatRFC3339inUTC(val), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,69 @@
+package salesforce
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestMakeSOQLCondition(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        *common.FilterExpr
+		expected     string
+		expectedErrs []error
+	}{
+		{
+			name:     "Equality with escaped text",
+			input:    common.FilterEq("Name", `O'Brien`),
+			expected: `Name = 'O\'Brien'`,
+		},
+		{
+			name:     "Numbers and booleans are not quoted",
+			input:    common.FilterAnd(common.FilterGt("NumberOfEmployees", 10), common.FilterNe("IsDeleted", true)),
+			expected: `(NumberOfEmployees > 10 AND IsDeleted != true)`,
+		},
+		{
+			name:     "Datetime literal",
+			input:    common.FilterLt("CreatedDate", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+			expected: `CreatedDate < 2024-01-02T03:04:05Z`,
+		},
+		{
+			name: "Nested disjunction with IN and LIKE",
+			input: common.FilterOr(
+				common.FilterIn("Industry", "Energy", "Media"),
+				common.FilterContains("Name", "50%_off"),
+			),
+			expected: `(Industry IN ('Energy','Media') OR Name LIKE '%50\%\_off%')`,
+		},
+		{
+			name:         "Field names cannot carry conditions",
+			input:        common.FilterEq("Name = 'x' OR Id", "y"),
+			expectedErrs: []error{common.ErrInvalidFilter},
+		},
+		{
+			name:         "Unsupported value type",
+			input:        common.FilterEq("Name", []string{"a"}),
+			expectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := makeSOQLCondition(tt.input)
+			testutils.CheckErrors(t, tt.name, tt.expectedErrs, err)
+
+			if output != tt.expected {
+				t.Fatalf("%s: expected: (%v), got: (%v)", tt.name, tt.expected, output)
+			}
+		})
+	}
+}
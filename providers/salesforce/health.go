@@ -0,0 +1,46 @@
+package salesforce
+
+import (
+	"context"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// dailyQuotaWindow is the rolling period of the daily API requests limit.
+const dailyQuotaWindow = 24 * time.Hour
+
+// CheckConnection validates credentials by reading org limits, which is cheap and available to any API user.
+// Quota reports daily API requests. Scopes are known only for OAuth tokens issued with the "scope" field.
+// https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_limits.htm
+func (c *Connector) CheckConnection(ctx context.Context) (*common.HealthReport, error) {
+	report := &common.HealthReport{
+		CheckedAt: time.Now(),
+		Account:   c.BaseURL,
+	}
+
+	limits, err := c.Limits(ctx)
+	report.Latency = time.Since(report.CheckedAt)
+
+	if err != nil {
+		return report, common.ClassifyConnectionError(err)
+	}
+
+	if limits == nil {
+		return report, common.ErrEmptyJSONHTTPResponse
+	}
+
+	report.Healthy = true
+	report.Quota = &common.APIQuota{
+		Limit:     int64(limits.DailyAPIRequests.Max),
+		Remaining: int64(limits.DailyAPIRequests.Remaining),
+		Window:    dailyQuotaWindow,
+	}
+
+	token, _, err := common.OAuthToken(ctx, c.Client.HTTPClient.Client)
+	if err == nil {
+		report.Scopes = common.OAuthTokenScopes(token)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,111 @@
+package salesforce
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+)
+
+func TestCheckConnection(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []checkConnectionTestCase{
+		{
+			Name: "Healthy connection reports daily quota",
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.PathSuffix("/services/data/v59.0/limits"),
+				Then: mockserver.ResponseString(http.StatusOK, `{
+					"DailyApiRequests": {"Max": 15000, "Remaining": 14250}
+				}`),
+			}.Server(),
+			Comparator: healthReportComparator,
+			Expected: &common.HealthReport{
+				Healthy: true,
+				Quota: &common.APIQuota{
+					Limit:     15000,
+					Remaining: 14250,
+					Window:    24 * time.Hour,
+				},
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Expired session is an invalid access token",
+			Server: mockserver.Fixed{
+				Setup: mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusUnauthorized, `[{
+					"message": "Session expired or invalid",
+					"errorCode": "INVALID_SESSION_ID"
+				}]`),
+			}.Server(),
+			Comparator:   healthReportComparator,
+			Expected:     &common.HealthReport{},
+			ExpectedErrs: []error{common.ErrAccessToken, common.ErrInvalidSessionId},
+		},
+		{
+			Name: "Disabled API is reported",
+			Server: mockserver.Fixed{
+				Setup: mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusForbidden, `[{
+					"message": "The REST API is not enabled for this Organization.",
+					"errorCode": "API_DISABLED_FOR_ORG"
+				}]`),
+			}.Server(),
+			Comparator:   healthReportComparator,
+			Expected:     &common.HealthReport{},
+			ExpectedErrs: []error{common.ErrApiDisabled},
+		},
+		{
+			Name: "Unknown 403 is forbidden",
+			Server: mockserver.Fixed{
+				Setup:  mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusForbidden, `[]`),
+			}.Server(),
+			Comparator:   healthReportComparator,
+			Expected:     &common.HealthReport{},
+			ExpectedErrs: []error{common.ErrForbidden},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+// healthReportComparator ignores timing of the check, the account must be the instance URL.
+func healthReportComparator(serverURL string, actual, expected *common.HealthReport) bool {
+	return actual != nil &&
+		actual.Healthy == expected.Healthy &&
+		actual.Account == serverURL &&
+		!actual.CheckedAt.IsZero() &&
+		reflect.DeepEqual(actual.Scopes, expected.Scopes) &&
+		reflect.DeepEqual(actual.Quota, expected.Quota)
+}
+
+type (
+	checkConnectionTestCaseType = testroutines.TestCase[struct{}, *common.HealthReport]
+	checkConnectionTestCase     checkConnectionTestCaseType
+)
+
+func (c checkConnectionTestCase) Run(t *testing.T, builder testroutines.ConnectorBuilder[*Connector]) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	output, err := conn.CheckConnection(context.Background())
+	checkConnectionTestCaseType(c).Validate(t, err, output)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/handy"
 )
 
 // ListObjectMetadata returns object metadata for each object name provided.
@@ -82,31 +83,82 @@ func constructResponseMap(response *common.JSONHTTPResponse) (*common.ListObject
 				"%w: %s", ErrCannotReadMetadata, string(subRes.Body),
 			)
 		} else {
-			objectsMap.Result[strings.ToLower(result.Name)] = common.ObjectMetadata{
-				DisplayName: result.Label,
-				// Map that satisfies type constraint
-				FieldsMap: makeFieldsMap(result.Fields),
-			}
+			objectsMap.Result[strings.ToLower(result.Name)] = makeObjectMetadata(result)
 		}
 	}
 
 	return objectsMap, nil
 }
 
-// makeFieldsMap constructs a map of field names to field labels from a describeSObjectResult.
-func makeFieldsMap(fields []fieldResult) map[string]string {
-	fieldsMap := make(map[string]string)
+// makeObjectMetadata converts describeSObjectResult into object metadata.
+func makeObjectMetadata(result *describeSObjectResult) common.ObjectMetadata {
+	metadata := common.ObjectMetadata{
+		DisplayName: result.Label,
+		FieldsMap:   make(map[string]string),
+		Fields:      make(map[string]common.FieldMetadata),
+	}
+
+	for _, field := range result.Fields {
+		metadata.AddField(strings.ToLower(field.Name), makeFieldMetadata(field))
+	}
 
-	for _, field := range fields {
-		fieldName := strings.ToLower(field.Name)
+	return metadata
+}
+
+func makeFieldMetadata(field fieldResult) common.FieldMetadata {
+	values := make([]common.FieldValue, 0, len(field.PicklistValues))
 
-		// Add entry to fieldsMap
-		fieldsMap[fieldName] = field.Label
+	for _, value := range field.PicklistValues {
+		if value.Active {
+			values = append(values, common.FieldValue{
+				Value:        value.Value,
+				DisplayValue: value.Label,
+			})
+		}
 	}
 
-	return fieldsMap
+	// A value must be supplied on create, unless the field is allowed to be empty or Salesforce fills it in.
+	required := field.Createable && !field.Nillable && !field.DefaultedOnCreate
+
+	return common.FieldMetadata{
+		DisplayName:  field.Label,
+		ValueType:    fieldValueTypes.Get(field.Type),
+		ProviderType: field.Type,
+		Required:     &required,
+		Nullable:     handy.Pointers.Bool(field.Nillable),
+		Createable:   handy.Pointers.Bool(field.Createable),
+		Updateable:   handy.Pointers.Bool(field.Updateable),
+		Values:       values,
+		ReferenceTo:  field.ReferenceTo,
+	}
 }
 
+// Field types of describeSObjectResult.
+// https://developer.salesforce.com/docs/atlas.en-us.api.meta/api/field_types.htm
+var fieldValueTypes = handy.NewDefaultMap(map[string]common.ValueType{ //nolint:gochecknoglobals
+	"string":          common.ValueTypeString,
+	"textarea":        common.ValueTypeString,
+	"email":           common.ValueTypeString,
+	"phone":           common.ValueTypeString,
+	"url":             common.ValueTypeString,
+	"id":              common.ValueTypeString,
+	"combobox":        common.ValueTypeString,
+	"encryptedstring": common.ValueTypeString,
+	"boolean":         common.ValueTypeBoolean,
+	"int":             common.ValueTypeInt,
+	"long":            common.ValueTypeInt,
+	"double":          common.ValueTypeFloat,
+	"currency":        common.ValueTypeFloat,
+	"percent":         common.ValueTypeFloat,
+	"date":            common.ValueTypeDate,
+	"datetime":        common.ValueTypeDateTime,
+	"picklist":        common.ValueTypeSingleSelect,
+	"multipicklist":   common.ValueTypeMultiSelect,
+	"reference":       common.ValueTypeReference,
+}, func(string) common.ValueType {
+	return common.ValueTypeOther
+})
+
 type compositeRequest struct {
 	AllOrNone        bool                   `json:"allOrNone"`
 	CompositeRequest []compositeRequestItem `json:"compositeRequest"`
@@ -146,6 +198,22 @@ type describeSObjectResult struct {
 //
 //nolint:lll
 type fieldResult struct {
-	Name  string `json:"name"`
-	Label string `json:"label"`
+	Name              string           `json:"name"`
+	Label             string           `json:"label"`
+	Type              string           `json:"type"`
+	Nillable          bool             `json:"nillable"`
+	Createable        bool             `json:"createable"`
+	Updateable        bool             `json:"updateable"`
+	DefaultedOnCreate bool             `json:"defaultedOnCreate"`
+	PicklistValues    []picklistResult `json:"picklistValues"`
+	ReferenceTo       []string         `json:"referenceTo"`
+}
+
+// See https://developer.salesforce.com/docs/atlas.en-us.api.meta/api/sforce_api_calls_describesobjects_describesobjectresult.htm#picklistentry.
+//
+//nolint:lll
+type picklistResult struct {
+	Active bool   `json:"active"`
+	Label  string `json:"label"`
+	Value  string `json:"value"`
 }
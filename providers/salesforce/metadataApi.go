@@ -1,41 +1,255 @@
 package salesforce
 
 import (
+	"bytes"
 	"context"
+	"encoding/xml"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/common/xquery"
 )
 
-var ErrCreateMetadata = errors.New("error in CreateMetadata")
+var (
+	ErrCreateMetadata = errors.New("error in CreateMetadata")
+	// ErrMissingSessionToken is returned when the connector's client has no OAuth token to put into SessionHeader.
+	ErrMissingSessionToken = errors.New("metadata API requires OAuth client to provide session id")
+	// ErrMetadataNotSaved is returned along with results when at least one component was not saved or deleted.
+	// Every failed result is joined to the error as MetadataError.
+	ErrMetadataNotSaved = errors.New("metadata was not saved")
+)
+
+// MetadataComponent is one item of metadata, such as a custom object or a custom field.
+// See https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_types_list.htm.
+type MetadataComponent struct {
+	// Type is the metadata type, such as "CustomObject" or "CustomField".
+	Type string
+	// Content is the XML inside the metadata element, including fullName. Example of a custom field:
+	//
+	//	<fullName>Account.Tier__c</fullName><label>Tier</label><type>Text</type><length>20</length>
+	Content string
+}
+
+// MetadataError explains why a component was not saved.
+type MetadataError struct {
+	FullName   string
+	StatusCode string
+	Message    string
+	// Fields which caused the error, when known.
+	Fields []string
+}
+
+func (e MetadataError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", e.FullName, e.StatusCode, e.Message)
+}
 
-// CreateMetadata creates custom metadata.
-// Requires non-expired access token to be passed directly.
-// According to documentation every XML type of request must include SessionHeader with access token.
+// MetadataResult is the outcome of create, update, upsert or delete for one component.
+type MetadataResult struct {
+	FullName string
+	Success  bool
+	// Created is true when upsert created the component, it is false for other operations.
+	Created bool
+	Errors  []MetadataError
+}
+
+// MetadataListQuery selects components to be listed.
+type MetadataListQuery struct {
+	// Type is the metadata type, such as "CustomObject".
+	Type string
+	// Folder is required for types stored in folders, such as "Report" or "EmailTemplate".
+	Folder string
+}
+
+// MetadataFileProperties describes a listed component.
+type MetadataFileProperties struct {
+	FullName           string
+	Type               string
+	FileName           string
+	ID                 string
+	NamespacePrefix    string
+	ManageableState    string
+	CreatedByName      string
+	CreatedDate        time.Time
+	LastModifiedByName string
+	LastModifiedDate   time.Time
+}
+
+// CreateMetadata creates components, all or none of them.
+// nolint: lll
+// https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_createMetadata.htm
+func (c *Connector) CreateMetadata(ctx context.Context, components ...MetadataComponent) ([]MetadataResult, error) {
+	body, err := c.callMetadataAPI(ctx, "createMetadata", metadataElements(components))
+	if err != nil {
+		return nil, errors.Join(ErrCreateMetadata, err)
+	}
+
+	return parseMetadataResults(body)
+}
+
+// ReadMetadata returns components of the type, which are identified by full names.
+// Components which don't exist are omitted.
+// https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_readMetadata.htm
+func (c *Connector) ReadMetadata(
+	ctx context.Context, metadataType string, fullNames ...string,
+) ([]MetadataComponent, error) {
+	body, err := c.callMetadataAPI(ctx, "readMetadata", typeAndFullNames(metadataType, fullNames))
+	if err != nil {
+		return nil, err
+	}
+
+	records := body.FindMany("//records")
+	components := make([]MetadataComponent, 0, len(records))
+
+	for _, record := range records {
+		// Missing components are returned as empty records.
+		if record.FindOne("fullName").IsEmpty() {
+			continue
+		}
+
+		var content strings.Builder
+		for _, child := range record.GetChildren() {
+			content.WriteString(child.RawXML())
+		}
+
+		components = append(components, MetadataComponent{
+			Type:    record.Attr("xsi:type"),
+			Content: content.String(),
+		})
+	}
+
+	return components, nil
+}
+
+// UpdateMetadata changes components, all or none of them. Components must exist.
+// nolint: lll
+// https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_updateMetadata.htm
+func (c *Connector) UpdateMetadata(ctx context.Context, components ...MetadataComponent) ([]MetadataResult, error) {
+	body, err := c.callMetadataAPI(ctx, "updateMetadata", metadataElements(components))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseMetadataResults(body)
+}
+
+// UpsertMetadata creates components or updates those which exist. MetadataResult.Created tells which one happened.
+// nolint: lll
+// https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_upsertMetadata.htm
+func (c *Connector) UpsertMetadata(ctx context.Context, components ...MetadataComponent) ([]MetadataResult, error) {
+	body, err := c.callMetadataAPI(ctx, "upsertMetadata", metadataElements(components))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseMetadataResults(body)
+}
+
+// DeleteMetadata deletes components of the type, which are identified by full names.
+// nolint: lll
+// https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_deleteMetadata.htm
+func (c *Connector) DeleteMetadata(
+	ctx context.Context, metadataType string, fullNames ...string,
+) ([]MetadataResult, error) {
+	body, err := c.callMetadataAPI(ctx, "deleteMetadata", typeAndFullNames(metadataType, fullNames))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseMetadataResults(body)
+}
+
+// ListMetadata returns properties of components matching the queries, at most 3 queries are allowed per call.
+// https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_listmetadata.htm
+func (c *Connector) ListMetadata(
+	ctx context.Context, queries ...MetadataListQuery,
+) ([]MetadataFileProperties, error) {
+	var content strings.Builder
+
+	for _, query := range queries {
+		content.WriteString("<queries>")
+
+		if len(query.Folder) != 0 {
+			content.WriteString("<folder>" + escapeXML(query.Folder) + "</folder>")
+		}
+
+		content.WriteString("<type>" + escapeXML(query.Type) + "</type></queries>")
+	}
+
+	content.WriteString("<asOfVersion>" + apiVersion + "</asOfVersion>")
+
+	body, err := c.callMetadataAPI(ctx, "listMetadata", content.String())
+	if err != nil {
+		return nil, err
+	}
+
+	results := body.FindMany("//result")
+	properties := make([]MetadataFileProperties, len(results))
+
+	for i, result := range results {
+		properties[i] = MetadataFileProperties{
+			FullName:           result.FindOne("fullName").Text(),
+			Type:               result.FindOne("type").Text(),
+			FileName:           result.FindOne("fileName").Text(),
+			ID:                 result.FindOne("id").Text(),
+			NamespacePrefix:    result.FindOne("namespacePrefix").Text(),
+			ManageableState:    result.FindOne("manageableState").Text(),
+			CreatedByName:      result.FindOne("createdByName").Text(),
+			CreatedDate:        parseMetadataTime(result.FindOne("createdDate").Text()),
+			LastModifiedByName: result.FindOne("lastModifiedByName").Text(),
+			LastModifiedDate:   parseMetadataTime(result.FindOne("lastModifiedDate").Text()),
+		}
+	}
+
+	return properties, nil
+}
+
+// callMetadataAPI sends the operation with its content to the Metadata API and returns the response envelope.
+// According to documentation every XML type of request must include SessionHeader with access token,
+// which is taken from the OAuth client of the connector.
 // See: https://developer.salesforce.com/docs/atlas.en-us.api.meta/api/sforce_api_header_sessionheader.htm.
-func (c *Connector) CreateMetadata(ctx context.Context, data []byte, accessToken string) (string, error) {
-	body, err := xquery.NewXML(data)
+func (c *Connector) callMetadataAPI(ctx context.Context, operation, content string) (*xquery.XML, error) {
+	sessionID, err := c.sessionID(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	body, err = putInsideEnvelope(body, accessToken)
+	body, err := xquery.NewXML([]byte("<" + operation + ">" + content + "</" + operation + ">"))
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	body, err = putInsideEnvelope(body, sessionID)
+	if err != nil {
+		return nil, err
 	}
 
 	url, err := c.getSoapURL()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	resp, err := c.XMLClient.Post(ctx, url.String(), body, getSOAPHeaders()...)
 	if err != nil {
-		return "", errors.Join(ErrCreateMetadata, err)
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (c *Connector) sessionID(ctx context.Context) (string, error) {
+	token, ok, err := common.OAuthToken(ctx, c.Client.HTTPClient.Client)
+	if err != nil {
+		return "", err
+	}
+
+	if !ok || token == nil || len(token.AccessToken) == 0 {
+		return "", ErrMissingSessionToken
 	}
 
-	return resp.Body.RawXML(), nil
+	return token.AccessToken, nil
 }
 
 func putInsideEnvelope(content *xquery.XML, accessToken string) (*xquery.XML, error) {
@@ -86,3 +300,84 @@ func getSOAPHeaders() []common.Header {
 		Value: "''",
 	}}
 }
+
+func metadataElements(components []MetadataComponent) string {
+	var content strings.Builder
+
+	for _, component := range components {
+		content.WriteString(`<metadata xsi:type="` + escapeXML(component.Type) + `">`)
+		content.WriteString(component.Content)
+		content.WriteString("</metadata>")
+	}
+
+	return content.String()
+}
+
+func typeAndFullNames(metadataType string, fullNames []string) string {
+	var content strings.Builder
+
+	content.WriteString("<type>" + escapeXML(metadataType) + "</type>")
+
+	for _, fullName := range fullNames {
+		content.WriteString("<fullNames>" + escapeXML(fullName) + "</fullNames>")
+	}
+
+	return content.String()
+}
+
+// parseMetadataResults reads SaveResult, UpsertResult or DeleteResult of every component.
+func parseMetadataResults(body *xquery.XML) ([]MetadataResult, error) {
+	nodes := body.FindMany("//result")
+	results := make([]MetadataResult, len(nodes))
+	failures := []error{ErrMetadataNotSaved}
+
+	for i, node := range nodes {
+		results[i] = MetadataResult{
+			FullName: node.FindOne("fullName").Text(),
+			Success:  node.FindOne("success").Text() == "true",
+			Created:  node.FindOne("created").Text() == "true",
+		}
+
+		for _, errNode := range node.FindMany("errors") {
+			metadataErr := MetadataError{
+				FullName:   results[i].FullName,
+				StatusCode: errNode.FindOne("statusCode").Text(),
+				Message:    strings.TrimSpace(errNode.FindOne("message").Text()),
+			}
+
+			for _, field := range errNode.FindMany("fields") {
+				metadataErr.Fields = append(metadataErr.Fields, field.Text())
+			}
+
+			results[i].Errors = append(results[i].Errors, metadataErr)
+			failures = append(failures, metadataErr)
+		}
+
+		if !results[i].Success && len(results[i].Errors) == 0 {
+			failures = append(failures, MetadataError{FullName: results[i].FullName})
+		}
+	}
+
+	if len(failures) > 1 {
+		return results, errors.Join(failures...)
+	}
+
+	return results, nil
+}
+
+func parseMetadataTime(text string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, text)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return parsed
+}
+
+func escapeXML(text string) string {
+	var buffer bytes.Buffer
+
+	_ = xml.EscapeText(&buffer, []byte(text))
+
+	return buffer.String()
+}
@@ -3,119 +3,350 @@ package salesforce
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
-	"net/http/httptest"
-	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/amp-labs/connectors/common"
-	"github.com/amp-labs/connectors/common/xquery"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
 	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
 	"github.com/amp-labs/connectors/test/utils/testutils"
-	"github.com/go-test/deep"
+	"golang.org/x/oauth2"
 )
 
-func TestCreateMetadata(t *testing.T) { // nolint:funlen,gocognit,cyclop
+var customField = MetadataComponent{ // nolint:gochecknoglobals
+	Type:    "CustomField",
+	Content: "<fullName>Account.Tier__c</fullName><label>Tier</label><type>Text</type><length>20</length>",
+}
+
+func TestCreateMetadata(t *testing.T) { // nolint:funlen
 	t.Parallel()
 
-	request := testutils.DataFromFile(t, "metadata-create-request.xml")
-	response := testutils.DataFromFile(t, "metadata-create-response.xml")
+	responseRolledBack := testutils.DataFromFile(t, "metadata-create-response.xml")
+	responseTokenExpired := testutils.DataFromFile(t, "metadata-create-token-expired.xml")
 
-	successResponse, err := xquery.NewXML(response)
-	if err != nil {
-		t.Fatalf("failed to start test, CreateMetadata response is not XML")
+	tests := []metadataResultsTestCase{
+		{
+			Name:         "Server responded with empty body",
+			Server:       mockserver.Fixed{Always: mockserver.Response(http.StatusOK)}.Server(),
+			ExpectedErrs: []error{common.ErrNotXML},
+		},
+		{
+			Name: "Error token expired is understood",
+			Server: mockserver.Fixed{
+				// 500 is what real server returns
+				Always: mockserver.Response(http.StatusInternalServerError, responseTokenExpired),
+			}.Server(),
+			ExpectedErrs: []error{
+				common.ErrAccessToken,
+				errors.New("INVALID_SESSION_ID"), // nolint:goerr113
+			},
+		},
+		{
+			Name: "Components are sent with session of the client",
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentXML(),
+				If: bodyContains(
+					"<sessionId>test-session</sessionId>",
+					`<createMetadata><metadata xsi:type="CustomField"><fullName>Account.Tier__c</fullName>`,
+				),
+				Then: mockserver.Response(http.StatusOK, responseRolledBack),
+			}.Server(),
+			Expected: []MetadataResult{{
+				FullName: "TestObject15__c",
+				Errors: []MetadataError{{
+					FullName:   "TestObject15__c",
+					StatusCode: "ALL_OR_NONE_OPERATION_ROLLED_BACK",
+					Message: "Record rolled back because not all records were valid and the request was using AllOrNone" +
+						"\n                        header",
+				}},
+			}, {
+				FullName: "TestObject13__c.Comments__c",
+				Errors: []MetadataError{{
+					FullName:   "TestObject13__c.Comments__c",
+					StatusCode: "FIELD_INTEGRITY_EXCEPTION",
+					Message:    "Entity 'TestObject13__c' not found.",
+				}},
+			}},
+			ExpectedErrs: []error{ErrMetadataNotSaved, errors.New("FIELD_INTEGRITY_EXCEPTION")}, // nolint:goerr113
+		},
 	}
 
-	tokenExpired := testutils.DataFromFile(t, "metadata-create-token-expired.xml")
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructMetadataTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestMetadataRequiresOAuthClient(t *testing.T) {
+	t.Parallel()
+
+	server := mockserver.Dummy()
+	defer server.Close()
 
-	tokenExpiredResponse, err := xquery.NewXML(tokenExpired)
+	conn, err := constructTestConnector(server.URL)
 	if err != nil {
-		t.Fatalf("failed to start test, CreateMetadata tokenExpired is not XML")
+		t.Fatal(err)
 	}
 
-	tests := []struct {
-		name         string
-		input        []byte
-		server       *httptest.Server
-		expected     string
-		expectedErrs []error
-	}{
+	if _, err = conn.ListMetadata(context.Background(), MetadataListQuery{Type: "CustomObject"}); !errors.Is(
+		err, ErrMissingSessionToken) {
+		t.Fatalf("expected: (%v), got: (%v)", ErrMissingSessionToken, err)
+	}
+}
+
+func TestUpsertMetadata(t *testing.T) {
+	t.Parallel()
+
+	tests := []metadataResultsTestCase{
 		{
-			name:  "Server responded with empty body",
-			input: request,
-			server: mockserver.Fixed{
-				Always: mockserver.Response(http.StatusOK),
+			Name:  "Created flag is reported",
+			Input: true,
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentXML(),
+				If:    bodyContains("<upsertMetadata><metadata"),
+				Then: mockserver.ResponseString(http.StatusOK, `<?xml version="1.0" encoding="UTF-8"?>
+					<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"
+						xmlns="http://soap.sforce.com/2006/04/metadata"><soapenv:Body><upsertMetadataResponse>
+						<result><created>true</created><fullName>Account.Tier__c</fullName><success>true</success></result>
+					</upsertMetadataResponse></soapenv:Body></soapenv:Envelope>`),
 			}.Server(),
-			expectedErrs: []error{common.ErrNotXML},
+			Expected:     []MetadataResult{{FullName: "Account.Tier__c", Success: true, Created: true}},
+			ExpectedErrs: nil,
 		},
 		{
-			name:  "Error token expired is understood",
-			input: request,
-			server: mockserver.Fixed{
-				// 500 is what real server returns
-				Always: mockserver.ResponseString(http.StatusInternalServerError, tokenExpiredResponse.RawXML()),
+			Name:  "Fault is typed",
+			Input: true,
+			Server: mockserver.Fixed{
+				Setup: mockserver.ContentXML(),
+				Always: mockserver.ResponseString(http.StatusInternalServerError, `<?xml version="1.0" encoding="UTF-8"?>
+					<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"
+						xmlns:sf="http://soap.sforce.com/2006/04/metadata"><soapenv:Body><soapenv:Fault>
+						<faultcode>sf:INVALID_TYPE</faultcode>
+						<faultstring>INVALID_TYPE: This type of object is not available for this organization</faultstring>
+						<detail><sf:UnexpectedErrorFault><sf:exceptionCode>INVALID_TYPE</sf:exceptionCode>
+						</sf:UnexpectedErrorFault></detail>
+					</soapenv:Fault></soapenv:Body></soapenv:Envelope>`),
 			}.Server(),
-			expectedErrs: []error{
-				common.ErrAccessToken,
-				errors.New("INVALID_SESSION_ID"), // nolint:goerr113
-			},
+			ExpectedErrs: []error{common.ErrBadRequest, errors.New("not available")}, // nolint:goerr113
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructMetadataTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestSOAPFault(t *testing.T) {
+	t.Parallel()
+
+	server := mockserver.Fixed{
+		Setup: mockserver.ContentXML(),
+		Always: mockserver.ResponseString(http.StatusInternalServerError, `<soapenv:Envelope
+			xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns:sf="http://soap.sforce.com/2006/04/metadata">
+			<soapenv:Body><soapenv:Fault><faultcode>sf:INVALID_TYPE</faultcode><faultstring>bad type</faultstring>
+			<detail><sf:UnexpectedErrorFault><sf:exceptionCode>INVALID_TYPE</sf:exceptionCode></sf:UnexpectedErrorFault>
+			</detail></soapenv:Fault></soapenv:Body></soapenv:Envelope>`),
+	}.Server()
+	defer server.Close()
+
+	conn, err := constructMetadataTestConnector(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = conn.DeleteMetadata(context.Background(), "CustomField", "Account.Tier__c")
+
+	var fault *SOAPFault
+	if !errors.As(err, &fault) {
+		t.Fatalf("expected SOAP fault, got: (%v)", err)
+	}
+
+	if fault.Code != "INVALID_TYPE" || fault.ExceptionCode != "INVALID_TYPE" || fault.Message != "bad type" {
+		t.Fatalf("unexpected fault %+v", fault)
+	}
+}
+
+func TestReadMetadata(t *testing.T) {
+	t.Parallel()
+
+	tests := []readMetadataTestCase{
+		{
+			Name:  "Existing components are returned",
+			Input: []string{"Account.Tier__c", "Account.Missing__c"},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentXML(),
+				If: bodyContains("<readMetadata><type>CustomField</type>" +
+					"<fullNames>Account.Tier__c</fullNames><fullNames>Account.Missing__c</fullNames></readMetadata>"),
+				Then: mockserver.ResponseString(http.StatusOK, `<?xml version="1.0" encoding="UTF-8"?>
+					<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"
+						xmlns="http://soap.sforce.com/2006/04/metadata"
+						xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><soapenv:Body><readMetadataResponse>
+						<result><records xsi:type="CustomField"><fullName>Account.Tier__c</fullName><label>Tier</label>
+						<type>Text</type><length>20</length></records><records xsi:nil="true"/></result>
+					</readMetadataResponse></soapenv:Body></soapenv:Envelope>`),
+			}.Server(),
+			Expected:     []MetadataComponent{customField},
+			ExpectedErrs: nil,
 		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructMetadataTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestListMetadata(t *testing.T) {
+	t.Parallel()
+
+	tests := []listMetadataTestCase{
 		{
-			name:  "Successful response given valid request",
-			input: request,
-			server: mockserver.Fixed{
-				Always: mockserver.ResponseString(http.StatusOK, successResponse.RawXML()),
+			Name:  "File properties are returned",
+			Input: []MetadataListQuery{{Type: "CustomObject"}},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentXML(),
+				If: bodyContains("<listMetadata><queries><type>CustomObject</type></queries>" +
+					"<asOfVersion>59.0</asOfVersion></listMetadata>"),
+				Then: mockserver.ResponseString(http.StatusOK, `<?xml version="1.0" encoding="UTF-8"?>
+					<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"
+						xmlns="http://soap.sforce.com/2006/04/metadata"><soapenv:Body><listMetadataResponse><result>
+						<createdById>005ak000001</createdById><createdByName>Ada Lovelace</createdByName>
+						<createdDate>2024-10-17T08:00:00.000Z</createdDate><fileName>objects/Invoice__c.object</fileName>
+						<fullName>Invoice__c</fullName><id>01Iak0000001</id>
+						<lastModifiedByName>Ada Lovelace</lastModifiedByName>
+						<lastModifiedDate>2024-10-17T09:00:00.000Z</lastModifiedDate>
+						<manageableState>unmanaged</manageableState><type>CustomObject</type>
+					</result></listMetadataResponse></soapenv:Body></soapenv:Envelope>`),
 			}.Server(),
-			expected:     successResponse.RawXML(),
-			expectedErrs: nil,
+			Expected: []MetadataFileProperties{{
+				FullName:           "Invoice__c",
+				Type:               "CustomObject",
+				FileName:           "objects/Invoice__c.object",
+				ID:                 "01Iak0000001",
+				ManageableState:    "unmanaged",
+				CreatedByName:      "Ada Lovelace",
+				CreatedDate:        time.Date(2024, 10, 17, 8, 0, 0, 0, time.UTC),
+				LastModifiedByName: "Ada Lovelace",
+				LastModifiedDate:   time.Date(2024, 10, 17, 9, 0, 0, 0, time.UTC),
+			}},
+			ExpectedErrs: nil,
 		},
 	}
 
 	for _, tt := range tests {
 		// nolint:varnamelen
 		tt := tt // rebind, omit loop side effects for parallel goroutine
-		t.Run(tt.name, func(t *testing.T) {
+		t.Run(tt.Name, func(t *testing.T) {
 			t.Parallel()
 
-			defer tt.server.Close()
+			tt.Run(t, func() (*Connector, error) {
+				return constructMetadataTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+// bodyContains checks the SOAP envelope for parts of the request.
+func bodyContains(parts ...string) mockcond.Check {
+	return func(w http.ResponseWriter, r *http.Request) bool {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return false
+		}
 
-			connector, err := NewConnector(
-				WithAuthenticatedClient(http.DefaultClient),
-				WithWorkspace("test-workspace"),
-			)
-			if err != nil {
-				t.Fatalf("%s: error in test while constructing connector %v", tt.name, err)
+		for _, part := range parts {
+			if !strings.Contains(string(body), part) {
+				return false
 			}
+		}
 
-			// for testing we want to redirect calls to our mock server
-			connector.setBaseURL(tt.server.URL)
-
-			// start of tests
-			output, err := connector.CreateMetadata(context.Background(), tt.input, "access_token_testing")
-			if err != nil {
-				if len(tt.expectedErrs) == 0 {
-					t.Fatalf("%s: expected no errors, got: (%v)", tt.name, err)
-				}
-			} else {
-				// check that missing error is what is expected
-				if len(tt.expectedErrs) != 0 {
-					t.Fatalf("%s: expected errors (%v), but got nothing", tt.name, tt.expectedErrs)
-				}
-			}
+		return true
+	}
+}
 
-			// check every error
-			for _, expectedErr := range tt.expectedErrs {
-				if !errors.Is(err, expectedErr) && !strings.Contains(err.Error(), expectedErr.Error()) {
-					t.Fatalf("%s: expected Error: (%v), got: (%v)", tt.name, expectedErr, err)
-				}
-			}
+// constructMetadataTestConnector uses OAuth client, which provides session id to the Metadata API.
+func constructMetadataTestConnector(serverURL string) (*Connector, error) {
+	client, err := common.NewOAuthHTTPClient(context.Background(),
+		common.WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-session"})))
+	if err != nil {
+		return nil, err
+	}
 
-			if !reflect.DeepEqual(output, tt.expected) {
-				diff := deep.Equal(output, tt.expected)
-				t.Fatalf("%s:, \nexpected: (%v), \ngot: (%v), \ndiff: (%v)",
-					tt.name, tt.expected, output, diff)
-			}
-		})
+	connector, err := NewConnector(
+		WithAuthenticatedClient(client),
+		WithWorkspace("test-workspace"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// for testing we want to redirect calls to our mock server
+	connector.setBaseURL(serverURL)
+
+	return connector, nil
+}
+
+type (
+	// metadataResultsTestCase input tells whether to create or upsert.
+	metadataResultsTestCaseType = testroutines.TestCase[bool, []MetadataResult]
+	metadataResultsTestCase     metadataResultsTestCaseType
+
+	readMetadataTestCaseType = testroutines.TestCase[[]string, []MetadataComponent]
+	readMetadataTestCase     readMetadataTestCaseType
+
+	listMetadataTestCaseType = testroutines.TestCase[[]MetadataListQuery, []MetadataFileProperties]
+	listMetadataTestCase     listMetadataTestCaseType
+)
+
+func (c metadataResultsTestCase) Run(t *testing.T, builder testroutines.ConnectorBuilder[*Connector]) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+
+	write := conn.CreateMetadata
+	if c.Input {
+		write = conn.UpsertMetadata
 	}
+
+	output, err := write(context.Background(), customField)
+	metadataResultsTestCaseType(c).Validate(t, err, output)
+}
+
+func (c readMetadataTestCase) Run(t *testing.T, builder testroutines.ConnectorBuilder[*Connector]) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	output, err := conn.ReadMetadata(context.Background(), "CustomField", c.Input...)
+	readMetadataTestCaseType(c).Validate(t, err, output)
+}
+
+func (c listMetadataTestCase) Run(t *testing.T, builder testroutines.ConnectorBuilder[*Connector]) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	output, err := conn.ListMetadata(context.Background(), c.Input...)
+	listMetadataTestCaseType(c).Validate(t, err, output)
 }
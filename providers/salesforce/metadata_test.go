@@ -6,6 +6,8 @@ import (
 
 	"github.com/amp-labs/connectors"
 	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/handy"
+	"github.com/amp-labs/connectors/test/utils/mockutils"
 	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
 	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
 	"github.com/amp-labs/connectors/test/utils/testroutines"
@@ -16,6 +18,7 @@ func TestListObjectMetadata(t *testing.T) { // nolint:funlen,gocognit,cyclop
 	t.Parallel()
 
 	responseOrgMeta := testutils.DataFromFile(t, "organization-metadata.json")
+	responseLeadMeta := testutils.DataFromFile(t, "lead-metadata.json")
 
 	tests := []testroutines.Metadata{
 		{
@@ -44,6 +47,11 @@ func TestListObjectMetadata(t *testing.T) { // nolint:funlen,gocognit,cyclop
 				}]}`),
 				Then: mockserver.Response(http.StatusOK, responseOrgMeta),
 			}.Server(),
+			Comparator: func(baseURL string, actual, expected *common.ListObjectMetadataResult) bool {
+				return mockutils.MetadataResultComparator.SubsetFields(actual, expected) &&
+					len(actual.Result["organization"].FieldsMap) == len(expected.Result["organization"].FieldsMap) &&
+					len(actual.Errors) == 0
+			},
 			Expected: &common.ListObjectMetadataResult{
 				Result: map[string]common.ObjectMetadata{
 					"organization": {
@@ -110,6 +118,93 @@ func TestListObjectMetadata(t *testing.T) { // nolint:funlen,gocognit,cyclop
 			},
 			ExpectedErrs: nil,
 		},
+		{
+			Name:  "Field types, flags, picklist values and references are described",
+			Input: []string{"Lead"},
+			Server: mockserver.Fixed{
+				Setup:  mockserver.ContentJSON(),
+				Always: mockserver.Response(http.StatusOK, responseLeadMeta),
+			}.Server(),
+			Comparator: func(baseURL string, actual, expected *common.ListObjectMetadataResult) bool {
+				return mockutils.MetadataResultComparator.SubsetFields(actual, expected)
+			},
+			Expected: &common.ListObjectMetadataResult{
+				Result: map[string]common.ObjectMetadata{
+					"lead": {
+						DisplayName: "Lead",
+						FieldsMap: map[string]string{
+							"id":       "Lead ID",
+							"lastname": "Last Name",
+						},
+						Fields: map[string]common.FieldMetadata{
+							"id": {
+								DisplayName:  "Lead ID",
+								ValueType:    common.ValueTypeString,
+								ProviderType: "id",
+								Required:     handy.Pointers.Bool(false),
+								Nullable:     handy.Pointers.Bool(false),
+								Createable:   handy.Pointers.Bool(false),
+								Updateable:   handy.Pointers.Bool(false),
+								Values:       []common.FieldValue{},
+								ReferenceTo:  []string{},
+							},
+							"lastname": {
+								DisplayName:  "Last Name",
+								ValueType:    common.ValueTypeString,
+								ProviderType: "string",
+								Required:     handy.Pointers.Bool(true),
+								Nullable:     handy.Pointers.Bool(false),
+								Createable:   handy.Pointers.Bool(true),
+								Updateable:   handy.Pointers.Bool(true),
+								Values:       []common.FieldValue{},
+								ReferenceTo:  []string{},
+							},
+							"status": {
+								DisplayName:  "Status",
+								ValueType:    common.ValueTypeSingleSelect,
+								ProviderType: "picklist",
+								Required:     handy.Pointers.Bool(false),
+								Nullable:     handy.Pointers.Bool(false),
+								Createable:   handy.Pointers.Bool(true),
+								Updateable:   handy.Pointers.Bool(true),
+								Values: []common.FieldValue{{
+									Value:        "Open - Not Contacted",
+									DisplayValue: "Open - Not Contacted",
+								}, {
+									Value:        "Closed - Converted",
+									DisplayValue: "Closed - Converted",
+								}},
+								ReferenceTo: []string{},
+							},
+							"ownerid": {
+								DisplayName:  "Owner ID",
+								ValueType:    common.ValueTypeReference,
+								ProviderType: "reference",
+								Required:     handy.Pointers.Bool(false),
+								Nullable:     handy.Pointers.Bool(false),
+								Createable:   handy.Pointers.Bool(true),
+								Updateable:   handy.Pointers.Bool(true),
+								Values:       []common.FieldValue{},
+								ReferenceTo:  []string{"Group", "User"},
+							},
+							"annualrevenue": {
+								DisplayName:  "Annual Revenue",
+								ValueType:    common.ValueTypeFloat,
+								ProviderType: "currency",
+								Required:     handy.Pointers.Bool(false),
+								Nullable:     handy.Pointers.Bool(true),
+								Createable:   handy.Pointers.Bool(true),
+								Updateable:   handy.Pointers.Bool(true),
+								Values:       []common.FieldValue{},
+								ReferenceTo:  []string{},
+							},
+						},
+					},
+				},
+				Errors: map[string]error{},
+			},
+			ExpectedErrs: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -15,6 +15,10 @@ func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common
 		return nil, err
 	}
 
+	if err := config.ValidateNoAssociations(); err != nil {
+		return nil, err
+	}
+
 	url, err := c.buildReadURL(config)
 	if err != nil {
 		return nil, err
@@ -29,7 +33,7 @@ func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common
 		rsp,
 		getRecords,
 		getNextRecordsURL,
-		common.GetMarshaledData,
+		common.MakeMarshaledDataFunc(config.PreserveFieldCase),
 		config.Fields,
 	)
 }
@@ -48,14 +52,85 @@ func (c *Connector) buildReadURL(config common.ReadParams) (*urlbuilder.URL, err
 		return nil, err
 	}
 
-	url.WithQueryParam("q", makeSOQL(config).String())
+	soql, err := makeSOQL(config)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := soql.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	url.WithQueryParam("q", query)
+
+	return url, nil
+}
+
+// Query reads records matching the SOQL query. Records are returned in pages,
+// the following page is read by passing the query again with the NextPage of the result set by SOQL.Page.
+//
+//	result, err := conn.Query(ctx, salesforce.NewSOQL("Contact").Select("Id", "Account.Name"))
+//	next, err := conn.Query(ctx, query.Page(result.NextPage))
+//
+// https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_query.htm
+func (c *Connector) Query(ctx context.Context, soql *SOQL) (*common.ReadResult, error) {
+	url, err := c.buildQueryURL(soql)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := c.Client.Get(ctx, url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return common.ParseResult(
+		rsp,
+		getRecords,
+		getNextRecordsURL,
+		// Fields keep the casing in which they were selected.
+		common.MakeMarshaledDataFunc(true),
+		handy.NewSetFromList(soql.selectedFields()),
+	)
+}
+
+func (c *Connector) buildQueryURL(soql *SOQL) (*urlbuilder.URL, error) {
+	query, err := soql.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(soql.nextPage) != 0 {
+		return c.getDomainURL(soql.nextPage.String())
+	}
+
+	// Deleted and archived records are only returned by queryAll.
+	// https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_queryall.htm
+	resource := "query"
+	if soql.includeDeleted {
+		resource = "queryAll"
+	}
+
+	url, err := c.getRestApiURL(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	url.WithQueryParam("q", query)
 
 	return url, nil
 }
 
 // makeSOQL returns the SOQL query for the desired read operation.
-func makeSOQL(config common.ReadParams) *soqlBuilder {
-	soql := (&soqlBuilder{}).SelectFields(config.Fields.List()).From(config.ObjectName)
+func makeSOQL(config common.ReadParams) (*SOQL, error) {
+	fields := config.Fields.List()
+	if config.Fields.Has("*") {
+		// Listing fields besides all of them would select them twice.
+		fields = []string{FieldsAll}
+	}
+
+	soql := NewSOQL(config.ObjectName).selectTrusted(fields...)
 
 	// If Since is not set, then we're doing a backfill. We read all rows (in pages)
 	if !config.Since.IsZero() {
@@ -66,11 +141,14 @@ func makeSOQL(config common.ReadParams) *soqlBuilder {
 		soql.Where("IsDeleted = true")
 	}
 
-	// TODO: When we support builder facing filters, we should escape the
-	// filter string to avoid SOQL injection.
+	// Filter is trusted SOQL, values coming from builders should be passed through FilterBy.
 	if config.Filter != "" {
 		soql.Where(config.Filter)
 	}
 
-	return soql
+	if config.FilterBy != nil {
+		soql.WhereFilter(config.FilterBy)
+	}
+
+	return soql, nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/common/jsonquery"
 	"github.com/amp-labs/connectors/test/utils/mockutils"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
 	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
 	"github.com/amp-labs/connectors/test/utils/testroutines"
 	"github.com/amp-labs/connectors/test/utils/testutils"
@@ -33,6 +34,16 @@ func TestRead(t *testing.T) { //nolint:funlen,gocognit,cyclop,maintidx
 			Server:       mockserver.Dummy(),
 			ExpectedErrs: []error{common.ErrMissingFields},
 		},
+		{
+			Name: "Associated objects are not supported",
+			Input: common.ReadParams{
+				ObjectName:        "contacts",
+				Fields:            connectors.Fields("Name"),
+				AssociatedObjects: []string{"accounts"},
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
 		{
 			Name:  "Correct error message is understood from JSON response",
 			Input: common.ReadParams{ObjectName: "leads", Fields: connectors.Fields("Name")},
@@ -97,6 +108,29 @@ func TestRead(t *testing.T) { //nolint:funlen,gocognit,cyclop,maintidx
 			},
 			ExpectedErrs: nil,
 		},
+		{
+			Name:  "Fields wrapped in functions are selected as is",
+			Input: common.ReadParams{ObjectName: "Lead", Fields: connectors.Fields("toLabel(Status)")},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.QueryParam("q", "SELECT toLabel(Status) FROM Lead"),
+				Then: mockserver.ResponseString(http.StatusOK, `{"totalSize": 1, "done": true,
+					"records": [{"Status": "Open - Not Contacted"}]}`),
+			}.Server(),
+			Comparator: func(baseURL string, actual, expected *common.ReadResult) bool {
+				return mockutils.ReadResultComparator.SubsetRaw(actual, expected) &&
+					actual.Done == expected.Done &&
+					actual.Rows == expected.Rows
+			},
+			Expected: &common.ReadResult{
+				Rows: 1,
+				Data: []common.ReadResultRow{{
+					Raw: map[string]any{"Status": "Open - Not Contacted"},
+				}},
+				Done: true,
+			},
+			ExpectedErrs: nil,
+		},
 		{
 			Name: "Successful read with chosen fields",
 			Input: common.ReadParams{
@@ -0,0 +1,190 @@
+package salesforce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// ErrMissingSearchTerm is returned when there is nothing to search for.
+var ErrMissingSearchTerm = errors.New("missing search term")
+
+// SearchScope tells which fields are searched.
+type SearchScope string
+
+const (
+	SearchAllFields   SearchScope = "ALL"
+	SearchNameFields  SearchScope = "NAME"
+	SearchEmailFields SearchScope = "EMAIL"
+	SearchPhoneFields SearchScope = "PHONE"
+)
+
+// Characters that have meaning in SOSL search terms, they must be escaped to be searched literally.
+// https://developer.salesforce.com/docs/atlas.en-us.soql_sosl.meta/soql_sosl/sforce_api_calls_sosl_find.htm
+var soslEscaper = strings.NewReplacer( // nolint:gochecknoglobals
+	`\`, `\\`, `?`, `\?`, `&`, `\&`, `|`, `\|`, `!`, `\!`, `{`, `\{`, `}`, `\}`,
+	`[`, `\[`, `]`, `\]`, `(`, `\(`, `)`, `\)`, `^`, `\^`, `~`, `\~`, `*`, `\*`,
+	`:`, `\:`, `"`, `\"`, `'`, `\'`, `+`, `\+`, `-`, `\-`,
+)
+
+// EscapeSOSL escapes the search term of a SOSL FIND clause, so that wildcards and operators are searched literally.
+func EscapeSOSL(term string) string {
+	return soslEscaper.Replace(term)
+}
+
+// SearchParams describes a full-text search across objects.
+type SearchParams struct {
+	// Term is searched literally.
+	Term string
+	// In tells which fields are searched, all of them by default.
+	In SearchScope
+	// Objects lists objects to return. When empty, every searchable object is returned with its Id only.
+	Objects []SearchObject
+	// Limit caps the number of records of all objects, Salesforce allows at most 2000.
+	Limit int
+}
+
+// SearchObject is an object returned by the search.
+type SearchObject struct {
+	Name   string
+	Fields []string
+	// Limit caps the number of records of this object.
+	Limit int
+}
+
+// SearchResult holds records found by the search.
+type SearchResult struct {
+	// Records are grouped per object name, in order of relevance.
+	Records map[string][]common.ReadResultRow
+}
+
+// Search finds records containing the term, using parameterized search which needs no SOSL.
+// https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_search_parameterized.htm
+func (c *Connector) Search(ctx context.Context, params SearchParams) (*SearchResult, error) {
+	if params.Term == "" {
+		return nil, ErrMissingSearchTerm
+	}
+
+	url, err := c.getRestApiURL("parameterizedSearch")
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := c.Client.Post(ctx, url.String(), makeSearchPayload(params))
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string][]string, len(params.Objects))
+	for _, object := range params.Objects {
+		fields[object.Name] = object.Fields
+	}
+
+	return parseSearchResult(rsp, fields)
+}
+
+// SearchSOSL runs the SOSL query. Search terms which come from users must be escaped with EscapeSOSL.
+// Returned rows have all fields of the record.
+//
+//	conn.SearchSOSL(ctx, "FIND {"+salesforce.EscapeSOSL(term)+"} IN EMAIL FIELDS RETURNING Contact(Id, Email)")
+//
+// https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_search.htm
+func (c *Connector) SearchSOSL(ctx context.Context, sosl string) (*SearchResult, error) {
+	url, err := c.getRestApiURL("search")
+	if err != nil {
+		return nil, err
+	}
+
+	url.WithQueryParam("q", sosl)
+
+	rsp, err := c.Client.Get(ctx, url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSearchResult(rsp, nil)
+}
+
+type searchPayload struct {
+	Query        string               `json:"q"`
+	In           SearchScope          `json:"in,omitempty"`
+	Objects      []searchPayloadEntry `json:"sobjects,omitempty"`
+	OverallLimit int                  `json:"overallLimit,omitempty"`
+}
+
+type searchPayloadEntry struct {
+	Name   string   `json:"name"`
+	Fields []string `json:"fields,omitempty"`
+	Limit  int      `json:"limit,omitempty"`
+}
+
+func makeSearchPayload(params SearchParams) *searchPayload {
+	payload := &searchPayload{
+		// Parameterized search still understands wildcards and operators in the term.
+		Query:        EscapeSOSL(params.Term),
+		In:           params.In,
+		Objects:      make([]searchPayloadEntry, len(params.Objects)),
+		OverallLimit: params.Limit,
+	}
+
+	for index, object := range params.Objects {
+		payload.Objects[index] = searchPayloadEntry(object)
+	}
+
+	return payload
+}
+
+type searchResponse struct {
+	SearchRecords []map[string]any `json:"searchRecords"`
+}
+
+// parseSearchResult groups records by their type. Rows have the fields requested for the object,
+// or all fields of the record when none were requested.
+func parseSearchResult(rsp *common.JSONHTTPResponse, fields map[string][]string) (*SearchResult, error) {
+	response, err := common.UnmarshalJSON[searchResponse](rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SearchResult{Records: make(map[string][]common.ReadResultRow)}
+
+	if response == nil {
+		return result, nil
+	}
+
+	for _, record := range response.SearchRecords {
+		attributes, _ := record["attributes"].(map[string]any)
+
+		objectName, ok := attributes["type"].(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: search record has no type", common.ErrParseError)
+		}
+
+		recordFields, ok := fields[objectName]
+		if !ok || len(recordFields) == 0 {
+			recordFields = recordKeys(record)
+		}
+
+		result.Records[objectName] = append(result.Records[objectName], common.ReadResultRow{
+			Fields: common.ExtractFieldsFromRaw(recordFields, record, true),
+			Raw:    record,
+		})
+	}
+
+	return result, nil
+}
+
+func recordKeys(record map[string]any) []string {
+	keys := make([]string, 0, len(record))
+
+	for key := range record {
+		if key != "attributes" {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
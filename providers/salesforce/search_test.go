@@ -0,0 +1,165 @@
+package salesforce
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+)
+
+func TestSearch(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []searchTestCase{
+		{
+			Name:         "Search term is required",
+			Input:        SearchParams{},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{ErrMissingSearchTerm},
+		},
+		{
+			Name: "Records are grouped by object",
+			Input: SearchParams{
+				Term: "acme@",
+				In:   SearchEmailFields,
+				Objects: []SearchObject{
+					{Name: "Contact", Fields: []string{"Id", "Email"}, Limit: 10},
+					{Name: "Lead", Fields: []string{"Id"}},
+				},
+				Limit: 50,
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPOST(),
+					mockcond.PathSuffix("/services/data/v59.0/parameterizedSearch"),
+					mockcond.Body(`{"q": "acme@", "in": "EMAIL", "overallLimit": 50, "sobjects": [
+						{"name": "Contact", "fields": ["Id", "Email"], "limit": 10},
+						{"name": "Lead", "fields": ["Id"]}]}`),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{"searchRecords": [
+					{"attributes": {"type": "Contact"}, "Id": "003ak1", "Email": "bob@acme.com"},
+					{"attributes": {"type": "Lead"}, "Id": "00Qak1"},
+					{"attributes": {"type": "Contact"}, "Id": "003ak2", "Email": "ann@acme.com"}]}`),
+			}.Server(),
+			Expected: &SearchResult{Records: map[string][]common.ReadResultRow{
+				"Contact": {{
+					Fields: map[string]any{"Id": "003ak1", "Email": "bob@acme.com"},
+					Raw: map[string]any{
+						"attributes": map[string]any{"type": "Contact"}, "Id": "003ak1", "Email": "bob@acme.com",
+					},
+				}, {
+					Fields: map[string]any{"Id": "003ak2", "Email": "ann@acme.com"},
+					Raw: map[string]any{
+						"attributes": map[string]any{"type": "Contact"}, "Id": "003ak2", "Email": "ann@acme.com",
+					},
+				}},
+				"Lead": {{
+					Fields: map[string]any{"Id": "00Qak1"},
+					Raw:    map[string]any{"attributes": map[string]any{"type": "Lead"}, "Id": "00Qak1"},
+				}},
+			}},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Operators in the term are searched literally",
+			Input: SearchParams{Term: "AT&T (US)"},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.Body(`{"q": "AT\\&T \\(US\\)"}`),
+				Then:  mockserver.ResponseString(http.StatusOK, `{"searchRecords": []}`),
+			}.Server(),
+			Expected:     &SearchResult{Records: map[string][]common.ReadResultRow{}},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Errors are interpreted",
+			Input: SearchParams{Term: "acme"},
+			Server: mockserver.Fixed{
+				Setup: mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusBadRequest,
+					`[{"errorCode": "INVALID_TYPE", "message": "sObject type 'Accout' is not supported."}]`),
+			}.Server(),
+			ExpectedErrs: []error{common.ErrBadRequest},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestSearchSOSL(t *testing.T) {
+	t.Parallel()
+
+	sosl := "FIND {" + EscapeSOSL("acme-corp") + "} RETURNING Account(Name)"
+
+	tests := []searchSOSLTestCase{
+		{
+			Name:  "All fields of records are returned",
+			Input: sosl,
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.PathSuffix("/services/data/v59.0/search"),
+					mockcond.QueryParam("q", `FIND {acme\-corp} RETURNING Account(Name)`),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{"searchRecords": [
+					{"attributes": {"type": "Account"}, "Name": "Acme Corp"}]}`),
+			}.Server(),
+			Expected: &SearchResult{Records: map[string][]common.ReadResultRow{
+				"Account": {{
+					Fields: map[string]any{"Name": "Acme Corp"},
+					Raw:    map[string]any{"attributes": map[string]any{"type": "Account"}, "Name": "Acme Corp"},
+				}},
+			}},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+type (
+	searchTestCaseType = testroutines.TestCase[SearchParams, *SearchResult]
+	searchTestCase     searchTestCaseType
+
+	searchSOSLTestCaseType = testroutines.TestCase[string, *SearchResult]
+	searchSOSLTestCase     searchSOSLTestCaseType
+)
+
+func (c searchTestCase) Run(t *testing.T, builder testroutines.ConnectorBuilder[*Connector]) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	output, err := conn.Search(context.Background(), c.Input)
+	searchTestCaseType(c).Validate(t, err, output)
+}
+
+func (c searchSOSLTestCase) Run(t *testing.T, builder testroutines.ConnectorBuilder[*Connector]) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	output, err := conn.SearchSOSL(context.Background(), c.Input)
+	searchSOSLTestCaseType(c).Validate(t, err, output)
+}
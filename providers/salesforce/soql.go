@@ -1,62 +1,329 @@
 package salesforce
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// ErrInvalidSOQL is returned when the query cannot be built, because of an invalid name or argument.
+var ErrInvalidSOQL = errors.New("invalid SOQL query")
+
+// Field groups, which select fields without listing them.
+// https://developer.salesforce.com/docs/atlas.en-us.soql_sosl.meta/soql_sosl/sforce_api_calls_soql_select_fields.htm
+const (
+	FieldsAll      = "FIELDS(ALL)"
+	FieldsStandard = "FIELDS(STANDARD)"
+	FieldsCustom   = "FIELDS(CUSTOM)"
 )
 
-// soqlBuilder builder of Salesforce Object Query Language.
-// It constructs query dynamically.
-type soqlBuilder struct {
-	fields string
-	from   string
-	where  []string
-	limit  string
+// FIELDS(ALL) and FIELDS(CUSTOM) are limited to 200 records per query.
+// Error example: `The SOQL FIELDS function must have a LIMIT of at most 200`.
+const maxFieldsGroupLimit = 200
+
+// soqlName matches fields, objects and relationships. Parent fields are reached with dots, as in "Account.Name".
+var soqlName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*(\.[A-Za-z][A-Za-z0-9_]*)*$`) // nolint:gochecknoglobals
+
+// SOQL is a builder of Salesforce Object Query Language.
+// Names are validated and values are escaped, so that the query cannot be altered by the data it is built from.
+// Conditions given to Where are trusted, their values should be passed as arguments.
+//
+//	query := salesforce.NewSOQL("Account").
+//		Select("Id", "Name", "Owner.Name").
+//		SelectSubquery(salesforce.NewSOQL("Contacts").Select("Id", "Email")).
+//		Where("Industry = ? AND AnnualRevenue > ?", industry, 1000000).
+//		OrderBy("Name", false).
+//		Limit(100)
+//
+// https://developer.salesforce.com/docs/atlas.en-us.soql_sosl.meta/soql_sosl/sforce_api_calls_soql_select.htm
+type SOQL struct {
+	fields     []string
+	subqueries []*SOQL
+	from       string
+	where      []string
+	orderBy    []string
+	limit      *int
+	offset     int
+	// includeDeleted reads deleted and archived records as well, using queryAll.
+	includeDeleted bool
+	nextPage       common.NextPageToken
+	err            error
+}
+
+// NewSOQL starts a query of the object. For subqueries the object is the name of the child relationship.
+func NewSOQL(objectName string) *SOQL {
+	s := &SOQL{from: objectName}
+	s.checkName(objectName)
+
+	return s
+}
+
+// Select adds fields to the query. Besides names, field groups such as FieldsStandard are accepted.
+// "*" selects FieldsAll.
+func (s *SOQL) Select(fields ...string) *SOQL {
+	for _, field := range fields {
+		switch field {
+		case "*", FieldsAll:
+			s.fields = append(s.fields, FieldsAll)
+		case FieldsStandard, FieldsCustom:
+			s.fields = append(s.fields, field)
+		default:
+			s.checkName(field)
+			s.fields = append(s.fields, field)
+		}
+	}
+
+	return s
 }
 
-func (s *soqlBuilder) SelectFields(fields []string) *soqlBuilder {
+// selectTrusted adds fields as they are. Fields of ReadParams are trusted, as they were before the builder,
+// and may be wrapped in functions, such as toLabel(Status) or convertCurrency(Amount).
+func (s *SOQL) selectTrusted(fields ...string) *SOQL {
 	for _, field := range fields {
 		if field == "*" {
-			s.fields = "FIELDS(ALL)"
-			// if all fields are to be returned then we must limit to avoid error.
-			// Error example: `The SOQL FIELDS function must have a LIMIT of at most 200`
-			s.limit = "200"
+			field = FieldsAll
+		}
+
+		s.fields = append(s.fields, field)
+	}
+
+	return s
+}
+
+// SelectSubquery adds child records of the relationship, such as Contacts of an Account.
+func (s *SOQL) SelectSubquery(subquery *SOQL) *SOQL {
+	s.subqueries = append(s.subqueries, subquery)
+
+	if subquery.err != nil && s.err == nil {
+		s.err = subquery.err
+	}
+
+	return s
+}
+
+// Where adds the condition, conditions are joined with AND.
+// Every "?" of the condition is replaced by the escaped literal of the next argument,
+// lists become a parenthesized list for the IN operator and must not be empty. Without arguments the condition is used as is.
+func (s *SOQL) Where(condition string, args ...any) *SOQL {
+	if len(args) == 0 {
+		s.where = append(s.where, condition)
+
+		return s
+	}
+
+	parts := strings.Split(condition, "?")
+	if len(parts)-1 != len(args) {
+		s.fail(fmt.Errorf("%w: condition %q expects %d arguments, got %d",
+			ErrInvalidSOQL, condition, len(parts)-1, len(args)))
+
+		return s
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(parts[0])
+
+	for i, arg := range args {
+		literal, err := formatSOQLArgument(arg)
+		if err != nil {
+			s.fail(err)
 
 			return s
 		}
+
+		builder.WriteString(literal)
+		builder.WriteString(parts[i+1])
 	}
 
-	s.fields = strings.Join(fields, ",")
+	s.where = append(s.where, builder.String())
 
 	return s
 }
 
-func (s *soqlBuilder) From(from string) *soqlBuilder {
-	s.from = from
+// WhereFilter adds the condition of the filter expression.
+func (s *SOQL) WhereFilter(expr *common.FilterExpr) *SOQL {
+	condition, err := makeSOQLCondition(expr)
+	if err != nil {
+		s.fail(err)
+
+		return s
+	}
+
+	s.where = append(s.where, condition)
 
 	return s
 }
 
-func (s *soqlBuilder) Where(condition string) *soqlBuilder {
-	if s.where == nil {
-		s.where = make([]string, 0)
+// OrderBy sorts records by the field, fields are applied in the order they were added.
+func (s *SOQL) OrderBy(field string, descending bool) *SOQL {
+	s.checkName(field)
+
+	if descending {
+		field += " DESC"
 	}
 
-	s.where = append(s.where, condition)
+	s.orderBy = append(s.orderBy, field)
+
+	return s
+}
+
+// Limit caps the number of returned records.
+func (s *SOQL) Limit(limit int) *SOQL {
+	if limit < 0 {
+		s.fail(fmt.Errorf("%w: negative limit %d", ErrInvalidSOQL, limit))
+	}
+
+	s.limit = &limit
+
+	return s
+}
+
+// Offset skips records, Salesforce allows at most 2000.
+func (s *SOQL) Offset(offset int) *SOQL {
+	if offset < 0 {
+		s.fail(fmt.Errorf("%w: negative offset %d", ErrInvalidSOQL, offset))
+	}
+
+	s.offset = offset
+
+	return s
+}
+
+// IncludeDeleted makes Connector.Query return deleted and archived records, which are in the Recycle Bin.
+func (s *SOQL) IncludeDeleted() *SOQL {
+	s.includeDeleted = true
+
+	return s
+}
+
+// Page continues the query from the page returned by a previous Connector.Query.
+func (s *SOQL) Page(nextPage common.NextPageToken) *SOQL {
+	s.nextPage = nextPage
 
 	return s
 }
 
-func (s *soqlBuilder) String() string {
-	query := fmt.Sprintf("SELECT %s FROM %s", s.fields, s.from)
+// Build returns the query, or the first error found while it was built.
+func (s *SOQL) Build() (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+
+	if len(s.fields) == 0 && len(s.subqueries) == 0 {
+		return "", fmt.Errorf("%w: no fields are selected from %v", ErrInvalidSOQL, s.from)
+	}
+
+	return s.String(), nil
+}
+
+// String returns the query. Use Build to learn if it is valid.
+func (s *SOQL) String() string {
+	fields := append([]string{}, s.fields...)
+	for _, subquery := range s.subqueries {
+		fields = append(fields, "("+subquery.String()+")")
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(fields, ","), s.from)
 
 	if len(s.where) != 0 {
 		query += " WHERE " + strings.Join(s.where, " AND ")
 	}
 
-	if len(s.limit) != 0 {
-		query += " LIMIT " + s.limit
+	if len(s.orderBy) != 0 {
+		query += " ORDER BY " + strings.Join(s.orderBy, ",")
+	}
+
+	if limit, ok := s.effectiveLimit(); ok {
+		query += " LIMIT " + strconv.Itoa(limit)
+	}
+
+	if s.offset != 0 {
+		query += " OFFSET " + strconv.Itoa(s.offset)
 	}
 
 	return query
 }
+
+// selectedFields are names under which values appear in records, subqueries appear as their relationship.
+func (s *SOQL) selectedFields() []string {
+	fields := make([]string, 0, len(s.fields)+len(s.subqueries))
+
+	for _, field := range s.fields {
+		if !strings.HasPrefix(field, "FIELDS(") {
+			fields = append(fields, field)
+		}
+	}
+
+	for _, subquery := range s.subqueries {
+		fields = append(fields, subquery.from)
+	}
+
+	return fields
+}
+
+func (s *SOQL) effectiveLimit() (int, bool) {
+	needsLimit := false
+
+	for _, field := range s.fields {
+		if field == FieldsAll || field == FieldsCustom {
+			needsLimit = true
+		}
+	}
+
+	switch {
+	case s.limit != nil && (!needsLimit || *s.limit <= maxFieldsGroupLimit):
+		return *s.limit, true
+	case needsLimit:
+		return maxFieldsGroupLimit, true
+	default:
+		return 0, false
+	}
+}
+
+func (s *SOQL) checkName(name string) {
+	if !soqlName.MatchString(name) {
+		s.fail(fmt.Errorf("%w: %q is not a valid name", ErrInvalidSOQL, name))
+	}
+}
+
+func (s *SOQL) fail(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// formatSOQLArgument returns the literal of a value, or a list of literals when the value is a slice.
+func formatSOQLArgument(arg any) (string, error) {
+	value := reflect.ValueOf(arg)
+	if arg != nil && value.Kind() == reflect.Slice {
+		if value.Len() == 0 {
+			// An empty list "()" is rejected by Salesforce.
+			return "", fmt.Errorf("%w: list of values is empty", ErrInvalidSOQL)
+		}
+
+		literals := make([]string, value.Len())
+
+		for i := range literals {
+			literal, ok := soqlLiteral(value.Index(i).Interface())
+			if !ok {
+				return "", fmt.Errorf("%w: value of type %T is not supported", ErrInvalidSOQL, value.Index(i).Interface())
+			}
+
+			literals[i] = literal
+		}
+
+		return "(" + strings.Join(literals, ",") + ")", nil
+	}
+
+	literal, ok := soqlLiteral(arg)
+	if !ok {
+		return "", fmt.Errorf("%w: value of type %T is not supported", ErrInvalidSOQL, arg)
+	}
+
+	return literal, nil
+}
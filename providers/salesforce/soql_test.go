@@ -0,0 +1,207 @@
+package salesforce
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestSOQL(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        *SOQL
+		expected     string
+		expectedErrs []error
+	}{
+		{
+			name:     "Parent lookups and ordering",
+			input:    NewSOQL("Contact").Select("Id", "Account.Name").OrderBy("LastName", false).OrderBy("CreatedDate", true),
+			expected: "SELECT Id,Account.Name FROM Contact ORDER BY LastName,CreatedDate DESC",
+		},
+		{
+			name: "Child subquery",
+			input: NewSOQL("Account").Select("Name").
+				SelectSubquery(NewSOQL("Contacts").Select("Email").Where("Email != ?", nil).Limit(5)),
+			expected: "SELECT Name,(SELECT Email FROM Contacts WHERE Email != null LIMIT 5) FROM Account",
+		},
+		{
+			name:     "Standard fields need no limit",
+			input:    NewSOQL("Lead").Select(FieldsStandard).Limit(1000).Offset(50),
+			expected: "SELECT FIELDS(STANDARD) FROM Lead LIMIT 1000 OFFSET 50",
+		},
+		{
+			name:     "All fields are limited to 200 records",
+			input:    NewSOQL("Lead").Select("*").Limit(1000),
+			expected: "SELECT FIELDS(ALL) FROM Lead LIMIT 200",
+		},
+		{
+			name: "Arguments are escaped",
+			input: NewSOQL("Account").Select("Id").
+				Where("Name = ? AND Industry IN ?", `x' OR Name != '`, []string{"Energy", "Retail"}).
+				Where("CreatedDate > ?", time.Date(2024, 10, 17, 8, 0, 0, 0, time.UTC)),
+			expected: `SELECT Id FROM Account WHERE Name = 'x\' OR Name != \'' AND ` +
+				`Industry IN ('Energy','Retail') AND CreatedDate > 2024-10-17T08:00:00Z`,
+		},
+		{
+			name: "Filter expression",
+			input: NewSOQL("Account").Select("Id").
+				WhereFilter(&common.FilterExpr{Field: "Name", Operator: common.FilterOperatorEq, Value: "Acme"}),
+			expected: "SELECT Id FROM Account WHERE Name = 'Acme'",
+		},
+		{
+			name:         "Field names cannot carry conditions",
+			input:        NewSOQL("Account").Select("Id FROM User WHERE Id != null"),
+			expectedErrs: []error{ErrInvalidSOQL},
+		},
+		{
+			name:         "Arguments must match placeholders",
+			input:        NewSOQL("Account").Select("Id").Where("Name = ? AND Phone = ?", "Acme"),
+			expectedErrs: []error{ErrInvalidSOQL},
+		},
+		{
+			name:         "Empty lists are rejected",
+			input:        NewSOQL("Account").Select("Id").Where("Industry IN ?", []string{}),
+			expectedErrs: []error{ErrInvalidSOQL},
+		},
+		{
+			name:         "Invalid subquery fails the query",
+			input:        NewSOQL("Account").Select("Id").SelectSubquery(NewSOQL("Contacts;").Select("Id")),
+			expectedErrs: []error{ErrInvalidSOQL},
+		},
+		{
+			name:         "Fields are required",
+			input:        NewSOQL("Account"),
+			expectedErrs: []error{ErrInvalidSOQL},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := tt.input.Build()
+			testutils.CheckErrors(t, tt.name, tt.expectedErrs, err)
+
+			if output != tt.expected {
+				t.Fatalf("%s: expected: (%v), got: (%v)", tt.name, tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestQuery(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	responseContacts := `{"totalSize": 1, "done": false,
+		"nextRecordsUrl": "/services/data/v59.0/query/01gak00000A1-2000",
+		"records": [{"attributes": {"type": "Contact"}, "Id": "003ak00000A1",
+			"Account": {"attributes": {"type": "Account"}, "Name": "Acme"}}]}`
+
+	tests := []queryTestCase{
+		{
+			Name:         "Invalid query is not sent",
+			Input:        NewSOQL("Contact").Select("Id,Name"),
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{ErrInvalidSOQL},
+		},
+		{
+			Name:  "Parent fields are read",
+			Input: NewSOQL("Contact").Select("Id", "Account.Name").Where("LastName = ?", "O'Brien"),
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.PathSuffix("/services/data/v59.0/query"),
+					mockcond.QueryParam("q", `SELECT Id,Account.Name FROM Contact WHERE LastName = 'O\'Brien'`),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, responseContacts),
+			}.Server(),
+			Comparator: func(baseURL string, actual, expected *common.ReadResult) bool {
+				return mockutils.ReadResultComparator.SubsetFields(actual, expected) &&
+					actual.NextPage.String() == expected.NextPage.String() &&
+					actual.Done == expected.Done &&
+					actual.Rows == expected.Rows
+			},
+			Expected: &common.ReadResult{
+				Rows: 1,
+				Data: []common.ReadResultRow{{
+					Fields: map[string]any{"Id": "003ak00000A1", "Account.Name": "Acme"},
+				}},
+				NextPage: "/services/data/v59.0/query/01gak00000A1-2000",
+				Done:     false,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Next page is read from its URL",
+			Input: NewSOQL("Contact").Select("Id").Page("/services/data/v59.0/query/01gak00000A1-2000"),
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.PathSuffix("/services/data/v59.0/query/01gak00000A1-2000"),
+				Then: mockserver.ResponseString(http.StatusOK,
+					`{"totalSize": 1, "done": true, "records": [{"Id": "003ak00000A2"}]}`),
+			}.Server(),
+			Expected: &common.ReadResult{
+				Rows: 1,
+				Data: []common.ReadResultRow{{
+					Fields: map[string]any{"Id": "003ak00000A2"},
+					Raw:    map[string]any{"Id": "003ak00000A2"},
+				}},
+				Done: true,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Deleted records are queried from queryAll",
+			Input: NewSOQL("Contact").Select("Id").Where("IsDeleted = true").IncludeDeleted(),
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.PathSuffix("/services/data/v59.0/queryAll"),
+					mockcond.QueryParam("q", "SELECT Id FROM Contact WHERE IsDeleted = true"),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{"totalSize": 0, "done": true, "records": []}`),
+			}.Server(),
+			Expected: &common.ReadResult{
+				Data: []common.ReadResultRow{},
+				Done: true,
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+type (
+	queryTestCaseType = testroutines.TestCase[*SOQL, *common.ReadResult]
+	queryTestCase     queryTestCaseType
+)
+
+func (c queryTestCase) Run(t *testing.T, builder testroutines.ConnectorBuilder[*Connector]) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	output, err := conn.Query(context.Background(), c.Input)
+	queryTestCaseType(c).Validate(t, err, output)
+}
@@ -2,6 +2,7 @@ package salesforce
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/common/jsonquery"
@@ -9,6 +10,8 @@ import (
 )
 
 // Write will write data to Salesforce.
+// Upsert matches the record by the external ID field, which must be marked as External ID in Salesforce.
+// Read more @ https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/dome_upsert.htm
 func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*common.WriteResult, error) {
 	if err := config.ValidateParams(); err != nil {
 		return nil, err
@@ -19,20 +22,73 @@ func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*comm
 		return nil, err
 	}
 
+	write, err := common.WriteMethods{
+		common.WriteModeCreate: c.Client.Post,
+		// Salesforce allows for PATCH method override
+		common.WriteModeUpdate: c.Client.Post,
+		common.WriteModeUpsert: c.Client.Post,
+	}.Select(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.ResolveMode() == common.WriteModeUpsert {
+		url.AddPath(config.ExternalIdField)
+	}
+
 	if config.RecordId != "" {
 		url.AddPath(config.RecordId)
-		// Salesforce allows for PATCH method override
 		url.WithQueryParam("_HttpMethod", "PATCH")
 	}
 
-	rsp, err := c.Client.Post(ctx, url.String(), config.RecordData)
+	recordData, err := config.RecordDataWithClearedFields(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := write(ctx, url.String(), recordData)
 	if err != nil {
 		return nil, err
 	}
 
+	if config.ResolveMode() == common.WriteModeUpsert {
+		return parseUpsertResult(rsp)
+	}
+
 	return parseWriteResult(rsp)
 }
 
+// parseUpsertResult parses the response of upsert.
+// Salesforce responds 201 Created with the new record ID, or 200 OK when the record was updated.
+// The body has "created" flag since API version 46.0, older versions respond 204 NoContent on update.
+func parseUpsertResult(rsp *common.JSONHTTPResponse) (*common.WriteResult, error) {
+	body, ok := rsp.Body()
+	if !ok {
+		return &common.WriteResult{
+			Success: true,
+			Outcome: common.NewWriteOutcome(rsp.Code == http.StatusCreated),
+		}, nil
+	}
+
+	result, err := parseRecordResult(body)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := jsonquery.New(body).Bool("created", true)
+	if err != nil {
+		return nil, err
+	}
+
+	if created == nil {
+		result.Outcome = common.NewWriteOutcome(rsp.Code == http.StatusCreated)
+	} else {
+		result.Outcome = common.NewWriteOutcome(*created)
+	}
+
+	return result, nil
+}
+
 // parseWriteResult parses the response from writing to Salesforce API. A 2xx return type is assumed.
 func parseWriteResult(rsp *common.JSONHTTPResponse) (*common.WriteResult, error) {
 	body, ok := rsp.Body()
@@ -47,12 +103,31 @@ func parseWriteResult(rsp *common.JSONHTTPResponse) (*common.WriteResult, error)
 		return nil, err
 	}
 
-	errors, err := getErrors(body)
+	result, err := parseRecordResult(body)
+	if err != nil {
+		return nil, err
+	}
+
+	result.RecordId = *recordID
+
+	return result, nil
+}
+
+// parseRecordResult parses the outcome of an operation on a single record.
+// The same shape is used by single record endpoints and by each element of sObject Collections response.
+// Record ID is optional, failed records may not have one.
+func parseRecordResult(node *ajson.Node) (*common.WriteResult, error) {
+	recordID, err := jsonquery.New(node).StrWithDefault("id", "")
+	if err != nil {
+		return nil, err
+	}
+
+	errors, err := getErrors(node, "errors")
 	if err != nil {
 		return nil, err
 	}
 
-	success, err := jsonquery.New(body).Bool("success", false)
+	success, err := jsonquery.New(node).Bool("success", false)
 	if err != nil {
 		return nil, err
 	}
@@ -60,15 +135,15 @@ func parseWriteResult(rsp *common.JSONHTTPResponse) (*common.WriteResult, error)
 	// Salesforce does not return record data upon successful write so we do not populate
 	// the corresponding result field
 	return &common.WriteResult{
-		RecordId: *recordID,
+		RecordId: recordID,
 		Errors:   errors,
 		Success:  *success,
 	}, nil
 }
 
-// getErrors returns the errors from the response.
-func getErrors(node *ajson.Node) ([]any, error) {
-	arr, err := jsonquery.New(node).Array("errors", true)
+// getErrors returns the list of errors found under the key.
+func getErrors(node *ajson.Node, key string) ([]any, error) {
+	arr, err := jsonquery.New(node).Array(key, true)
 	if err != nil {
 		return nil, err
 	}
@@ -76,6 +76,54 @@ func TestWrite(t *testing.T) { // nolint:funlen,cyclop
 			},
 			ExpectedErrs: nil,
 		},
+		{
+			Name: "Upsert creates record matched by external id",
+			Input: common.WriteParams{
+				ObjectName:      "Account",
+				RecordId:        "A-1",
+				RecordData:      map[string]any{"Name": "Acme"},
+				Mode:            common.WriteModeUpsert,
+				ExternalIdField: "External_Id__c",
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPOST(),
+					mockcond.PathSuffix("/sobjects/Account/External_Id__c/A-1"),
+					mockcond.QueryParam("_HttpMethod", "PATCH"),
+				},
+				Then: mockserver.ResponseString(http.StatusCreated, `{
+					"id": "001ak00000OQTieAAH", "success": true, "errors": [], "created": true
+				}`),
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success:  true,
+				RecordId: "001ak00000OQTieAAH",
+				Errors:   []any{},
+				Outcome:  common.WriteOutcomeCreated,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Upsert updating without response body",
+			Input: common.WriteParams{
+				ObjectName:      "Account",
+				RecordId:        "A-1",
+				RecordData:      map[string]any{"Name": "Acme"},
+				Mode:            common.WriteModeUpsert,
+				ExternalIdField: "External_Id__c",
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.PathSuffix("/sobjects/Account/External_Id__c/A-1"),
+				Then:  mockserver.Response(http.StatusNoContent),
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success: true,
+				Outcome: common.WriteOutcomeUpdated,
+			},
+			ExpectedErrs: nil,
+		},
 		{
 			Name:  "Valid creation of account",
 			Input: common.WriteParams{ObjectName: "accounts", RecordData: "dummy"},
@@ -31,13 +31,27 @@ func (c *Connector) Write(
 		return nil, err
 	}
 
+	// Smartlead updates records with POST.
+	write, err := common.WriteMethods{
+		common.WriteModeCreate: c.Client.Post,
+		common.WriteModeUpdate: c.Client.Post,
+	}.Select(config)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(config.RecordId) == 0 {
 		constructURLPathCreate(config, url)
 	} else {
 		constructURLPathUpdate(config, url)
 	}
 
-	res, err := c.Client.Post(ctx, url.String(), config.RecordData)
+	recordData, err := config.RecordDataWithClearedFields(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := write(ctx, url.String(), recordData)
 	if err != nil {
 		return nil, err
 	}
@@ -37,7 +37,7 @@ func init() { // nolint:funlen
 			},
 			Proxy:     true,
 			Read:      true,
-			Subscribe: false,
+			Subscribe: true,
 			Write:     true,
 		},
 	})
@@ -0,0 +1,277 @@
+package zendesksupport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/naming"
+)
+
+const (
+	webhooksObjectName = "webhooks"
+	eventTypePrefix    = "zen:event-type:"
+)
+
+// updateEvents lists events which report changes of a record, per object.
+// Zendesk has no single update event, every kind of change has its own.
+// https://developer.zendesk.com/api-reference/webhooks/event-types/webhook-event-types/
+var updateEvents = map[string][]string{ // nolint:gochecknoglobals
+	"users": {
+		"active_changed", "alias_changed", "custom_field_changed", "custom_role_changed",
+		"default_group_changed", "details_changed", "external_id_changed", "identity_changed",
+		"last_login_changed", "merged", "name_changed", "notes_changed", "only_private_comments_changed",
+		"password_changed", "photo_changed", "role_changed", "suspended_changed", "tags_changed",
+		"time_zone_changed",
+	},
+	"organizations": {
+		"custom_field_changed", "external_id_changed", "group_changed", "name_changed", "tags_changed",
+	},
+}
+
+type webhookPayload struct {
+	Webhook webhook `json:"webhook"`
+}
+
+type webhook struct {
+	ID             string                 `json:"id,omitempty"`
+	Name           string                 `json:"name"`
+	Endpoint       string                 `json:"endpoint"`
+	HTTPMethod     string                 `json:"http_method"`
+	RequestFormat  string                 `json:"request_format"`
+	Status         string                 `json:"status"`
+	Subscriptions  []string               `json:"subscriptions"`
+	Authentication *webhookAuthentication `json:"authentication,omitempty"`
+}
+
+type webhookAuthentication struct {
+	Type        string         `json:"type"`
+	Data        map[string]any `json:"data"`
+	AddPosition string         `json:"add_position"`
+}
+
+type webhooksResponse struct {
+	Webhooks []map[string]any `json:"webhooks"`
+	Meta     struct {
+		HasMore bool `json:"has_more"`
+	} `json:"meta"`
+	Links struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+type signingSecretResponse struct {
+	SigningSecret struct {
+		Secret string `json:"secret"`
+	} `json:"signing_secret"`
+}
+
+// Subscribe creates a webhook for user or organization events.
+// Its signing secret is returned, to be given to webhook.NewZendeskHandler.
+// If the secret cannot be read, the created webhook is returned together with the error,
+// so that the caller can delete it or read the secret again.
+// https://developer.zendesk.com/api-reference/webhooks/webhooks-api/webhooks/#create-or-clone-webhook
+func (c *Connector) Subscribe(ctx context.Context, params common.SubscribeParams) ([]common.Subscription, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	events, err := eventTypes(params)
+	if err != nil {
+		return nil, err
+	}
+
+	name := params.Name
+	if len(name) == 0 {
+		name = "Changes of " + params.ObjectName
+	}
+
+	payload := webhookPayload{Webhook: webhook{
+		Name:          name,
+		Endpoint:      params.TargetURL,
+		HTTPMethod:    "POST",
+		RequestFormat: "json",
+		Status:        "active",
+		Subscriptions: events,
+	}}
+
+	if len(params.Username) != 0 {
+		payload.Webhook.Authentication = &webhookAuthentication{
+			Type:        "basic_auth",
+			Data:        map[string]any{"username": params.Username, "password": params.Password},
+			AddPosition: "header",
+		}
+	}
+
+	url, err := c.getURL(webhooksObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.Client.Post(ctx, url.String(), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := common.UnmarshalJSON[map[string]map[string]any](res)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := toSubscription((*created)["webhook"])
+
+	subscription.Secret, err = c.signingSecret(ctx, subscription.ID)
+	if err != nil {
+		return []common.Subscription{subscription}, err
+	}
+
+	return []common.Subscription{subscription}, nil
+}
+
+// ListSubscriptions returns all webhooks of the account.
+// https://developer.zendesk.com/api-reference/webhooks/webhooks-api/webhooks/#list-webhooks
+func (c *Connector) ListSubscriptions(ctx context.Context) ([]common.Subscription, error) {
+	url, err := c.getURL(webhooksObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	url.WithQueryParam("page[size]", "100")
+
+	next := url.String()
+
+	var subscriptions []common.Subscription
+
+	for len(next) != 0 {
+		res, err := c.Client.Get(ctx, next)
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := common.UnmarshalJSON[webhooksResponse](res)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, raw := range page.Webhooks {
+			subscriptions = append(subscriptions, toSubscription(raw))
+		}
+
+		next = ""
+		if page.Meta.HasMore {
+			next = page.Links.Next
+		}
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteSubscription deletes the webhook.
+// https://developer.zendesk.com/api-reference/webhooks/webhooks-api/webhooks/#delete-webhook
+func (c *Connector) DeleteSubscription(ctx context.Context, id string) error {
+	if len(id) == 0 {
+		return common.ErrMissingSubscriptionID
+	}
+
+	url, err := c.getURL(webhooksObjectName)
+	if err != nil {
+		return err
+	}
+
+	url.AddPath(id)
+
+	// 204 NoContent is expected
+	_, err = c.Client.Delete(ctx, url.String())
+
+	return err
+}
+
+// https://developer.zendesk.com/api-reference/webhooks/webhooks-api/webhooks/#show-webhook-signing-secret
+func (c *Connector) signingSecret(ctx context.Context, id string) (string, error) {
+	url, err := c.getURL(webhooksObjectName)
+	if err != nil {
+		return "", err
+	}
+
+	url.AddPath(id, "signing_secret")
+
+	res, err := c.Client.Get(ctx, url.String())
+	if err != nil {
+		return "", err
+	}
+
+	response, err := common.UnmarshalJSON[signingSecretResponse](res)
+	if err != nil {
+		return "", err
+	}
+
+	return response.SigningSecret.Secret, nil
+}
+
+// eventTypes converts requested changes into Zendesk event types, such as "zen:event-type:user.created".
+func eventTypes(params common.SubscribeParams) ([]string, error) {
+	updates, ok := updateEvents[params.ObjectName]
+	if !ok {
+		return nil, fmt.Errorf("%w: subscribing to %v", common.ErrOperationNotSupportedForObject, params.ObjectName)
+	}
+
+	prefix := eventTypePrefix + naming.NewSingularString(params.ObjectName).String() + "."
+
+	var events []string
+
+	for _, change := range params.ResolveChanges() {
+		switch change {
+		case common.ChangeTypeCreated:
+			events = append(events, prefix+"created")
+		case common.ChangeTypeDeleted:
+			events = append(events, prefix+"deleted")
+		case common.ChangeTypeUpdated:
+			for _, update := range updates {
+				events = append(events, prefix+update)
+			}
+		}
+	}
+
+	return events, nil
+}
+
+func toSubscription(raw map[string]any) common.Subscription {
+	subscription := common.Subscription{
+		ID:        fmt.Sprint(raw["id"]),
+		TargetURL: fmt.Sprint(raw["endpoint"]),
+		Active:    raw["status"] == "active",
+		Raw:       raw,
+	}
+
+	events, _ := raw["subscriptions"].([]any)
+	changes := make(map[common.ChangeType]bool)
+
+	for _, event := range events {
+		object, action, found := strings.Cut(strings.TrimPrefix(fmt.Sprint(event), eventTypePrefix), ".")
+		if !found {
+			continue
+		}
+
+		if len(subscription.ObjectName) == 0 {
+			subscription.ObjectName = naming.NewPluralString(object).String()
+		}
+
+		switch action {
+		case "created":
+			changes[common.ChangeTypeCreated] = true
+		case "deleted":
+			changes[common.ChangeTypeDeleted] = true
+		default:
+			changes[common.ChangeTypeUpdated] = true
+		}
+	}
+
+	for _, change := range common.AllChangeTypes {
+		if changes[change] {
+			subscription.Changes = append(subscription.Changes, change)
+		}
+	}
+
+	return subscription
+}
@@ -0,0 +1,204 @@
+package zendesksupport
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+)
+
+func TestSubscribe(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []subscribeTestCase{
+		{
+			Name:         "Target URL must be included",
+			Input:        common.SubscribeParams{ObjectName: "users"},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingTargetURL},
+		},
+		{
+			Name:         "Tickets have no webhook events",
+			Input:        common.SubscribeParams{ObjectName: "tickets", TargetURL: "https://example.com/hooks"},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			Name: "Webhook is created with its signing secret",
+			Input: common.SubscribeParams{
+				ObjectName: "organizations",
+				Changes:    []common.ChangeType{common.ChangeTypeCreated, common.ChangeTypeDeleted},
+				TargetURL:  "https://example.com/hooks",
+				Name:       "Amp",
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If: mockcond.And{
+						mockcond.MethodPOST(),
+						mockcond.PathSuffix("/v2/webhooks"),
+						mockcond.Body(`{"webhook":{"name":"Amp","endpoint":"https://example.com/hooks",
+							"http_method":"POST","request_format":"json","status":"active",
+							"subscriptions":["zen:event-type:organization.created","zen:event-type:organization.deleted"]}}`),
+					},
+					Then: mockserver.ResponseString(http.StatusCreated, `{"webhook": {
+						"id": "01GDXYD7ZTWYP3K1FTP8RXCP6D", "name": "Amp", "status": "active",
+						"endpoint": "https://example.com/hooks",
+						"subscriptions": ["zen:event-type:organization.created","zen:event-type:organization.deleted"]
+					}}`),
+				}, {
+					If: mockcond.PathSuffix("/v2/webhooks/01GDXYD7ZTWYP3K1FTP8RXCP6D/signing_secret"),
+					Then: mockserver.ResponseString(http.StatusOK,
+						`{"signing_secret": {"algorithm": "SHA256", "secret": "dGhpc19zZWNyZXQ"}}`),
+				}},
+			}.Server(),
+			Comparator: subscriptionsComparator,
+			Expected: []common.Subscription{{
+				ID:         "01GDXYD7ZTWYP3K1FTP8RXCP6D",
+				ObjectName: "organizations",
+				Changes:    []common.ChangeType{common.ChangeTypeCreated, common.ChangeTypeDeleted},
+				TargetURL:  "https://example.com/hooks",
+				Active:     true,
+				Secret:     "dGhpc19zZWNyZXQ",
+			}},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Webhook is returned when its signing secret cannot be read",
+			Input: common.SubscribeParams{ObjectName: "users", TargetURL: "https://example.com/hooks"},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If: mockcond.And{
+						mockcond.MethodPOST(),
+						mockcond.PathSuffix("/v2/webhooks"),
+					},
+					Then: mockserver.ResponseString(http.StatusCreated, `{"webhook": {
+						"id": "01GDXYD7ZTWYP3K1FTP8RXCP6D", "status": "active",
+						"endpoint": "https://example.com/hooks", "subscriptions": ["zen:event-type:user.created"]
+					}}`),
+				}, {
+					If: mockcond.PathSuffix("/v2/webhooks/01GDXYD7ZTWYP3K1FTP8RXCP6D/signing_secret"),
+					Then: mockserver.ResponseString(http.StatusForbidden,
+						`{"errors": [{"code": "Forbidden", "title": "Forbidden"}]}`),
+				}},
+			}.Server(),
+			Comparator: subscriptionsComparator,
+			Expected: []common.Subscription{{
+				ID:         "01GDXYD7ZTWYP3K1FTP8RXCP6D",
+				ObjectName: "users",
+				Changes:    []common.ChangeType{common.ChangeTypeCreated},
+				TargetURL:  "https://example.com/hooks",
+				Active:     true,
+			}},
+			ExpectedErrs: []error{common.ErrForbidden},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestListSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []listSubscriptionsTestCase{
+		{
+			Name: "Every page is listed",
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If: mockcond.QueryParam("page[after]", "abc"),
+					Then: mockserver.ResponseString(http.StatusOK, `{
+						"webhooks": [{"id": "2", "status": "inactive", "endpoint": "https://example.com/b",
+							"subscriptions": ["conditional_ticket_events"]}],
+						"meta": {"has_more": false}
+					}`),
+				}, {
+					If: mockcond.And{mockcond.PathSuffix("/v2/webhooks"), mockcond.QueryParam("page[size]", "100")},
+					Then: func(w http.ResponseWriter, r *http.Request) {
+						mockserver.ResponseString(http.StatusOK, `{
+							"webhooks": [{"id": "1", "status": "active", "endpoint": "https://example.com/a",
+								"subscriptions": ["zen:event-type:user.created","zen:event-type:user.name_changed"]}],
+							"meta": {"has_more": true},
+							"links": {"next": "http://`+r.Host+`/api/v2/webhooks?page[after]=abc"}
+						}`)(w, r)
+					},
+				}},
+			}.Server(),
+			Comparator: subscriptionsComparator,
+			Expected: []common.Subscription{{
+				ID:         "1",
+				ObjectName: "users",
+				Changes:    []common.ChangeType{common.ChangeTypeCreated, common.ChangeTypeUpdated},
+				TargetURL:  "https://example.com/a",
+				Active:     true,
+			}, {
+				ID:        "2",
+				TargetURL: "https://example.com/b",
+			}},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (*Connector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+// subscriptionsComparator ignores raw responses of the provider.
+func subscriptionsComparator(_ string, actual, expected []common.Subscription) bool {
+	if len(actual) != len(expected) {
+		return false
+	}
+
+	for i := range actual {
+		actual[i].Raw = nil
+	}
+
+	return reflect.DeepEqual(actual, expected)
+}
+
+type (
+	subscribeTestCaseType = testroutines.TestCase[common.SubscribeParams, []common.Subscription]
+	subscribeTestCase     subscribeTestCaseType
+
+	listSubscriptionsTestCaseType = testroutines.TestCase[struct{}, []common.Subscription]
+	listSubscriptionsTestCase     listSubscriptionsTestCaseType
+)
+
+func (c subscribeTestCase) Run(t *testing.T, builder testroutines.ConnectorBuilder[*Connector]) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	output, err := conn.Subscribe(context.Background(), c.Input)
+	subscribeTestCaseType(c).Validate(t, err, output)
+}
+
+func (c listSubscriptionsTestCase) Run(t *testing.T, builder testroutines.ConnectorBuilder[*Connector]) {
+	t.Helper()
+	conn := builder.Build(t, c.Name)
+	output, err := conn.ListSubscriptions(context.Background())
+	listSubscriptionsTestCaseType(c).Validate(t, err, output)
+}
@@ -103,6 +103,36 @@ func TestWrite(t *testing.T) { // nolint:funlen,cyclop
 			Expected:     &common.WriteResult{Success: true},
 			ExpectedErrs: nil,
 		},
+		{
+			Name: "Cleared fields are sent as null",
+			Input: common.WriteParams{
+				ObjectName:  "brands",
+				RecordId:    "31207417638931",
+				RecordData:  map[string]any{"brand": map[string]any{"name": "Nike"}},
+				ClearFields: []string{"brand.signature_template"},
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPUT(),
+					mockcond.Body(`{"brand":{"name":"Nike","signature_template":null}}`),
+				},
+				Then: mockserver.Response(http.StatusOK),
+			}.Server(),
+			Expected:     &common.WriteResult{Success: true},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Replace mode is not supported",
+			Input: common.WriteParams{
+				ObjectName: "brands",
+				RecordId:   "31207417638931",
+				RecordData: "dummy",
+				Mode:       common.WriteModeReplace,
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
 		{
 			Name:  "Valid creation of a brand",
 			Input: common.WriteParams{ObjectName: "brands", RecordData: "dummy"},
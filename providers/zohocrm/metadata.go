@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/handy"
 	"github.com/amp-labs/connectors/common/naming"
 )
 
@@ -12,11 +13,32 @@ import (
 // doc: https://www.zoho.com/crm/developer/docs/api/v6/field-meta.html
 const restMetadataEndpoint = "settings/fields"
 
-// apiKeyField is the key holding the metadata field name.
-const apiKeyField = "api_name"
-
 type metadataFields struct {
-	Fields []map[string]any `json:"fields"`
+	Fields []fieldMetadata `json:"fields"`
+}
+
+// fieldMetadata is a subset of field properties.
+// doc: https://www.zoho.com/crm/developer/docs/api/v6/field-meta.html
+type fieldMetadata struct {
+	APIName         string           `json:"api_name"`
+	DisplayLabel    string           `json:"display_label"`
+	DataType        string           `json:"data_type"`
+	SystemMandatory bool             `json:"system_mandatory"`
+	ReadOnly        bool             `json:"read_only"`
+	FieldReadOnly   bool             `json:"field_read_only"`
+	PickListValues  []pickListValue  `json:"pick_list_values"`
+	Lookup          *lookupReference `json:"lookup"`
+}
+
+type pickListValue struct {
+	DisplayValue string `json:"display_value"`
+	ActualValue  string `json:"actual_value"`
+}
+
+type lookupReference struct {
+	Module *struct {
+		APIName string `json:"api_name"`
+	} `json:"module"`
 }
 
 func (c *Connector) ListObjectMetadata(ctx context.Context,
@@ -99,15 +121,74 @@ func parseMetadataResponse(resp *common.JSONHTTPResponse) (*common.ObjectMetadat
 
 	metadata := &common.ObjectMetadata{
 		FieldsMap: make(map[string]string),
+		Fields:    make(map[string]common.FieldMetadata),
 	}
 
 	// Ranging on the fields Slice, to construct the metadata fields.
-	for _, f := range response.Fields {
-		apiField, ok := f[apiKeyField].(string)
-		if ok {
-			metadata.FieldsMap[apiField] = apiField
+	for _, field := range response.Fields {
+		if len(field.APIName) != 0 {
+			metadata.FieldsMap[field.APIName] = field.APIName
+			metadata.Fields[field.APIName] = makeFieldMetadata(field)
 		}
 	}
 
 	return metadata, nil
 }
+
+func makeFieldMetadata(field fieldMetadata) common.FieldMetadata {
+	displayName := field.DisplayLabel
+	if len(displayName) == 0 {
+		displayName = field.APIName
+	}
+
+	// Fields can be made read-only either by the system or by the administrator.
+	writable := !field.ReadOnly && !field.FieldReadOnly
+
+	metadata := common.FieldMetadata{
+		DisplayName:  displayName,
+		ValueType:    fieldValueTypes.Get(field.DataType),
+		ProviderType: field.DataType,
+		Required:     handy.Pointers.Bool(field.SystemMandatory),
+		Createable:   &writable,
+		Updateable:   &writable,
+	}
+
+	for _, value := range field.PickListValues {
+		metadata.Values = append(metadata.Values, common.FieldValue{
+			Value:        value.ActualValue,
+			DisplayValue: value.DisplayValue,
+		})
+	}
+
+	if field.Lookup != nil && field.Lookup.Module != nil {
+		metadata.ReferenceTo = []string{field.Lookup.Module.APIName}
+	}
+
+	return metadata
+}
+
+// Field data types.
+// doc: https://www.zoho.com/crm/developer/docs/api/v6/field-meta.html
+var fieldValueTypes = handy.NewDefaultMap(map[string]common.ValueType{ //nolint:gochecknoglobals
+	"text":                common.ValueTypeString,
+	"textarea":            common.ValueTypeString,
+	"email":               common.ValueTypeString,
+	"phone":               common.ValueTypeString,
+	"website":             common.ValueTypeString,
+	"autonumber":          common.ValueTypeString,
+	"boolean":             common.ValueTypeBoolean,
+	"integer":             common.ValueTypeInt,
+	"bigint":              common.ValueTypeInt,
+	"double":              common.ValueTypeFloat,
+	"decimal":             common.ValueTypeFloat,
+	"currency":            common.ValueTypeFloat,
+	"percent":             common.ValueTypeFloat,
+	"date":                common.ValueTypeDate,
+	"datetime":            common.ValueTypeDateTime,
+	"picklist":            common.ValueTypeSingleSelect,
+	"multiselectpicklist": common.ValueTypeMultiSelect,
+	"lookup":              common.ValueTypeReference,
+	"ownerlookup":         common.ValueTypeReference,
+}, func(string) common.ValueType {
+	return common.ValueTypeOther
+})
@@ -1,7 +1,10 @@
 package zohocrm
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/common/naming"
@@ -43,12 +46,24 @@ type writeResponse struct {
 // Write creates or updates records in a zohoCRM account.
 // A maximum of 100 records can be inserted per API call.
 // https://www.zoho.com/crm/developer/docs/api/v6/insert-records.html
+// Upsert matches records by ExternalIdField used as the duplicate check field.
+// https://www.zoho.com/crm/developer/docs/api/v6/upsert-records.html
 func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*common.WriteResult, error) {
 	if err := config.ValidateParams(); err != nil {
 		return nil, err
 	}
 
-	var write common.WriteMethod
+	if config.ResolveMode() == common.WriteModeUpsert {
+		return c.upsert(ctx, config)
+	}
+
+	write, err := common.WriteMethods{
+		common.WriteModeCreate: c.Client.Post,
+		common.WriteModeUpdate: c.Client.Put,
+	}.Select(config)
+	if err != nil {
+		return nil, err
+	}
 
 	// Object names in ZohoCRM API are case sensitive.
 	// Capitalizing the first character of object names to form correct URL.
@@ -61,16 +76,17 @@ func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*comm
 
 	if len(config.RecordId) != 0 {
 		url.AddPath(config.RecordId)
+	}
 
-		write = c.Client.Put
-	} else {
-		write = c.Client.Post
+	recordData, err := config.RecordDataWithClearedFields(nil)
+	if err != nil {
+		return nil, err
 	}
 
 	// ZohoCRM requires everything to be wrapped in a "data" object.
 	// RecordData should be a list of map[string]any
 	body := map[string]any{
-		"data": config.RecordData,
+		"data": recordData,
 	}
 
 	resp, err := write(ctx, url.String(), body)
@@ -98,3 +114,103 @@ func (c *Connector) Write(ctx context.Context, config common.WriteParams) (*comm
 		Errors:  errors,
 	}, nil
 }
+
+// upsert writes a single record to the upsert endpoint.
+// RecordId is the value of the duplicate check field, it is set unless the record has one.
+// Lists of records are rejected, since WriteResult can only describe one of them.
+func (c *Connector) upsert(ctx context.Context, config common.WriteParams) (*common.WriteResult, error) {
+	obj := naming.CapitalizeFirstLetterEveryWord(config.ObjectName)
+
+	url, err := c.getAPIURL(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	url.AddPath("upsert")
+
+	recordData, err := config.RecordDataWithClearedFields(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := makeUpsertRecord(recordData, config.ExternalIdField, config.RecordId)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]any{
+		"data":                   []any{record},
+		"duplicate_check_fields": []string{config.ExternalIdField},
+	}
+
+	resp, err := c.Client.Post(ctx, url.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := common.UnmarshalJSON[writeResponse](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if response == nil || len(response.Data) == 0 {
+		return nil, common.ErrEmptyJSONHTTPResponse
+	}
+
+	return constructUpsertResult(response.Data[0]), nil
+}
+
+// makeUpsertRecord returns the record with the duplicate check field set.
+func makeUpsertRecord(recordData any, field, value string) (map[string]any, error) {
+	// Records are normalized to maps and lists via JSON, numbers are kept as written so that large ids are not rounded.
+	body, err := json.Marshal(recordData)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var data any
+	if err = decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+
+	if _, isList := data.([]any); isList {
+		return nil, fmt.Errorf("%w: upsert of a list of records", common.ErrOperationNotSupportedForObject)
+	}
+
+	record, ok := data.(map[string]any)
+	if !ok {
+		return nil, common.ErrRecordDataNotObject
+	}
+
+	if _, found := record[field]; !found {
+		record[field] = value
+	}
+
+	return record, nil
+}
+
+// constructUpsertResult reads the outcome of a single record.
+// Example: {"code":"SUCCESS","action":"update","details":{"id":"3652397000000649013"},"status":"success"}.
+func constructUpsertResult(record map[string]any) *common.WriteResult {
+	if record["code"] != "SUCCESS" {
+		return &common.WriteResult{
+			Success: false,
+			Errors:  []any{record},
+		}
+	}
+
+	result := &common.WriteResult{
+		Success: true,
+		Outcome: common.NewWriteOutcome(record["action"] == "insert"),
+	}
+
+	if details, ok := record["details"].(map[string]any); ok {
+		result.RecordId = fmt.Sprint(details["id"])
+		result.Data = details
+	}
+
+	return result
+}
@@ -0,0 +1,136 @@
+package zohocrm
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+)
+
+func TestWrite(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	upsertContact := common.WriteParams{
+		ObjectName:      "contacts",
+		RecordId:        "bob@acme.com",
+		RecordData:      map[string]any{"Last_Name": "Doe"},
+		Mode:            common.WriteModeUpsert,
+		ExternalIdField: "Email",
+	}
+
+	tests := []testroutines.Write{
+		{
+			Name:  "Upsert reports created record",
+			Input: upsertContact,
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPOST(),
+					mockcond.PathSuffix("/crm/v6/Contacts/upsert"),
+					mockcond.Body(`{"data": [{"Last_Name": "Doe", "Email": "bob@acme.com"}],
+						"duplicate_check_fields": ["Email"]}`),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{"data": [{"code": "SUCCESS",
+					"duplicate_field": null, "action": "insert", "details": {"id": "5725767000000524157"},
+					"message": "record added", "status": "success"}]}`),
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success:  true,
+				RecordId: "5725767000000524157",
+				Data:     map[string]any{"id": "5725767000000524157"},
+				Outcome:  common.WriteOutcomeCreated,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Upsert of a list of records is not supported",
+			Input: common.WriteParams{
+				ObjectName:      "contacts",
+				RecordId:        "bob@acme.com",
+				RecordData:      []map[string]any{{"Last_Name": "Doe"}, {"Last_Name": "Roe"}},
+				Mode:            common.WriteModeUpsert,
+				ExternalIdField: "Email",
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			Name:  "Upsert reports updated record",
+			Input: upsertContact,
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.PathSuffix("/crm/v6/Contacts/upsert"),
+				Then: mockserver.ResponseString(http.StatusOK, `{"data": [{"code": "SUCCESS",
+					"duplicate_field": "Email", "action": "update", "details": {"id": "5725767000000524157"},
+					"message": "record updated", "status": "success"}]}`),
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success:  true,
+				RecordId: "5725767000000524157",
+				Data:     map[string]any{"id": "5725767000000524157"},
+				Outcome:  common.WriteOutcomeUpdated,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Upsert of rejected record is not successful",
+			Input: upsertContact,
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.PathSuffix("/crm/v6/Contacts/upsert"),
+				Then: mockserver.ResponseString(http.StatusMultiStatus, `{"data": [{"code": "INVALID_DATA",
+					"details": {"api_name": "Email"}, "message": "invalid data", "status": "error"}]}`),
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success: false,
+				Errors: []any{map[string]any{
+					"code":    "INVALID_DATA",
+					"details": map[string]any{"api_name": "Email"},
+					"message": "invalid data",
+					"status":  "error",
+				}},
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Upsert failure is understood from status code",
+			Input: upsertContact,
+			Server: mockserver.Fixed{
+				Setup: mockserver.ContentJSON(),
+				Always: mockserver.ResponseString(http.StatusBadRequest, `{"data": [{"code": "MANDATORY_NOT_FOUND",
+					"details": {"api_name": "Last_Name"}, "message": "required field not found", "status": "error"}]}`),
+			}.Server(),
+			ExpectedErrs: []error{common.ErrCaller},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.WriteConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func constructTestConnector(serverURL string) (*Connector, error) {
+	connector, err := NewConnector(
+		WithAuthenticatedClient(http.DefaultClient),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// for testing we want to redirect calls to our mock server
+	connector.setBaseURL(serverURL)
+
+	return connector, nil
+}
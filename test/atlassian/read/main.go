@@ -24,15 +24,21 @@ func main() {
 	conn := connTest.GetAtlassianConnector(ctx)
 	defer utils.Close(conn)
 
-	res, err := conn.Read(ctx, common.ReadParams{
-		Fields: connectors.Fields("id", "summary", "status"),
+	params := common.ReadParams{
+		ObjectName: "issues",
+		Fields:     connectors.Fields("id", "summary", "status"),
 		// Below is the example to get issues that were updated in the last 15 min.
 		// Since: time.Now().Add(-15 * time.Minute),
-	})
-	if err != nil {
-		utils.Fail("error reading from Atlassian", "error", err)
 	}
 
-	fmt.Println("Reading issue..")
-	utils.DumpJSON(res, os.Stdout)
+	// Read at most two pages to check that pagination works.
+	pages := connectors.ReadPages(ctx, conn, params, connectors.WithMaxPages(2))
+	for res, err := range pages {
+		if err != nil {
+			utils.Fail("error reading from Atlassian", "error", err)
+		}
+
+		fmt.Println("Reading issue..")
+		utils.DumpJSON(res, os.Stdout)
+	}
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"os"
 
 	"github.com/amp-labs/connectors"
@@ -13,6 +12,9 @@ import (
 	"github.com/amp-labs/connectors/test/attio"
 )
 
+// maxRows keeps the output short, while still crossing a page boundary for paginated objects.
+const maxRows = 60
+
 func main() {
 	os.Exit(MainFn())
 }
@@ -20,173 +22,37 @@ func main() {
 func MainFn() int {
 	conn := attio.GetAttioConnector(context.Background())
 
-	err := testReadObjects(context.Background(), conn)
-	if err != nil {
-		return 1
-	}
-
-	err = testReadLists(context.Background(), conn)
-	if err != nil {
-		return 1
-	}
-
-	err = testReadWorkspacemembers(context.Background(), conn)
-	if err != nil {
-		return 1
-	}
-
-	err = testReadWebhooks(context.Background(), conn)
-	if err != nil {
-		return 1
-	}
-
-	err = testReadTasks(context.Background(), conn)
-	if err != nil {
-		return 1
-	}
+	for _, objectName := range []string{"objects", "lists", "workspace_members", "webhooks", "tasks", "notes"} {
+		if err := testRead(context.Background(), conn, objectName); err != nil {
+			fmt.Fprintf(os.Stderr, "error reading %v: %v\n", objectName, err)
 
-	err = testReadNotes(context.Background(), conn)
-	if err != nil {
-		return 1
+			return 1
+		}
 	}
 
 	return 0
 }
 
-func testReadObjects(ctx context.Context, conn *ap.Connector) error {
-	params := common.ReadParams{
-		ObjectName: "objects",
-		Fields:     connectors.Fields(""),
-	}
-
-	res, err := conn.Read(ctx, params)
-	if err != nil {
-		log.Fatal(err.Error())
-	}
-
-	// Print the results.
-	jsonStr, err := json.MarshalIndent(res, "", "  ")
-	if err != nil {
-		return fmt.Errorf("error marshalling JSON: %w", err)
-	}
-
-	_, _ = os.Stdout.Write(jsonStr)
-	_, _ = os.Stdout.WriteString("\n")
-
-	return nil
-}
-
-func testReadLists(ctx context.Context, conn *ap.Connector) error {
+func testRead(ctx context.Context, conn *ap.Connector, objectName string) error {
 	params := common.ReadParams{
-		ObjectName: "lists",
+		ObjectName: objectName,
 		Fields:     connectors.Fields(""),
 	}
 
-	res, err := conn.Read(ctx, params)
-	if err != nil {
-		log.Fatal(err.Error())
-	}
-
-	// Print the results.
-	jsonStr, err := json.MarshalIndent(res, "", "  ")
-	if err != nil {
-		return fmt.Errorf("error marshalling JSON: %w", err)
-	}
-
-	_, _ = os.Stdout.Write(jsonStr)
-	_, _ = os.Stdout.WriteString("\n")
-
-	return nil
-}
+	for row, err := range connectors.ReadRows(ctx, conn, params, connectors.WithMaxRows(maxRows)) {
+		if err != nil {
+			return err
+		}
 
-func testReadWorkspacemembers(ctx context.Context, conn *ap.Connector) error {
-	params := common.ReadParams{
-		ObjectName: "workspace_members",
-		Fields:     connectors.Fields(""),
-	}
+		// Print the results.
+		jsonStr, err := json.MarshalIndent(row, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshalling JSON: %w", err)
+		}
 
-	res, err := conn.Read(ctx, params)
-	if err != nil {
-		log.Fatal(err.Error())
+		_, _ = os.Stdout.Write(jsonStr)
+		_, _ = os.Stdout.WriteString("\n")
 	}
 
-	// Print the results.
-	jsonStr, err := json.MarshalIndent(res, "", "  ")
-	if err != nil {
-		return fmt.Errorf("error marshalling JSON: %w", err)
-	}
-
-	_, _ = os.Stdout.Write(jsonStr)
-	_, _ = os.Stdout.WriteString("\n")
-
-	return nil
-}
-
-func testReadWebhooks(ctx context.Context, conn *ap.Connector) error {
-	params := common.ReadParams{
-		ObjectName: "webhooks",
-		Fields:     connectors.Fields(""),
-	}
-
-	res, err := conn.Read(ctx, params)
-	if err != nil {
-		log.Fatal(err.Error())
-	}
-
-	// Print the results.
-	jsonStr, err := json.MarshalIndent(res, "", "  ")
-	if err != nil {
-		return fmt.Errorf("error marshalling JSON: %w", err)
-	}
-
-	_, _ = os.Stdout.Write(jsonStr)
-	_, _ = os.Stdout.WriteString("\n")
-
-	return nil
-}
-
-func testReadTasks(ctx context.Context, conn *ap.Connector) error {
-	params := common.ReadParams{
-		ObjectName: "tasks",
-		Fields:     connectors.Fields(""),
-	}
-
-	res, err := conn.Read(ctx, params)
-	if err != nil {
-		log.Fatal(err.Error())
-	}
-
-	// Print the results.
-	jsonStr, err := json.MarshalIndent(res, "", "  ")
-	if err != nil {
-		return fmt.Errorf("error marshalling JSON: %w", err)
-	}
-
-	_, _ = os.Stdout.Write(jsonStr)
-	_, _ = os.Stdout.WriteString("\n")
-
-	return nil
-}
-
-func testReadNotes(ctx context.Context, conn *ap.Connector) error {
-	params := common.ReadParams{
-		ObjectName: "notes",
-		Fields:     connectors.Fields(""),
-	}
-
-	res, err := conn.Read(ctx, params)
-	if err != nil {
-		log.Fatal(err.Error())
-	}
-
-	// Print the results.
-	jsonStr, err := json.MarshalIndent(res, "", "  ")
-	if err != nil {
-		return fmt.Errorf("error marshalling JSON: %w", err)
-	}
-
-	_, _ = os.Stdout.Write(jsonStr)
-	_, _ = os.Stdout.WriteString("\n")
-
 	return nil
 }
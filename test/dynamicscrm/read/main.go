@@ -27,18 +27,23 @@ func main() {
 	conn := connTest.GetMSDynamics365CRMConnector(ctx)
 	defer utils.Close(conn)
 
-	res, err := conn.Read(ctx, common.ReadParams{
+	params := common.ReadParams{
 		ObjectName: objectName,
 		Fields:     connectors.Fields("fullname", "emailaddress1", "fax"),
-	})
-	if err != nil {
-		utils.Fail("error reading from microsoft CRM", "error", err)
 	}
 
-	fmt.Println("Reading contacts..")
-	utils.DumpJSON(res, os.Stdout)
+	// Read at most two pages to check that pagination works.
+	pages := connectors.ReadPages(ctx, conn, params, connectors.WithMaxPages(2))
+	for res, err := range pages {
+		if err != nil {
+			utils.Fail("error reading from microsoft CRM", "error", err)
+		}
 
-	if res.Rows > dynamicscrm.DefaultPageSize {
-		utils.Fail(fmt.Sprintf("expected max %v rows", dynamicscrm.DefaultPageSize))
+		fmt.Println("Reading contacts..")
+		utils.DumpJSON(res, os.Stdout)
+
+		if res.Rows > dynamicscrm.DefaultPageSize {
+			utils.Fail(fmt.Sprintf("expected max %v rows", dynamicscrm.DefaultPageSize))
+		}
 	}
 }
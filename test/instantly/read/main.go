@@ -28,20 +28,25 @@ func main() {
 	conn := connTest.GetInstantlyConnector(ctx)
 	defer utils.Close(conn)
 
-	res, err := conn.Read(ctx, common.ReadParams{
+	params := common.ReadParams{
 		ObjectName: objectName,
 		Fields: connectors.Fields(
 			"name",
 		),
-	})
-	if err != nil {
-		utils.Fail("error reading from Instantly", "error", err)
 	}
 
-	slog.Info("Reading campaigns..")
-	utils.DumpJSON(res, os.Stdout)
+	// Read at most two pages to check that pagination works.
+	pages := connectors.ReadPages(ctx, conn, params, connectors.WithMaxPages(2))
+	for res, err := range pages {
+		if err != nil {
+			utils.Fail("error reading from Instantly", "error", err)
+		}
 
-	if res.Rows > instantly.DefaultPageSize {
-		utils.Fail(fmt.Sprintf("expected max %v rows", instantly.DefaultPageSize))
+		slog.Info("Reading campaigns..")
+		utils.DumpJSON(res, os.Stdout)
+
+		if res.Rows > instantly.DefaultPageSize {
+			utils.Fail(fmt.Sprintf("expected max %v rows", instantly.DefaultPageSize))
+		}
 	}
 }
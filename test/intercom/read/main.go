@@ -25,22 +25,27 @@ func main() {
 	conn := msTest.GetIntercomConnector(ctx)
 	defer utils.Close(conn)
 
-	res, err := conn.Read(ctx, common.ReadParams{
+	params := common.ReadParams{
 		ObjectName: "conversations",
 		Fields: connectors.Fields(
 			"id",
 			"state",
 			"type",
 		),
-	})
-	if err != nil {
-		utils.Fail("error reading from Intercom", "error", err)
 	}
 
-	fmt.Println("Reading conversations..")
-	utils.DumpJSON(res, os.Stdout)
+	// Read at most two pages to check that pagination works.
+	pages := connectors.ReadPages(ctx, conn, params, connectors.WithMaxPages(2))
+	for res, err := range pages {
+		if err != nil {
+			utils.Fail("error reading from Intercom", "error", err)
+		}
 
-	if res.Rows > intercom.DefaultPageSize {
-		utils.Fail(fmt.Sprintf("expected max %v rows", intercom.DefaultPageSize))
+		fmt.Println("Reading conversations..")
+		utils.DumpJSON(res, os.Stdout)
+
+		if res.Rows > intercom.DefaultPageSize {
+			utils.Fail(fmt.Sprintf("expected max %v rows", intercom.DefaultPageSize))
+		}
 	}
 }
@@ -25,7 +25,7 @@ func main() {
 	conn := msTest.GetIntercomConnector(ctx)
 	defer utils.Close(conn)
 
-	res, err := conn.Read(ctx, common.ReadParams{
+	params := common.ReadParams{
 		ObjectName: "conversations",
 		Fields: connectors.Fields(
 			"id",
@@ -33,32 +33,16 @@ func main() {
 			"type",
 		),
 		Since: time.Unix(1726674883, 0),
-	})
-	if err != nil {
-		utils.Fail("error reading from Intercom", "error", err)
 	}
 
-	fmt.Println("Reading conversations..")
-	utils.DumpJSON(res, os.Stdout)
+	// Read at most two pages to check that pagination works.
+	pages := connectors.ReadPages(ctx, conn, params, connectors.WithMaxPages(2))
+	for res, err := range pages {
+		if err != nil {
+			utils.Fail("error reading from Intercom", "error", err)
+		}
 
-	if len(res.NextPage) == 0 {
-		return
+		fmt.Println("Reading conversations..")
+		utils.DumpJSON(res, os.Stdout)
 	}
-
-	res, err = conn.Read(ctx, common.ReadParams{
-		ObjectName: "conversations",
-		Fields: connectors.Fields(
-			"id",
-			"state",
-			"type",
-		),
-		NextPage: res.NextPage,
-		Since:    time.Unix(1726674883, 0),
-	})
-	if err != nil {
-		utils.Fail("error reading from Intercom", "error", err)
-	}
-
-	fmt.Println("Reading conversations (SecondPage)..")
-	utils.DumpJSON(res, os.Stdout)
 }
@@ -27,18 +27,23 @@ func main() {
 	conn := connTest.GetPipelinerConnector(ctx)
 	defer utils.Close(conn)
 
-	res, err := conn.Read(ctx, common.ReadParams{
+	params := common.ReadParams{
 		ObjectName: objectName,
 		Fields:     connectors.Fields("id", "formatted_name", "account_position"),
-	})
-	if err != nil {
-		utils.Fail("error reading from Pipeliner", "error", err)
 	}
 
-	fmt.Println("Reading Contacts..")
-	utils.DumpJSON(res, os.Stdout)
+	// Read at most two pages to check that pagination works.
+	pages := connectors.ReadPages(ctx, conn, params, connectors.WithMaxPages(2))
+	for res, err := range pages {
+		if err != nil {
+			utils.Fail("error reading from Pipeliner", "error", err)
+		}
 
-	if res.Rows > pipeliner.DefaultPageSize {
-		utils.Fail(fmt.Sprintf("expected max %v rows", pipeliner.DefaultPageSize))
+		fmt.Println("Reading Contacts..")
+		utils.DumpJSON(res, os.Stdout)
+
+		if res.Rows > pipeliner.DefaultPageSize {
+			utils.Fail(fmt.Sprintf("expected max %v rows", pipeliner.DefaultPageSize))
+		}
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/amp-labs/connectors/providers/salesforce"
 	connTest "github.com/amp-labs/connectors/test/salesforce"
 	"github.com/amp-labs/connectors/test/utils"
 )
@@ -27,9 +28,9 @@ func main() {
 	conn := connTest.GetSalesforceConnector(ctx)
 	defer utils.Close(conn)
 
-	input := []byte(`
-<createMetadata>
-    <metadata xsi:type="CustomObject">
+	res, err := conn.CreateMetadata(ctx, salesforce.MetadataComponent{
+		Type: "CustomObject",
+		Content: `
         <fullName>TestObject15__c</fullName>
         <label>Test Object 15</label>
         <pluralLabel>Test Objects 15</pluralLabel>
@@ -38,9 +39,10 @@ func main() {
             <label>Test Object Name</label>
         </nameField>
         <deploymentStatus>Deployed</deploymentStatus>
-        <sharingModel>ReadWrite</sharingModel>
-    </metadata>
-    <metadata xsi:type="CustomField">
+        <sharingModel>ReadWrite</sharingModel>`,
+	}, salesforce.MetadataComponent{
+		Type: "CustomField",
+		Content: `
         <fullName>TestObject13__c.Comments__c</fullName>
         <label>Comments</label>
         <type>LongTextArea</type>
@@ -50,13 +52,8 @@ func main() {
         <visibleLines>30</visibleLines>
         <required>false</required>
         <trackFeedHistory>false</trackFeedHistory>
-        <trackHistory>false</trackHistory>
-    </metadata>
-</createMetadata>
-`)
-	accessToken := connTest.GetSalesforceAccessToken()
-
-	res, err := conn.CreateMetadata(ctx, input, accessToken)
+        <trackHistory>false</trackHistory>`,
+	})
 	if err != nil {
 		slog.Error("err", "err", err)
 	}
@@ -25,18 +25,23 @@ func main() {
 	conn := msTest.GetSalesloftConnector(ctx)
 	defer utils.Close(conn)
 
-	res, err := conn.Read(ctx, common.ReadParams{
+	params := common.ReadParams{
 		ObjectName: "people",
 		Fields:     connectors.Fields("display_name", "email_address"),
-	})
-	if err != nil {
-		utils.Fail("error reading from Salesloft", "error", err)
 	}
 
-	fmt.Println("Reading people..")
-	utils.DumpJSON(res, os.Stdout)
+	// Read at most two pages to check that pagination works.
+	pages := connectors.ReadPages(ctx, conn, params, connectors.WithMaxPages(2))
+	for res, err := range pages {
+		if err != nil {
+			utils.Fail("error reading from Salesloft", "error", err)
+		}
 
-	if res.Rows > salesloft.DefaultPageSize {
-		utils.Fail(fmt.Sprintf("expected max %v rows", salesloft.DefaultPageSize))
+		fmt.Println("Reading people..")
+		utils.DumpJSON(res, os.Stdout)
+
+		if res.Rows > salesloft.DefaultPageSize {
+			utils.Fail(fmt.Sprintf("expected max %v rows", salesloft.DefaultPageSize))
+		}
 	}
 }
@@ -26,14 +26,19 @@ func main() {
 	conn := connTest.GetSmartleadConnector(ctx)
 	defer utils.Close(conn)
 
-	res, err := conn.Read(ctx, common.ReadParams{
+	params := common.ReadParams{
 		ObjectName: objectName,
 		Fields:     connectors.Fields("name", "status", "user_id"),
-	})
-	if err != nil {
-		utils.Fail("error reading from Smartlead", "error", err)
 	}
 
-	fmt.Println("Reading campaign..")
-	utils.DumpJSON(res, os.Stdout)
+	// Read at most two pages to check that pagination works.
+	pages := connectors.ReadPages(ctx, conn, params, connectors.WithMaxPages(2))
+	for res, err := range pages {
+		if err != nil {
+			utils.Fail("error reading from Smartlead", "error", err)
+		}
+
+		fmt.Println("Reading campaign..")
+		utils.DumpJSON(res, os.Stdout)
+	}
 }
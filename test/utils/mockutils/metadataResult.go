@@ -3,6 +3,7 @@ package mockutils
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/amp-labs/connectors/common"
@@ -13,6 +14,7 @@ var MetadataResultComparator = metadataResultComparator{}
 type metadataResultComparator struct{}
 
 // SubsetFields checks that expected ListObjectMetadataResult fields are a subset of actual metadata result.
+// Field descriptions, if any are expected, must match exactly.
 func (metadataResultComparator) SubsetFields(actual, expected *common.ListObjectMetadataResult) bool {
 	if len(expected.Result) == 0 {
 		invalidTest("please specify expected FieldsMap response")
@@ -38,6 +40,17 @@ func (metadataResultComparator) SubsetFields(actual, expected *common.ListObject
 				return false
 			}
 		}
+
+		for k, v := range expectedMetadata.Fields {
+			value, ok := actualMetadata.Fields[k]
+			if !ok {
+				return false
+			}
+
+			if !reflect.DeepEqual(value, v) {
+				return false
+			}
+		}
 	}
 
 	return true
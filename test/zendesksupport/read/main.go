@@ -27,18 +27,23 @@ func main() {
 	conn := connTest.GetZendeskSupportConnector(ctx)
 	defer utils.Close(conn)
 
-	res, err := conn.Read(ctx, common.ReadParams{
+	params := common.ReadParams{
 		ObjectName: objectName,
 		Fields:     connectors.Fields("name", "time_zone", "role"),
-	})
-	if err != nil {
-		utils.Fail("error reading from Zendesk Support", "error", err)
 	}
 
-	fmt.Println("Reading users..")
-	utils.DumpJSON(res, os.Stdout)
+	// Read at most two pages to check that pagination works.
+	pages := connectors.ReadPages(ctx, conn, params, connectors.WithMaxPages(2))
+	for res, err := range pages {
+		if err != nil {
+			utils.Fail("error reading from Zendesk Support", "error", err)
+		}
 
-	if res.Rows > zendesksupport.DefaultPageSize {
-		utils.Fail(fmt.Sprintf("expected max %v rows", zendesksupport.DefaultPageSize))
+		fmt.Println("Reading users..")
+		utils.DumpJSON(res, os.Stdout)
+
+		if res.Rows > zendesksupport.DefaultPageSize {
+			utils.Fail(fmt.Sprintf("expected max %v rows", zendesksupport.DefaultPageSize))
+		}
 	}
 }
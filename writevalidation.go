@@ -0,0 +1,541 @@
+package connectors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/handy"
+)
+
+// ErrInvalidRecordData is returned when RecordData doesn't agree with object metadata.
+var ErrInvalidRecordData = errors.New("record data is invalid")
+
+// FieldErrorReason explains why a field was rejected.
+type FieldErrorReason string
+
+const (
+	// FieldErrorUnknown means the object has no such field.
+	FieldErrorUnknown FieldErrorReason = "unknownField"
+	// FieldErrorReadOnly means the field cannot be set by this operation.
+	FieldErrorReadOnly FieldErrorReason = "readOnly"
+	// FieldErrorInvalidValue means the value cannot be converted to the field type.
+	FieldErrorInvalidValue FieldErrorReason = "invalidValue"
+	// FieldErrorMissing means a required field was not provided when creating a record.
+	FieldErrorMissing FieldErrorReason = "missingField"
+)
+
+// FieldError describes a problem with a single field of RecordData.
+type FieldError struct {
+	Field   string
+	Reason  FieldErrorReason
+	Message string
+}
+
+func (e FieldError) String() string {
+	return fmt.Sprintf("%v: %v (%v)", e.Field, e.Message, e.Reason)
+}
+
+// FieldErrors is a list of all problems found in RecordData.
+// It matches both ErrInvalidRecordData and common.ErrCaller via errors.Is.
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, len(e))
+	for index, fieldErr := range e {
+		messages[index] = fieldErr.String()
+	}
+
+	return fmt.Sprintf("%v: %v", ErrInvalidRecordData, strings.Join(messages, "; "))
+}
+
+func (e FieldErrors) Unwrap() []error {
+	return []error{ErrInvalidRecordData, common.ErrCaller}
+}
+
+// WriteValidationOption configures WriteValidator.
+type WriteValidationOption = func(params *writeValidationParams)
+
+type writeValidationParams struct {
+	allowUnknownFields bool
+	skipCoercion       bool
+}
+
+// WithUnknownFieldsAllowed lets fields missing from metadata through, leaving their values untouched.
+func WithUnknownFieldsAllowed() WriteValidationOption {
+	return func(params *writeValidationParams) {
+		params.allowUnknownFields = true
+	}
+}
+
+// WithoutCoercion only checks values, RecordData is passed on as is.
+func WithoutCoercion() WriteValidationOption {
+	return func(params *writeValidationParams) {
+		params.skipCoercion = true
+	}
+}
+
+// WriteValidator checks RecordData against object metadata before anything is sent to the provider.
+// Metadata is kept by MetadataCacheConnector, either the one given or a default one wrapping the connector.
+// Fields are matched by name, ignoring the case, since some connectors report field names in lower case.
+type WriteValidator struct {
+	cache  *MetadataCacheConnector
+	params writeValidationParams
+}
+
+// NewWriteValidator creates a validator using metadata of the given connector.
+// Pass MetadataCacheConnector to control how long metadata is kept, or to share it with other callers.
+func NewWriteValidator(conn ObjectMetadataConnector, opts ...WriteValidationOption) *WriteValidator {
+	params := writeValidationParams{}
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	cache, ok := conn.(*MetadataCacheConnector)
+	if !ok {
+		cache = NewMetadataCacheConnector(conn)
+	}
+
+	return &WriteValidator{
+		cache:  cache,
+		params: params,
+	}
+}
+
+// Validate returns WriteParams with RecordData converted to the field types.
+// Every rejected field is reported in FieldErrors. Failure to get metadata is returned as is.
+//
+// Checks performed:
+//   - field exists on the object,
+//   - field can be set on create, or on update; upsert and replace may create the record and are checked as create,
+//   - value can be converted to the field type, ex: "42" for a number or "2024-01-31" for a date,
+//   - value is one of the options of a single select field,
+//   - required fields are present on create,
+//   - fields of ClearFields exist and can be set.
+//
+// Properties unknown to the connector, such as missing flags or types, are not checked.
+func (v *WriteValidator) Validate(ctx context.Context, params WriteParams) (WriteParams, error) {
+	if err := params.ValidateParams(); err != nil {
+		return params, err
+	}
+
+	metadata, err := v.objectMetadata(ctx, params.ObjectName)
+	if err != nil {
+		return params, err
+	}
+
+	record, err := recordToMap(params.RecordData)
+	if err != nil {
+		return params, err
+	}
+
+	fields := make(map[string]common.FieldMetadata, len(metadata.Fields))
+	for name, field := range metadata.Fields {
+		fields[strings.ToLower(name)] = field
+	}
+
+	knownNames := make(map[string]bool, len(metadata.FieldsMap))
+	for name := range metadata.FieldsMap {
+		knownNames[strings.ToLower(name)] = true
+	}
+
+	isUpdate := params.ResolveMode() == common.WriteModeUpdate
+	output := make(map[string]any, len(record))
+	fieldErrors := make(FieldErrors, 0)
+
+	for _, name := range params.ClearFields {
+		key := strings.ToLower(name)
+		if !knownNames[key] {
+			// Dotted fields clear nested values, which metadata doesn't describe.
+			if !v.params.allowUnknownFields && !strings.Contains(name, ".") {
+				fieldErrors = append(fieldErrors, FieldError{
+					Field:   name,
+					Reason:  FieldErrorUnknown,
+					Message: "field doesn't exist on " + params.ObjectName,
+				})
+			}
+
+			continue
+		}
+
+		if field, ok := fields[key]; ok {
+			if fieldErr := checkWritable(name, field, isUpdate); fieldErr != nil {
+				fieldErrors = append(fieldErrors, *fieldErr)
+			}
+		}
+	}
+
+	for name, value := range record {
+		output[name] = value
+
+		key := strings.ToLower(name)
+		if !knownNames[key] {
+			if !v.params.allowUnknownFields {
+				fieldErrors = append(fieldErrors, FieldError{
+					Field:   name,
+					Reason:  FieldErrorUnknown,
+					Message: "field doesn't exist on " + params.ObjectName,
+				})
+			}
+
+			continue
+		}
+
+		field, ok := fields[key]
+		if !ok {
+			// Connector doesn't describe fields.
+			continue
+		}
+
+		if fieldErr := checkWritable(name, field, isUpdate); fieldErr != nil {
+			fieldErrors = append(fieldErrors, *fieldErr)
+
+			continue
+		}
+
+		converted, err := coerceFieldValue(field, value)
+		if err != nil {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:   name,
+				Reason:  FieldErrorInvalidValue,
+				Message: err.Error(),
+			})
+
+			continue
+		}
+
+		if !v.params.skipCoercion {
+			output[name] = converted
+		}
+	}
+
+	if !isUpdate {
+		present := make([]string, 0, len(record)+1)
+		for name := range record {
+			present = append(present, name)
+		}
+
+		// Upsert carries the value of the external ID field in RecordId.
+		present = append(present, params.ExternalIdField)
+
+		fieldErrors = append(fieldErrors, findMissingRequiredFields(metadata, present)...)
+	}
+
+	if len(fieldErrors) != 0 {
+		slices.SortFunc(fieldErrors, func(a, b FieldError) int {
+			return strings.Compare(a.Field, b.Field)
+		})
+
+		return params, fieldErrors
+	}
+
+	// RecordData may be omitted when the write only clears fields.
+	if !v.params.skipCoercion && params.RecordData != nil {
+		params.RecordData = output
+	}
+
+	return params, nil
+}
+
+// objectMetadata returns cached metadata, requesting it from the connector when missing or expired.
+func (v *WriteValidator) objectMetadata(ctx context.Context, objectName string) (*common.ObjectMetadata, error) {
+	result, err := v.cache.ListObjectMetadata(ctx, []string{objectName})
+	if err != nil {
+		return nil, err
+	}
+
+	return lookupObjectMetadata(result, objectName)
+}
+
+// lookupObjectMetadata finds the object in the result.
+// Connectors may change the case or the form of the object name, therefore a single result is accepted as is.
+func lookupObjectMetadata(result *ListObjectMetadataResult, objectName string) (*common.ObjectMetadata, error) {
+	for _, name := range []string{objectName, strings.ToLower(objectName)} {
+		if err, ok := result.Errors[name]; ok {
+			return nil, err
+		}
+	}
+
+	if metadata, ok := result.Result[objectName]; ok {
+		return &metadata, nil
+	}
+
+	if metadata, ok := result.Result[strings.ToLower(objectName)]; ok {
+		return &metadata, nil
+	}
+
+	if len(result.Result) == 1 {
+		for _, metadata := range result.Result {
+			return &metadata, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: metadata is missing for object %v", common.ErrMissingExpectedValues, objectName)
+}
+
+// recordToMap converts RecordData into a map. Numbers are decoded as json.Number, so that large ones are not rounded.
+func recordToMap(data any) (map[string]any, error) {
+	if record, ok := data.(map[string]any); ok {
+		return record, nil
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Join(common.ErrRecordDataNotJSON, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+
+	var record map[string]any
+	if err = decoder.Decode(&record); err != nil {
+		return nil, errors.Join(common.ErrRecordDataNotJSON, err)
+	}
+
+	return record, nil
+}
+
+func checkWritable(name string, field common.FieldMetadata, isUpdate bool) *FieldError {
+	flag, operation := field.Createable, "created"
+	if isUpdate {
+		flag, operation = field.Updateable, "updated"
+	}
+
+	if flag == nil || *flag {
+		return nil
+	}
+
+	return &FieldError{
+		Field:   name,
+		Reason:  FieldErrorReadOnly,
+		Message: "field cannot be " + operation,
+	}
+}
+
+func findMissingRequiredFields(metadata *common.ObjectMetadata, names []string) []FieldError {
+	present := make(map[string]bool, len(names))
+	for _, name := range names {
+		present[strings.ToLower(name)] = true
+	}
+
+	missing := make([]FieldError, 0)
+
+	for name, field := range metadata.Fields {
+		if handy.Pointers.IsTrue(field.Required) && !present[strings.ToLower(name)] {
+			missing = append(missing, FieldError{
+				Field:   name,
+				Reason:  FieldErrorMissing,
+				Message: "field is required",
+			})
+		}
+	}
+
+	return missing
+}
+
+// coerceFieldValue converts value to the type of the field.
+// Values of types without conversion rules are returned unchanged.
+func coerceFieldValue(field common.FieldMetadata, value any) (any, error) { // nolint:cyclop
+	if value == nil {
+		if field.Nullable != nil && !*field.Nullable {
+			return nil, invalidValue("field cannot be empty")
+		}
+
+		return nil, nil
+	}
+
+	switch field.ValueType { // nolint:exhaustive
+	case common.ValueTypeString:
+		return coerceString(value)
+	case common.ValueTypeInt:
+		return coerceInt(value)
+	case common.ValueTypeFloat:
+		return coerceFloat(value)
+	case common.ValueTypeBoolean:
+		return coerceBool(value)
+	case common.ValueTypeDate:
+		return coerceTime(value, time.DateOnly)
+	case common.ValueTypeDateTime:
+		return coerceTime(value, time.RFC3339)
+	case common.ValueTypeSingleSelect:
+		return coerceOption(field, value)
+	default:
+		return value, nil
+	}
+}
+
+// invalidValue describes why value was rejected, it becomes FieldError.Message.
+func invalidValue(format string, args ...any) error {
+	return fmt.Errorf(format, args...) // nolint:goerr113
+}
+
+func coerceString(value any) (any, error) {
+	switch val := value.(type) {
+	case string:
+		return val, nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case int:
+		return strconv.Itoa(val), nil
+	case int64:
+		return strconv.FormatInt(val, 10), nil
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), nil
+	case json.Number:
+		return val.String(), nil
+	default:
+		return nil, invalidValue("expected text, got %T", value)
+	}
+}
+
+func coerceInt(value any) (any, error) {
+	switch val := value.(type) {
+	case int:
+		return int64(val), nil
+	case int64:
+		return val, nil
+	case float64:
+		if val != math.Trunc(val) {
+			return nil, invalidValue("expected whole number, got %v", val)
+		}
+
+		return int64(val), nil
+	case json.Number:
+		if number, err := val.Int64(); err == nil {
+			return number, nil
+		}
+
+		number, err := val.Float64()
+		if err != nil {
+			return nil, invalidValue("expected whole number, got %v", val)
+		}
+
+		return coerceInt(number)
+	case string:
+		number, err := strconv.ParseInt(strings.TrimSpace(val), 10, 64)
+		if err != nil {
+			return nil, invalidValue("expected whole number, got %q", val)
+		}
+
+		return number, nil
+	default:
+		return nil, invalidValue("expected whole number, got %T", value)
+	}
+}
+
+func coerceFloat(value any) (any, error) {
+	switch val := value.(type) {
+	case int:
+		return float64(val), nil
+	case int64:
+		return float64(val), nil
+	case float64:
+		return val, nil
+	case json.Number:
+		number, err := val.Float64()
+		if err != nil {
+			return nil, invalidValue("expected number, got %v", val)
+		}
+
+		return number, nil
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		if err != nil {
+			return nil, invalidValue("expected number, got %q", val)
+		}
+
+		return number, nil
+	default:
+		return nil, invalidValue("expected number, got %T", value)
+	}
+}
+
+func coerceBool(value any) (any, error) {
+	switch val := value.(type) {
+	case bool:
+		return val, nil
+	case string:
+		flag, err := strconv.ParseBool(strings.TrimSpace(val))
+		if err != nil {
+			return nil, invalidValue("expected boolean, got %q", val)
+		}
+
+		return flag, nil
+	default:
+		return nil, invalidValue("expected boolean, got %T", value)
+	}
+}
+
+// coerceTime formats time using layout. Text is accepted either as a date or as RFC3339 timestamp.
+func coerceTime(value any, layout string) (any, error) {
+	switch val := value.(type) {
+	case time.Time:
+		return val.Format(layout), nil
+	case string:
+		for _, candidate := range []string{time.RFC3339, time.DateOnly} {
+			if parsed, err := time.Parse(candidate, strings.TrimSpace(val)); err == nil {
+				return parsed.Format(layout), nil
+			}
+		}
+
+		return nil, invalidValue("expected date, got %q", val)
+	default:
+		return nil, invalidValue("expected date, got %T", value)
+	}
+}
+
+func coerceOption(field common.FieldMetadata, value any) (any, error) {
+	if len(field.Values) == 0 {
+		return value, nil
+	}
+
+	text, err := coerceString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, option := range field.Values {
+		if option.Value == text {
+			return value, nil
+		}
+	}
+
+	return nil, invalidValue("value %q is not one of the options", text)
+}
+
+// ValidatingWriteConnector validates RecordData using WriteValidator before every Write.
+type ValidatingWriteConnector struct {
+	WriteConnector
+
+	validator *WriteValidator
+}
+
+// NewValidatingWriteConnector wraps a connector capable of describing objects, making validation opt-in.
+func NewValidatingWriteConnector(conn interface {
+	WriteConnector
+	ObjectMetadataConnector
+}, opts ...WriteValidationOption,
+) *ValidatingWriteConnector {
+	return &ValidatingWriteConnector{
+		WriteConnector: conn,
+		validator:      NewWriteValidator(conn, opts...),
+	}
+}
+
+// Write sends the record to the provider only if it passed validation.
+func (c *ValidatingWriteConnector) Write(ctx context.Context, params WriteParams) (*WriteResult, error) {
+	params, err := c.validator.Validate(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.WriteConnector.Write(ctx, params)
+}
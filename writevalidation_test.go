@@ -0,0 +1,298 @@
+package connectors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/handy"
+	"github.com/amp-labs/connectors/mock"
+)
+
+// describedConnector describes "Contact" the way Salesforce does, using lower case field names.
+func describedConnector(t *testing.T, requests *atomic.Int32, writes *[]WriteParams) *mock.Connector {
+	t.Helper()
+
+	conn, err := mock.NewConnector(
+		mock.WithClient(http.DefaultClient),
+		mock.WithListObjectMetadata(func(ctx context.Context, objectNames []string) (*ListObjectMetadataResult, error) {
+			requests.Add(1)
+
+			metadata := common.ObjectMetadata{DisplayName: "Contact"}
+			metadata.AddField("id", common.FieldMetadata{
+				DisplayName: "ID",
+				ValueType:   common.ValueTypeString,
+				Createable:  handy.Pointers.Bool(false),
+				Updateable:  handy.Pointers.Bool(false),
+			})
+			metadata.AddField("lastname", common.FieldMetadata{
+				DisplayName: "Last Name",
+				ValueType:   common.ValueTypeString,
+				Required:    handy.Pointers.Bool(true),
+			})
+			metadata.AddField("numberofemployees", common.FieldMetadata{
+				DisplayName: "Employees",
+				ValueType:   common.ValueTypeInt,
+			})
+			metadata.AddField("birthdate", common.FieldMetadata{
+				DisplayName: "Birthdate",
+				ValueType:   common.ValueTypeDate,
+			})
+			metadata.AddField("donotcall", common.FieldMetadata{
+				DisplayName: "Do Not Call",
+				ValueType:   common.ValueTypeBoolean,
+			})
+			metadata.AddField("accountid", common.FieldMetadata{
+				DisplayName: "Account ID",
+				ValueType:   common.ValueTypeString,
+				Createable:  handy.Pointers.Bool(true),
+				Updateable:  handy.Pointers.Bool(false),
+			})
+			metadata.AddField("externalid", common.FieldMetadata{
+				DisplayName: "External ID",
+				ValueType:   common.ValueTypeString,
+			})
+			metadata.AddField("leadsource", common.FieldMetadata{
+				DisplayName: "Lead Source",
+				ValueType:   common.ValueTypeSingleSelect,
+				Values:      []common.FieldValue{{Value: "Web", DisplayValue: "Web"}},
+			})
+
+			return &ListObjectMetadataResult{
+				Result: map[string]common.ObjectMetadata{"contact": metadata},
+				Errors: map[string]error{},
+			}, nil
+		}),
+		mock.WithWrite(func(ctx context.Context, params common.WriteParams) (*common.WriteResult, error) {
+			*writes = append(*writes, params)
+
+			return &common.WriteResult{Success: true}, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create mock connector: %v", err)
+	}
+
+	return conn
+}
+
+func TestWriteValidator(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		params         WriteParams
+		opts           []WriteValidationOption
+		expectedData   any
+		expectedErrors FieldErrors
+	}{
+		{
+			name: "Values are coerced to field types",
+			params: WriteParams{ObjectName: "Contact", RecordData: map[string]any{
+				"LastName":          "Doe",
+				"NumberOfEmployees": "42",
+				"Birthdate":         "1990-05-17T10:00:00Z",
+				"DoNotCall":         "true",
+				"LeadSource":        "Web",
+			}},
+			expectedData: map[string]any{
+				"LastName":          "Doe",
+				"NumberOfEmployees": int64(42),
+				"Birthdate":         "1990-05-17",
+				"DoNotCall":         true,
+				"LeadSource":        "Web",
+			},
+		},
+		{
+			name: "Large numbers are not rounded",
+			params: WriteParams{ObjectName: "Contact", RecordData: struct {
+				LastName          string `json:"LastName"`
+				NumberOfEmployees int64  `json:"NumberOfEmployees"`
+			}{
+				LastName:          "Doe",
+				NumberOfEmployees: 9007199254740993,
+			}},
+			expectedData: map[string]any{
+				"LastName":          "Doe",
+				"NumberOfEmployees": int64(9007199254740993),
+			},
+		},
+		{
+			name: "Every problem is reported per field",
+			params: WriteParams{ObjectName: "Contact", RecordData: map[string]any{
+				"Id":                "003",
+				"Nickname":          "JD",
+				"NumberOfEmployees": "many",
+				"LeadSource":        "Fax",
+			}},
+			expectedErrors: FieldErrors{
+				{Field: "Id", Reason: FieldErrorReadOnly, Message: "field cannot be created"},
+				{Field: "LeadSource", Reason: FieldErrorInvalidValue, Message: `value "Fax" is not one of the options`},
+				{Field: "Nickname", Reason: FieldErrorUnknown, Message: "field doesn't exist on Contact"},
+				{Field: "NumberOfEmployees", Reason: FieldErrorInvalidValue, Message: `expected whole number, got "many"`},
+				{Field: "lastname", Reason: FieldErrorMissing, Message: "field is required"},
+			},
+		},
+		{
+			name: "Required fields are not enforced on update",
+			params: WriteParams{ObjectName: "Contact", RecordId: "003", RecordData: map[string]any{
+				"numberofemployees": 7.0,
+			}},
+			expectedData: map[string]any{
+				"numberofemployees": int64(7),
+			},
+		},
+		{
+			name: "Create-only fields cannot be updated",
+			params: WriteParams{ObjectName: "Contact", RecordId: "003", RecordData: map[string]any{
+				"AccountId": "001",
+			}},
+			expectedErrors: FieldErrors{
+				{Field: "AccountId", Reason: FieldErrorReadOnly, Message: "field cannot be updated"},
+			},
+		},
+		{
+			name: "Upsert is checked as create, external ID field counts as present",
+			params: WriteParams{
+				ObjectName:      "Contact",
+				Mode:            common.WriteModeUpsert,
+				ExternalIdField: "LastName",
+				RecordId:        "Doe",
+				RecordData:      map[string]any{"AccountId": "001"},
+			},
+			expectedData: map[string]any{"AccountId": "001"},
+		},
+		{
+			name: "Upsert may create the record, required fields are enforced",
+			params: WriteParams{
+				ObjectName:      "Contact",
+				Mode:            common.WriteModeUpsert,
+				ExternalIdField: "ExternalId",
+				RecordId:        "ext-1",
+				RecordData:      map[string]any{"Id": "003"},
+			},
+			expectedErrors: FieldErrors{
+				{Field: "Id", Reason: FieldErrorReadOnly, Message: "field cannot be created"},
+				{Field: "lastname", Reason: FieldErrorMissing, Message: "field is required"},
+			},
+		},
+		{
+			name: "Replace is checked as create",
+			params: WriteParams{
+				ObjectName: "Contact",
+				Mode:       common.WriteModeReplace,
+				RecordId:   "003",
+				RecordData: map[string]any{"AccountId": "001"},
+			},
+			expectedErrors: FieldErrors{
+				{Field: "lastname", Reason: FieldErrorMissing, Message: "field is required"},
+			},
+		},
+		{
+			name: "Cleared fields must exist and be writable",
+			params: WriteParams{
+				ObjectName:  "Contact",
+				RecordId:    "003",
+				ClearFields: []string{"AccountId", "Nickname", "Birthdate"},
+			},
+			expectedErrors: FieldErrors{
+				{Field: "AccountId", Reason: FieldErrorReadOnly, Message: "field cannot be updated"},
+				{Field: "Nickname", Reason: FieldErrorUnknown, Message: "field doesn't exist on Contact"},
+			},
+		},
+		{
+			name: "Record data stays empty when fields are only cleared",
+			params: WriteParams{
+				ObjectName:  "Contact",
+				RecordId:    "003",
+				ClearFields: []string{"Birthdate"},
+			},
+			expectedData: nil,
+		},
+		{
+			name: "Unknown fields can be allowed and coercion skipped",
+			params: WriteParams{ObjectName: "Contact", RecordData: map[string]any{
+				"LastName":          "Doe",
+				"Nickname":          "JD",
+				"NumberOfEmployees": "42",
+			}},
+			opts: []WriteValidationOption{WithUnknownFieldsAllowed(), WithoutCoercion()},
+			expectedData: map[string]any{
+				"LastName":          "Doe",
+				"Nickname":          "JD",
+				"NumberOfEmployees": "42",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		tt := tt // rebind, omit loop side effects for parallel goroutine
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				requests atomic.Int32
+				writes   []WriteParams
+			)
+
+			conn := NewValidatingWriteConnector(describedConnector(t, &requests, &writes), tt.opts...)
+
+			_, err := conn.Write(context.Background(), tt.params)
+
+			if tt.expectedErrors != nil {
+				var fieldErrors FieldErrors
+				if !errors.As(err, &fieldErrors) || !errors.Is(err, ErrInvalidRecordData) {
+					t.Fatalf("%s: expected field errors, got (%v)", tt.name, err)
+				}
+
+				if !reflect.DeepEqual(fieldErrors, tt.expectedErrors) {
+					t.Fatalf("%s: expected (%v), got (%v)", tt.name, tt.expectedErrors, fieldErrors)
+				}
+
+				if len(writes) != 0 {
+					t.Fatalf("%s: invalid record must not be written", tt.name)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+
+			if len(writes) != 1 || !reflect.DeepEqual(writes[0].RecordData, tt.expectedData) {
+				t.Fatalf("%s: expected (%v), got (%v)", tt.name, tt.expectedData, writes)
+			}
+		})
+	}
+}
+
+func TestWriteValidatorCachesMetadata(t *testing.T) {
+	t.Parallel()
+
+	var (
+		requests atomic.Int32
+		writes   []WriteParams
+	)
+
+	validator := NewWriteValidator(describedConnector(t, &requests, &writes))
+
+	for range 3 {
+		_, err := validator.Validate(context.Background(), WriteParams{
+			ObjectName: "Contact",
+			RecordData: map[string]any{"LastName": "Doe"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if requests.Load() != 1 {
+		t.Fatalf("expected metadata to be requested once, got (%v)", requests.Load())
+	}
+}